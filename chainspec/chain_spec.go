@@ -0,0 +1,91 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chainspec parses the chain spec JSON files used to start Substrate nodes, and serves the reads of their
+// raw genesis storage without running a node.
+package chainspec
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ChainSpec is a chain spec, as exported by the build-spec command of the nodes.
+type ChainSpec struct {
+	Name       string   `json:"name"`
+	ID         string   `json:"id"`
+	ChainType  string   `json:"chainType"`
+	BootNodes  []string `json:"bootNodes"`
+	ProtocolID string   `json:"protocolId"`
+	// Properties is the custom set of properties returned by system_properties, such as the token symbol.
+	Properties map[string]interface{} `json:"properties"`
+	Genesis    Genesis                `json:"genesis"`
+}
+
+// Genesis is the genesis of a chain spec. Only the raw genesis, which holds the storage entries, is decoded.
+type Genesis struct {
+	Raw *RawGenesis `json:"raw,omitempty"`
+}
+
+// RawGenesis holds the hex encoded keys and values of the genesis storage.
+type RawGenesis struct {
+	Top map[string]string `json:"top"`
+	// ChildrenDefault holds the default child tries by their unprefixed child key.
+	ChildrenDefault map[string]map[string]string `json:"childrenDefault"`
+}
+
+// Parse decodes the JSON chain spec.
+func Parse(data []byte) (*ChainSpec, error) {
+	var spec ChainSpec
+
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, ErrChainSpecDecoding.Wrap(err)
+	}
+
+	return &spec, nil
+}
+
+// ReadFile reads and decodes the JSON chain spec file.
+func ReadFile(path string) (*ChainSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrChainSpecRead.Wrap(err)
+	}
+
+	return Parse(data)
+}
+
+// Storage returns the storage of the raw genesis.
+func (c *ChainSpec) Storage() (*Storage, error) {
+	if c.Genesis.Raw == nil {
+		return nil, ErrGenesisNotRaw
+	}
+
+	return NewStorage(*c.Genesis.Raw)
+}
+
+// GenesisHash returns the hash of the genesis block, whose state is built with the state version of the runtime.
+func (c *ChainSpec) GenesisHash(version trie.StateVersion) (types.Hash, error) {
+	storage, err := c.Storage()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return storage.GenesisHash(version)
+}
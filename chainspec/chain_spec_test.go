@@ -0,0 +1,108 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainSpec = `{
+  "name": "Development",
+  "id": "dev",
+  "chainType": "Development",
+  "bootNodes": ["/ip4/127.0.0.1/tcp/30333/p2p/12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp"],
+  "telemetryEndpoints": null,
+  "protocolId": "dev",
+  "properties": {"ss58Format": 42, "tokenDecimals": 18, "tokenSymbol": "DEV"},
+  "codeSubstitutes": {},
+  "genesis": {
+    "raw": {
+      "top": {
+        "0x0102": "0x0a0b",
+        "0x0103": "0x0c",
+        "0x02": "0x2a000000"
+      },
+      "childrenDefault": {
+        "0x6368696c64": {"0x01": "0x0d"}
+      }
+    }
+  }
+}`
+
+func TestParse(t *testing.T) {
+	spec, err := Parse([]byte(testChainSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Development", spec.Name)
+	assert.Equal(t, "dev", spec.ID)
+	assert.Equal(t, "Development", spec.ChainType)
+	assert.Equal(t, "dev", spec.ProtocolID)
+	assert.Len(t, spec.BootNodes, 1)
+	assert.Equal(t, map[string]interface{}{
+		"ss58Format":    float64(42),
+		"tokenDecimals": float64(18),
+		"tokenSymbol":   "DEV",
+	}, spec.Properties)
+
+	require.NotNil(t, spec.Genesis.Raw)
+	assert.Equal(t, "0x0c", spec.Genesis.Raw.Top["0x0103"])
+	assert.Equal(t, map[string]string{"0x01": "0x0d"}, spec.Genesis.Raw.ChildrenDefault["0x6368696c64"])
+
+	_, err = Parse([]byte("{"))
+	assert.ErrorIs(t, err, ErrChainSpecDecoding)
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.json")
+	require.NoError(t, os.WriteFile(path, []byte(testChainSpec), 0600))
+
+	spec, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", spec.ID)
+
+	_, err = ReadFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, ErrChainSpecRead)
+}
+
+func TestChainSpec_GenesisHash(t *testing.T) {
+	spec, err := Parse([]byte(testChainSpec))
+	require.NoError(t, err)
+
+	storage, err := spec.Storage()
+	require.NoError(t, err)
+
+	expected, err := storage.GenesisHash(trie.StateVersionV1)
+	require.NoError(t, err)
+
+	hash, err := spec.GenesisHash(trie.StateVersionV1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, hash)
+
+	spec.Genesis.Raw = nil
+
+	_, err = spec.Storage()
+	assert.ErrorIs(t, err, ErrGenesisNotRaw)
+
+	_, err = spec.GenesisHash(trie.StateVersionV1)
+	assert.ErrorIs(t, err, ErrGenesisNotRaw)
+}
@@ -0,0 +1,287 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspec
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Storage is an in-memory storage holding the entries of a raw genesis. Its reads mirror the ones of the state RPC,
+// the child tries being identified by their prefixed child storage key.
+type Storage struct {
+	top      map[string][]byte
+	children map[string]map[string][]byte
+}
+
+// NewStorage creates a new Storage from the raw genesis.
+func NewStorage(raw RawGenesis) (*Storage, error) {
+	top, err := decodeEntries(raw.Top)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string]map[string][]byte, len(raw.ChildrenDefault))
+
+	for childKey, entries := range raw.ChildrenDefault {
+		key, err := codec.HexDecodeString(childKey)
+		if err != nil {
+			return nil, ErrInvalidHex.Wrap(err)
+		}
+
+		child, err := decodeEntries(entries)
+		if err != nil {
+			return nil, err
+		}
+
+		children[string(key)] = child
+	}
+
+	return &Storage{top: top, children: children}, nil
+}
+
+func decodeEntries(entries map[string]string) (map[string][]byte, error) {
+	res := make(map[string][]byte, len(entries))
+
+	for key, value := range entries {
+		k, err := codec.HexDecodeString(key)
+		if err != nil {
+			return nil, ErrInvalidHex.Wrap(err)
+		}
+
+		v, err := codec.HexDecodeString(value)
+		if err != nil {
+			return nil, ErrInvalidHex.Wrap(err)
+		}
+
+		res[string(k)] = v
+	}
+
+	return res, nil
+}
+
+// GetStorageRaw returns the stored data as raw bytes, which are empty if there is no value.
+func (s *Storage) GetStorageRaw(key types.StorageKey) *types.StorageDataRaw {
+	data := types.NewStorageDataRaw(s.top[string(key)])
+
+	return &data
+}
+
+// GetStorage decodes the stored data into the provided interface. Ok is true if the value is not empty.
+func (s *Storage) GetStorage(key types.StorageKey, target interface{}) (ok bool, err error) {
+	return decodeValue(s.top[string(key)], target)
+}
+
+// GetStorageEntry decodes the data stored for the storage entry of the module, with the provided keys for the
+// maps, into the provided interface. Ok is true if the value is not empty.
+func (s *Storage) GetStorageEntry(
+	meta *types.Metadata,
+	module, method string,
+	target interface{},
+	args ...[]byte,
+) (ok bool, err error) {
+	key, err := types.CreateStorageKey(meta, module, method, args...)
+	if err != nil {
+		return false, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	return s.GetStorage(key, target)
+}
+
+// GetStorageHash returns the blake2b-256 hash of the stored data, and an empty hash if there is no value.
+func (s *Storage) GetStorageHash(key types.StorageKey) (types.Hash, error) {
+	return hashValue(s.top[string(key)])
+}
+
+// GetStorageSize returns the size of the stored data.
+func (s *Storage) GetStorageSize(key types.StorageKey) types.U64 {
+	return types.U64(len(s.top[string(key)]))
+}
+
+// GetKeys returns the sorted keys with the provided prefix.
+func (s *Storage) GetKeys(prefix types.StorageKey) []types.StorageKey {
+	return keysWithPrefix(s.top, prefix)
+}
+
+// GetChildStorageRaw returns the data stored in the child trie as raw bytes, which are empty if there is no value.
+func (s *Storage) GetChildStorageRaw(childStorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
+	child, err := s.child(childStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	data := types.NewStorageDataRaw(child[string(key)])
+
+	return &data, nil
+}
+
+// GetChildStorage decodes the data stored in the child trie into the provided interface. Ok is true if the value is
+// not empty.
+func (s *Storage) GetChildStorage(childStorageKey, key types.StorageKey, target interface{}) (ok bool, err error) {
+	child, err := s.child(childStorageKey)
+	if err != nil {
+		return false, err
+	}
+
+	return decodeValue(child[string(key)], target)
+}
+
+// GetChildStorageHash returns the blake2b-256 hash of the data stored in the child trie, and an empty hash if there
+// is no value.
+func (s *Storage) GetChildStorageHash(childStorageKey, key types.StorageKey) (types.Hash, error) {
+	child, err := s.child(childStorageKey)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return hashValue(child[string(key)])
+}
+
+// GetChildKeys returns the sorted keys of the child trie with the provided prefix.
+func (s *Storage) GetChildKeys(childStorageKey, prefix types.StorageKey) ([]types.StorageKey, error) {
+	child, err := s.child(childStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return keysWithPrefix(child, prefix), nil
+}
+
+// child returns the entries of the child trie. An unknown child trie has no entries, as with the nodes.
+func (s *Storage) child(childStorageKey types.StorageKey) (map[string][]byte, error) {
+	childKey := string(childStorageKey)
+
+	if !strings.HasPrefix(childKey, trie.ChildStorageKeyPrefix) {
+		return nil, ErrInvalidChildStorageKey.WithMsg(
+			"%s has no %s prefix",
+			childStorageKey.Hex(),
+			trie.ChildStorageKeyPrefix,
+		)
+	}
+
+	return s.children[strings.TrimPrefix(childKey, trie.ChildStorageKeyPrefix)], nil
+}
+
+// Root returns the state root of the storage, built with the provided state version. The roots of the non-empty
+// child tries are stored in the top trie under their child storage key.
+func (s *Storage) Root(version trie.StateVersion) (types.Hash, error) {
+	entries := trieEntries(s.top)
+
+	for childKey, child := range s.children {
+		if len(child) == 0 {
+			continue
+		}
+
+		root, err := trie.Root(trieEntries(child), version)
+		if err != nil {
+			return types.Hash{}, ErrRootComputation.Wrap(err)
+		}
+
+		entries = append(entries, trie.KeyValue{Key: trie.NewChildStorageKey([]byte(childKey)), Value: root[:]})
+	}
+
+	root, err := trie.Root(entries, version)
+	if err != nil {
+		return types.Hash{}, ErrRootComputation.Wrap(err)
+	}
+
+	return root, nil
+}
+
+// GenesisHeader returns the header of the genesis block, which has the root of the storage as state root and no
+// extrinsics.
+func (s *Storage) GenesisHeader(version trie.StateVersion) (types.Header, error) {
+	root, err := s.Root(version)
+	if err != nil {
+		return types.Header{}, err
+	}
+
+	return types.Header{
+		Number:         0,
+		StateRoot:      root,
+		ExtrinsicsRoot: trie.EmptyRoot,
+	}, nil
+}
+
+// GenesisHash returns the hash of the genesis block, to be compared with the hash of the block 0 of a running chain.
+func (s *Storage) GenesisHash(version trie.StateVersion) (types.Hash, error) {
+	header, err := s.GenesisHeader(version)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return header.Hash()
+}
+
+func trieEntries(entries map[string][]byte) []trie.KeyValue {
+	res := make([]trie.KeyValue, 0, len(entries))
+
+	for key, value := range entries {
+		res = append(res, trie.KeyValue{Key: []byte(key), Value: value})
+	}
+
+	return res
+}
+
+func keysWithPrefix(entries map[string][]byte, prefix types.StorageKey) []types.StorageKey {
+	keys := make([]types.StorageKey, 0)
+
+	for key := range entries {
+		if strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, types.NewStorageKey([]byte(key)))
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	return keys
+}
+
+func decodeValue(value []byte, target interface{}) (bool, error) {
+	if len(value) == 0 {
+		return false, nil
+	}
+
+	if err := codec.Decode(value, target); err != nil {
+		return false, ErrStorageDecoding.Wrap(err)
+	}
+
+	return true, nil
+}
+
+func hashValue(value []byte) (types.Hash, error) {
+	if value == nil {
+		return types.Hash{}, nil
+	}
+
+	h, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	h.Write(value)
+
+	return types.NewHash(h.Sum(nil)), nil
+}
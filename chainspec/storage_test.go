@@ -0,0 +1,197 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspec
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStorage_InvalidHex(t *testing.T) {
+	_, err := NewStorage(RawGenesis{Top: map[string]string{"0x01": "0xzz"}})
+	assert.ErrorIs(t, err, ErrInvalidHex)
+
+	_, err = NewStorage(RawGenesis{Top: map[string]string{"0xzz": "0x01"}})
+	assert.ErrorIs(t, err, ErrInvalidHex)
+
+	_, err = NewStorage(RawGenesis{ChildrenDefault: map[string]map[string]string{"0xzz": {}}})
+	assert.ErrorIs(t, err, ErrInvalidHex)
+}
+
+func TestStorage_GetStorage(t *testing.T) {
+	storage, err := NewStorage(RawGenesis{
+		Top: map[string]string{"0x0102": "0x0a0b", "0x0103": "0x0c", "0x02": "0x2a000000"},
+	})
+	require.NoError(t, err)
+
+	key := types.NewStorageKey([]byte{0x02})
+
+	assert.Equal(t, types.StorageDataRaw{0x2a, 0, 0, 0}, *storage.GetStorageRaw(key))
+	assert.Equal(t, types.U64(4), storage.GetStorageSize(key))
+
+	var value types.U32
+
+	ok, err := storage.GetStorage(key, &value)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(42), value)
+
+	missing := types.NewStorageKey([]byte{0x03})
+
+	assert.Empty(t, *storage.GetStorageRaw(missing))
+
+	ok, err = storage.GetStorage(missing, &value)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	var hash types.Hash
+
+	ok, err = storage.GetStorage(key, &hash)
+	assert.ErrorIs(t, err, ErrStorageDecoding)
+	assert.False(t, ok)
+
+	hash, err = storage.GetStorageHash(key)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		types.NewHash(codec.MustHexDecodeString("0xde037c7466270a63a0f6cc9a3b5ad1a3067a64a0b7e626f4ea9e95cd6533ed89")),
+		hash,
+	)
+
+	hash, err = storage.GetStorageHash(missing)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash{}, hash)
+
+	assert.Equal(t, []types.StorageKey{{0x01, 0x02}, {0x01, 0x03}}, storage.GetKeys(types.NewStorageKey([]byte{0x01})))
+	assert.Len(t, storage.GetKeys(nil), 3)
+	assert.Empty(t, storage.GetKeys(types.NewStorageKey([]byte{0x04})))
+}
+
+func TestStorage_GetStorageEntry(t *testing.T) {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	key, err := types.CreateStorageKey(&meta, "System", "Number")
+	require.NoError(t, err)
+
+	storage, err := NewStorage(RawGenesis{Top: map[string]string{key.Hex(): "0x07000000"}})
+	require.NoError(t, err)
+
+	var number types.U32
+
+	ok, err := storage.GetStorageEntry(&meta, "System", "Number", &number)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(7), number)
+
+	_, err = storage.GetStorageEntry(&meta, "System", "Unknown", &number)
+	assert.ErrorIs(t, err, ErrStorageKeyCreation)
+}
+
+func TestStorage_GetChildStorage(t *testing.T) {
+	storage, err := NewStorage(RawGenesis{ChildrenDefault: map[string]map[string]string{"0x6368696c64": {"0x01": "0x0d"}}})
+	require.NoError(t, err)
+
+	childStorageKey := trie.NewChildStorageKey([]byte("child"))
+	key := types.NewStorageKey([]byte{0x01})
+
+	raw, err := storage.GetChildStorageRaw(childStorageKey, key)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StorageDataRaw{0x0d}, *raw)
+
+	var value types.U8
+
+	ok, err := storage.GetChildStorage(childStorageKey, key, &value)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U8(0x0d), value)
+
+	expected, err := storage.GetChildStorageHash(childStorageKey, key)
+	assert.NoError(t, err)
+	assert.NotEqual(t, types.Hash{}, expected)
+
+	keys, err := storage.GetChildKeys(childStorageKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageKey{key}, keys)
+
+	// An unknown child trie has no entries.
+	raw, err = storage.GetChildStorageRaw(trie.NewChildStorageKey([]byte("other")), key)
+	assert.NoError(t, err)
+	assert.Empty(t, *raw)
+
+	_, err = storage.GetChildStorageRaw(types.NewStorageKey([]byte("child")), key)
+	assert.ErrorIs(t, err, ErrInvalidChildStorageKey)
+
+	_, err = storage.GetChildStorage(types.NewStorageKey([]byte("child")), key, &value)
+	assert.ErrorIs(t, err, ErrInvalidChildStorageKey)
+
+	_, err = storage.GetChildStorageHash(types.NewStorageKey([]byte("child")), key)
+	assert.ErrorIs(t, err, ErrInvalidChildStorageKey)
+
+	_, err = storage.GetChildKeys(types.NewStorageKey([]byte("child")), nil)
+	assert.ErrorIs(t, err, ErrInvalidChildStorageKey)
+}
+
+func TestStorage_Root(t *testing.T) {
+	spec, err := Parse([]byte(testChainSpec))
+	require.NoError(t, err)
+
+	storage, err := spec.Storage()
+	require.NoError(t, err)
+
+	for _, version := range []trie.StateVersion{trie.StateVersionV0, trie.StateVersionV1} {
+		childRoot, err := trie.Root([]trie.KeyValue{{Key: []byte{0x01}, Value: []byte{0x0d}}}, version)
+		require.NoError(t, err)
+
+		expected, err := trie.Root([]trie.KeyValue{
+			{Key: []byte{0x01, 0x02}, Value: []byte{0x0a, 0x0b}},
+			{Key: []byte{0x01, 0x03}, Value: []byte{0x0c}},
+			{Key: []byte{0x02}, Value: []byte{0x2a, 0, 0, 0}},
+			{Key: trie.NewChildStorageKey([]byte("child")), Value: childRoot[:]},
+		}, version)
+		require.NoError(t, err)
+
+		root, err := storage.Root(version)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, root)
+
+		header, err := storage.GenesisHeader(version)
+		assert.NoError(t, err)
+		assert.Equal(t, types.Header{StateRoot: expected, ExtrinsicsRoot: trie.EmptyRoot}, header)
+
+		expectedHash, err := header.Hash()
+		require.NoError(t, err)
+
+		hash, err := storage.GenesisHash(version)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedHash, hash)
+	}
+
+	// The empty child tries are not stored in the top trie.
+	empty, err := NewStorage(RawGenesis{ChildrenDefault: map[string]map[string]string{"0x01": {}}})
+	require.NoError(t, err)
+
+	root, err := empty.Root(trie.StateVersionV1)
+	assert.NoError(t, err)
+	assert.Equal(t, trie.EmptyRoot, root)
+}
@@ -0,0 +1,51 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// DefaultBatchSize is the default maximum number of requests sent in a single batch by BatchCallInChunks.
+const DefaultBatchSize = 100
+
+// BatchCallInChunks sends the requests in consecutive batches of at most batchSize requests, which keeps the
+// batches under the request and response size limits of the nodes. A batchSize of 0 means DefaultBatchSize.
+//
+// The error of each request is set in the Error field of its BatchElem. The returned error is only set for I/O
+// errors, in which case the requests of the failed batch and of the following ones were not sent.
+func BatchCallInChunks(ctx context.Context, c Client, b []gethrpc.BatchElem, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	for start := 0; start < len(b); start += batchSize {
+		end := start + batchSize
+
+		if end > len(b) {
+			end = len(b)
+		}
+
+		if err := c.BatchCallContext(ctx, b[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
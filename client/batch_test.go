@@ -0,0 +1,88 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCallInChunks(t *testing.T) {
+	srv, chainService := newTestServer(t)
+
+	chainService.produce(4)
+
+	clients := map[string]Client{}
+
+	cl, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(cl.Close)
+
+	reconnectingCl, err := ConnectWithReconnect(srv.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(reconnectingCl.Close)
+
+	clients["client"] = cl
+	clients["reconnecting client"] = reconnectingCl
+
+	for name, cl := range clients {
+		t.Run(name, func(t *testing.T) {
+			// Blocks #0 to #4 are known, #5 is not.
+			headers := make([]types.Header, 6)
+			batch := make([]gethrpc.BatchElem, len(headers))
+
+			for i := range batch {
+				batch[i] = gethrpc.BatchElem{
+					Method: "chain_getHeader",
+					Args:   []interface{}{testHeaderHash(types.BlockNumber(i)).Hex()},
+					Result: &headers[i],
+				}
+			}
+
+			err := BatchCallInChunks(context.Background(), cl, batch, 4)
+			assert.NoError(t, err)
+
+			for i := 0; i < 5; i++ {
+				assert.NoError(t, batch[i].Error)
+				assert.Equal(t, types.BlockNumber(i), headers[i].Number)
+			}
+
+			assert.EqualError(t, batch[5].Error, "header not found")
+		})
+	}
+}
+
+func TestBatchCallInChunks_Closed(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	cl, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	cl.Close()
+
+	var res string
+
+	err = BatchCallInChunks(context.Background(), cl, []gethrpc.BatchElem{{Method: "chain_getHeader", Result: &res}}, 0)
+	assert.Error(t, err)
+}
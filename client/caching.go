@@ -0,0 +1,539 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const getBlockHashMethod = "chain_getBlockHash"
+
+// ErrGenesisHashNotFound is returned when creating a caching client for a node that does not return its genesis hash.
+var ErrGenesisHashNotFound = errors.New("genesis hash not found")
+
+// CacheStats holds the statistics of a caching client.
+type CacheStats struct {
+	// Hits is the number of responses served from the cache, including the ones read from disk.
+	Hits uint64
+	// DiskHits is the number of responses served from the on-disk cache.
+	DiskHits uint64
+	// Misses is the number of cacheable requests that were sent to the node.
+	Misses uint64
+	// Bypassed is the number of requests that were not cacheable, such as the ones made at the latest block.
+	Bypassed uint64
+	// Evictions is the number of responses evicted from memory.
+	Evictions uint64
+	// DiskErrors is the number of failed reads and writes of the on-disk cache.
+	DiskErrors uint64
+	// Entries is the number of responses kept in memory.
+	Entries int
+	// Size is the total size in bytes of the responses kept in memory.
+	Size int
+}
+
+// HitRatio returns the ratio of cacheable requests that were served from the cache.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// CachingClient is a Client that caches the responses of the queries that can never change, which are the ones made
+// at a block hash and the block hashes of finalized block numbers.
+type CachingClient interface {
+	Client
+
+	// CacheStats returns the current statistics of the cache.
+	CacheStats() CacheStats
+}
+
+// cachingClient implements the CachingClient interface.
+type cachingClient struct {
+	Client
+
+	opts *CacheOpts
+	// genesisHash is the hex encoded genesis hash of the chain, which prefixes the cache keys so that the
+	// responses of different chains sharing a cache directory are kept apart.
+	genesisHash string
+
+	mu    sync.Mutex
+	lru   *lruCache
+	stats CacheStats
+
+	finalizedMu        sync.Mutex
+	finalized          uint64
+	finalizedKnown     bool
+	finalizedRefreshed time.Time
+}
+
+// NewCachingClient wraps the provided client with a cache of the responses of the immutable queries, as configured
+// by the options. Requests made at the latest block, or for the block hash of a block number that is not finalized,
+// are always sent to the node. Subscriptions are not cached.
+//
+// The genesis hash of the chain is retrieved from the node, and namespaces the cached responses.
+func NewCachingClient(c Client, opts ...CacheOptsFn) (CachingClient, error) {
+	cacheOpts := NewDefaultCacheOpts()
+
+	for _, opt := range opts {
+		opt(cacheOpts)
+	}
+
+	if cacheOpts.dir != "" {
+		if err := os.MkdirAll(cacheOpts.dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	var genesisHash string
+
+	if err := c.CallContext(context.Background(), &genesisHash, getBlockHashMethod, 0); err != nil {
+		return nil, err
+	}
+
+	if genesisHash == "" {
+		return nil, ErrGenesisHashNotFound
+	}
+
+	return &cachingClient{
+		Client:      c,
+		opts:        cacheOpts,
+		genesisHash: genesisHash,
+		lru:         newLRUCache(cacheOpts.maxSize),
+	}, nil
+}
+
+// CacheStats returns the current statistics of the cache.
+func (c *cachingClient) CacheStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.len()
+	stats.Size = c.lru.size
+
+	return stats
+}
+
+func (c *cachingClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *cachingClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	key, ok := c.cacheKey(ctx, method, args)
+
+	if !ok {
+		c.count(func(stats *CacheStats) { stats.Bypassed++ })
+
+		return c.Client.CallContext(ctx, result, method, args...)
+	}
+
+	if raw, ok := c.get(key); ok {
+		return json.Unmarshal(raw, &result)
+	}
+
+	var raw json.RawMessage
+
+	if err := c.Client.CallContext(ctx, &raw, method, args...); err != nil {
+		return err
+	}
+
+	c.put(key, raw)
+
+	return json.Unmarshal(raw, &result)
+}
+
+func (c *cachingClient) BatchCall(b []gethrpc.BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+// BatchCallContext serves the cached requests of the batch from the cache and sends the remaining ones to the node
+// as a single batch.
+func (c *cachingClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	var (
+		pending []gethrpc.BatchElem
+		indices []int
+		keys    []string
+	)
+
+	for i := range b {
+		key, ok := c.cacheKey(ctx, b[i].Method, b[i].Args)
+
+		if !ok {
+			c.count(func(stats *CacheStats) { stats.Bypassed++ })
+		} else if raw, ok := c.get(key); ok {
+			if b[i].Result != nil {
+				b[i].Error = json.Unmarshal(raw, b[i].Result)
+			}
+
+			continue
+		}
+
+		pending = append(pending, gethrpc.BatchElem{
+			Method: b[i].Method,
+			Args:   b[i].Args,
+			Result: new(json.RawMessage),
+		})
+		indices = append(indices, i)
+		keys = append(keys, key)
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := c.Client.BatchCallContext(ctx, pending); err != nil {
+		return err
+	}
+
+	for j, elem := range pending {
+		i := indices[j]
+
+		if elem.Error != nil {
+			b[i].Error = elem.Error
+			continue
+		}
+
+		raw := *elem.Result.(*json.RawMessage)
+
+		if keys[j] != "" {
+			c.put(keys[j], raw)
+		}
+
+		if b[i].Result != nil {
+			b[i].Error = json.Unmarshal(raw, b[i].Result)
+		}
+	}
+
+	return nil
+}
+
+// cacheKey returns the key of the cached response of the request, prefixed by the genesis hash of the chain, and
+// false if the response can change over time.
+func (c *cachingClient) cacheKey(ctx context.Context, method string, args []interface{}) (string, bool) {
+	key, ok := c.requestKey(ctx, method, args)
+	if !ok {
+		return "", false
+	}
+
+	return c.genesisHash + "/" + key, true
+}
+
+// requestKey returns the key of the request within the chain, and false if its response can change over time.
+func (c *cachingClient) requestKey(ctx context.Context, method string, args []interface{}) (string, bool) {
+	if method == getBlockHashMethod {
+		return c.blockHashCacheKey(ctx, args)
+	}
+
+	blockHashArg, ok := c.opts.methods[method]
+
+	if !ok || len(args) <= blockHashArg || isNilArg(args[blockHashArg]) {
+		return "", false
+	}
+
+	encodedArgs, err := json.Marshal(args)
+
+	if err != nil {
+		return "", false
+	}
+
+	return method + string(encodedArgs), true
+}
+
+// blockHashCacheKey returns the key of the cached block hash of a block number, which is only cacheable once the
+// block is finalized.
+func (c *cachingClient) blockHashCacheKey(ctx context.Context, args []interface{}) (string, bool) {
+	if len(args) != 1 || isNilArg(args[0]) {
+		return "", false
+	}
+
+	number, ok := parseBlockNumberArg(args[0])
+
+	if !ok || !c.isFinalized(ctx, number) {
+		return "", false
+	}
+
+	return getBlockHashMethod + "[" + strconv.FormatUint(number, 10) + "]", true
+}
+
+// isFinalized returns true if the block number is known to be finalized. The finalized head is retrieved again
+// for block numbers above the last known one, at most once per refresh interval.
+func (c *cachingClient) isFinalized(ctx context.Context, number uint64) bool {
+	c.finalizedMu.Lock()
+	defer c.finalizedMu.Unlock()
+
+	if c.finalizedKnown && number <= c.finalized {
+		return true
+	}
+
+	if time.Since(c.finalizedRefreshed) < c.opts.finalizedRefreshInterval {
+		return false
+	}
+
+	c.finalizedRefreshed = time.Now()
+
+	var finalizedHash string
+
+	if err := c.Client.CallContext(ctx, &finalizedHash, "chain_getFinalizedHead"); err != nil {
+		return false
+	}
+
+	var header types.Header
+
+	if err := c.Client.CallContext(ctx, &header, "chain_getHeader", finalizedHash); err != nil {
+		return false
+	}
+
+	c.finalized = uint64(header.Number)
+	c.finalizedKnown = true
+
+	return number <= c.finalized
+}
+
+// get returns the cached response for the key, either from memory or from disk.
+func (c *cachingClient) get(key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+
+	if raw, ok := c.lru.get(key); ok {
+		c.stats.Hits++
+		c.mu.Unlock()
+
+		return raw, true
+	}
+
+	c.mu.Unlock()
+
+	raw, err := c.readFile(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case err == nil:
+		c.stats.Hits++
+		c.stats.DiskHits++
+		c.stats.Evictions += uint64(c.lru.add(key, raw))
+
+		return raw, true
+	case !errors.Is(err, fs.ErrNotExist):
+		c.stats.DiskErrors++
+	}
+
+	c.stats.Misses++
+
+	return nil, false
+}
+
+// put caches the response for the key, unless it is empty, since the node might not know about the block yet.
+func (c *cachingClient) put(key string, raw json.RawMessage) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+
+	raw = append(json.RawMessage{}, raw...)
+
+	err := c.writeFile(key, raw)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.stats.DiskErrors++
+	}
+
+	c.stats.Evictions += uint64(c.lru.add(key, raw))
+}
+
+func (c *cachingClient) count(fn func(stats *CacheStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fn(&c.stats)
+}
+
+func (c *cachingClient) readFile(key string) (json.RawMessage, error) {
+	if c.opts.dir == "" {
+		return nil, fs.ErrNotExist
+	}
+
+	return os.ReadFile(c.filePath(key))
+}
+
+// writeFile stores the response in the cache directory, through a temporary file that is renamed so that
+// the other readers of the directory never see a partial response.
+func (c *cachingClient) writeFile(key string, raw json.RawMessage) error {
+	if c.opts.dir == "" {
+		return nil
+	}
+
+	f, err := os.CreateTemp(c.opts.dir, ".tmp-*")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(raw); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+
+		return err
+	}
+
+	return os.Rename(f.Name(), c.filePath(key))
+}
+
+func (c *cachingClient) filePath(key string) string {
+	h := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.opts.dir, hex.EncodeToString(h[:]))
+}
+
+// parseBlockNumberArg parses a block number argument, which is either a JSON number or a hex encoded string.
+func parseBlockNumberArg(arg interface{}) (uint64, bool) {
+	encoded, err := json.Marshal(arg)
+
+	if err != nil {
+		return 0, false
+	}
+
+	var s string
+
+	if err := json.Unmarshal(encoded, &s); err != nil {
+		number, err := strconv.ParseUint(string(encoded), 10, 64)
+
+		return number, err == nil
+	}
+
+	number, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+
+	return number, err == nil
+}
+
+func isNilArg(arg interface{}) bool {
+	if arg == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(arg)
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// lruCache is a cache of responses bounded by their total size, which evicts the least recently used ones first.
+// It is not safe for concurrent use.
+type lruCache struct {
+	maxSize int
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key string
+	raw json.RawMessage
+}
+
+func newLRUCache(maxSize int) *lruCache {
+	return &lruCache{
+		maxSize: maxSize,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (l *lruCache) get(key string) (json.RawMessage, bool) {
+	elem, ok := l.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	l.order.MoveToFront(elem)
+
+	return elem.Value.(*lruEntry).raw, true
+}
+
+// add adds the response to the cache and returns the number of evicted responses. Responses larger than the
+// maximum size are not added.
+func (l *lruCache) add(key string, raw json.RawMessage) int {
+	entrySize := len(key) + len(raw)
+
+	if entrySize > l.maxSize {
+		return 0
+	}
+
+	if elem, ok := l.entries[key]; ok {
+		l.remove(elem)
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, raw: raw})
+	l.size += entrySize
+
+	evicted := 0
+
+	for l.size > l.maxSize {
+		l.remove(l.order.Back())
+		evicted++
+	}
+
+	return evicted
+}
+
+func (l *lruCache) remove(elem *list.Element) {
+	entry := l.order.Remove(elem).(*lruEntry)
+
+	delete(l.entries, entry.key)
+
+	l.size -= len(entry.key) + len(entry.raw)
+}
+
+func (l *lruCache) len() int {
+	return l.order.Len()
+}
@@ -0,0 +1,102 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+)
+
+const (
+	defaultCacheMaxSize             = 64 << 20
+	defaultFinalizedRefreshInterval = time.Second
+)
+
+// CacheOpts holds the options of a caching client.
+type CacheOpts struct {
+	maxSize                  int
+	dir                      string
+	finalizedRefreshInterval time.Duration
+
+	// methods maps the cached methods to the position of their block hash argument.
+	methods map[string]int
+}
+
+// NewDefaultCacheOpts returns the default options of a caching client:
+//
+//   - up to 64 MiB of responses are kept in memory, without on-disk persistence.
+//   - the metadata, runtime version, block, header and storage queries are cached when made at a block hash.
+//   - the block hashes of finalized block numbers are cached, with the finalized head being refreshed at most once
+//     per second.
+func NewDefaultCacheOpts() *CacheOpts {
+	return &CacheOpts{
+		maxSize:                  defaultCacheMaxSize,
+		finalizedRefreshInterval: defaultFinalizedRefreshInterval,
+		methods: map[string]int{
+			"state_getMetadata":       0,
+			"state_getRuntimeVersion": 0,
+			"chain_getBlock":          0,
+			"chain_getHeader":         0,
+			"state_getStorage":        1,
+			"state_getStorageHash":    1,
+			"state_getStorageSize":    1,
+			"state_getKeys":           1,
+			"state_call":              2,
+			"state_getChildStorage":   2,
+		},
+	}
+}
+
+// CacheOptsFn is function that sets an option of a caching client.
+type CacheOptsFn func(opts *CacheOpts)
+
+// WithCacheMaxSize sets the maximum total size in bytes of the responses kept in memory. The least recently used
+// responses are evicted first.
+func WithCacheMaxSize(maxSize int) CacheOptsFn {
+	return func(opts *CacheOpts) {
+		opts.maxSize = maxSize
+	}
+}
+
+// WithCacheDir enables the on-disk persistence of the cached responses in the provided directory, which is created
+// if needed. The responses evicted from memory are kept on disk, and the directory can be shared by consecutive runs,
+// as well as by the clients of different chains since the responses are namespaced by genesis hash.
+func WithCacheDir(dir string) CacheOptsFn {
+	return func(opts *CacheOpts) {
+		opts.dir = dir
+	}
+}
+
+// WithFinalizedRefreshInterval sets the minimum interval between two retrievals of the finalized head, which are
+// made when querying the block hash of a block number that is not known to be finalized.
+func WithFinalizedRefreshInterval(interval time.Duration) CacheOptsFn {
+	return func(opts *CacheOpts) {
+		opts.finalizedRefreshInterval = interval
+	}
+}
+
+// WithCachedMethod enables the caching of the responses of a method when it is called with the block hash argument
+// found at the provided position. A negative position disables the caching of the method.
+func WithCachedMethod(method string, blockHashArg int) CacheOptsFn {
+	return func(opts *CacheOpts) {
+		if blockHashArg < 0 {
+			delete(opts.methods, method)
+			return
+		}
+
+		opts.methods[method] = blockHashArg
+	}
+}
@@ -0,0 +1,316 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingClient_BlockHashQueries(t *testing.T) {
+	counter, _ := newTestCountingClient(t)
+
+	cl, err := NewCachingClient(counter)
+	require.NoError(t, err)
+
+	hash := testHeaderHash(1).Hex()
+
+	for i := 0; i < 3; i++ {
+		var header types.Header
+
+		err := cl.Call(&header, "chain_getHeader", hash)
+		assert.NoError(t, err)
+		assert.Equal(t, types.BlockNumber(1), header.Number)
+	}
+
+	assert.Equal(t, 1, counter.count("chain_getHeader"))
+
+	// The latest header is never cached.
+	for i := 0; i < 2; i++ {
+		var header types.Header
+
+		err := cl.Call(&header, "chain_getHeader")
+		assert.NoError(t, err)
+		assert.Equal(t, types.BlockNumber(4), header.Number)
+	}
+
+	assert.Equal(t, 3, counter.count("chain_getHeader"))
+
+	// Errors are not cached.
+	for i := 0; i < 2; i++ {
+		var header types.Header
+
+		err := cl.Call(&header, "chain_getHeader", testHeaderHash(10).Hex())
+		assert.EqualError(t, err, "header not found")
+	}
+
+	assert.Equal(t, 5, counter.count("chain_getHeader"))
+
+	assert.Equal(t, CacheStats{
+		Hits:     2,
+		Misses:   3,
+		Bypassed: 2,
+		Entries:  1,
+		Size:     cl.CacheStats().Size,
+	}, cl.CacheStats())
+	assert.InDelta(t, 0.4, cl.CacheStats().HitRatio(), 1e-9)
+}
+
+func TestCachingClient_FinalizedBlockHashes(t *testing.T) {
+	counter, chainService := newTestCountingClient(t)
+
+	cl, err := NewCachingClient(counter, WithFinalizedRefreshInterval(0))
+	require.NoError(t, err)
+
+	chainService.finalize(2)
+
+	getBlockHash := func(number interface{}) types.Hash {
+		var hash string
+
+		err := cl.Call(&hash, "chain_getBlockHash", number)
+		require.NoError(t, err)
+
+		res, err := types.NewHashFromHexString(hash)
+		require.NoError(t, err)
+
+		return res
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, testHeaderHash(2), getBlockHash(uint64(2)))
+		assert.Equal(t, testHeaderHash(2), getBlockHash(types.BlockNumber(2)))
+		assert.Equal(t, testHeaderHash(3), getBlockHash(uint64(3)))
+	}
+
+	// #2 is only retrieved once, while #3 is not finalized yet. The genesis hash is retrieved when creating the client.
+	assert.Equal(t, 4, counter.count("chain_getBlockHash"))
+
+	chainService.finalize(3)
+
+	assert.Equal(t, testHeaderHash(3), getBlockHash(uint64(3)))
+	assert.Equal(t, testHeaderHash(3), getBlockHash(uint64(3)))
+
+	assert.Equal(t, 5, counter.count("chain_getBlockHash"))
+}
+
+func TestCachingClient_Eviction(t *testing.T) {
+	counter, _ := newTestCountingClient(t)
+
+	cl, err := NewCachingClient(counter)
+	require.NoError(t, err)
+
+	var header types.Header
+
+	err = cl.Call(&header, "chain_getHeader", testHeaderHash(1).Hex())
+	require.NoError(t, err)
+
+	entrySize := cl.CacheStats().Size
+
+	counter, _ = newTestCountingClient(t)
+
+	cl, err = NewCachingClient(counter, WithCacheMaxSize(2*entrySize))
+	require.NoError(t, err)
+
+	for _, number := range []types.BlockNumber{1, 2, 1, 3, 1, 2} {
+		err := cl.Call(&header, "chain_getHeader", testHeaderHash(number).Hex())
+		require.NoError(t, err)
+		assert.Equal(t, number, header.Number)
+	}
+
+	// #2 is evicted when adding #3, since #1 was used more recently.
+	assert.Equal(t, 4, counter.count("chain_getHeader"))
+
+	stats := cl.CacheStats()
+	assert.Equal(t, uint64(2), stats.Evictions)
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, 2*entrySize, stats.Size)
+}
+
+func TestCachingClient_Persistence(t *testing.T) {
+	dir := t.TempDir()
+
+	counter, _ := newTestCountingClient(t)
+
+	cl, err := NewCachingClient(counter, WithCacheDir(dir))
+	require.NoError(t, err)
+
+	var header types.Header
+
+	err = cl.Call(&header, "chain_getHeader", testHeaderHash(1).Hex())
+	require.NoError(t, err)
+
+	counter, _ = newTestCountingClient(t)
+
+	cl, err = NewCachingClient(counter, WithCacheDir(dir))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		err = cl.Call(&header, "chain_getHeader", testHeaderHash(1).Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, types.BlockNumber(1), header.Number)
+	}
+
+	assert.Equal(t, 0, counter.count("chain_getHeader"))
+
+	stats := cl.CacheStats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.DiskHits)
+	assert.Equal(t, uint64(0), stats.DiskErrors)
+}
+
+func TestCachingClient_SharedDir(t *testing.T) {
+	dir := t.TempDir()
+
+	srv, chainService := newTestServer(t)
+	chainService.produce(4)
+	chainService.finalize(4)
+
+	otherSrv, otherChainService := newTestServer(t)
+	otherChainService.produce(4)
+	otherChainService.finalize(4)
+	otherChainService.genesis = types.Hash{0xff}
+
+	for _, test := range []struct {
+		srv      *rpcmocksrv.Server
+		misses   uint64
+		diskHits uint64
+	}{
+		{srv: srv, misses: 2},
+		// The responses of the other chain are not read from the shared directory.
+		{srv: otherSrv, misses: 2},
+		{srv: srv, diskHits: 2},
+	} {
+		inner, err := Connect(test.srv.URL)
+		require.NoError(t, err)
+
+		defer inner.Close()
+
+		cl, err := NewCachingClient(inner, WithCacheDir(dir), WithFinalizedRefreshInterval(0))
+		require.NoError(t, err)
+
+		var header types.Header
+
+		err = cl.Call(&header, "chain_getHeader", testHeaderHash(1).Hex())
+		require.NoError(t, err)
+
+		var hash string
+
+		err = cl.Call(&hash, "chain_getBlockHash", 2)
+		require.NoError(t, err)
+
+		stats := cl.CacheStats()
+		assert.Equal(t, test.misses, stats.Misses)
+		assert.Equal(t, test.diskHits, stats.DiskHits)
+	}
+}
+
+func TestCachingClient_BatchCall(t *testing.T) {
+	counter, _ := newTestCountingClient(t)
+
+	cl, err := NewCachingClient(counter)
+	require.NoError(t, err)
+
+	var header types.Header
+
+	err = cl.Call(&header, "chain_getHeader", testHeaderHash(1).Hex())
+	require.NoError(t, err)
+
+	headers := make([]types.Header, 4)
+
+	batch := []gethrpc.BatchElem{
+		{Method: "chain_getHeader", Args: []interface{}{testHeaderHash(1).Hex()}, Result: &headers[0]},
+		{Method: "chain_getHeader", Args: []interface{}{testHeaderHash(2).Hex()}, Result: &headers[1]},
+		{Method: "chain_getHeader", Result: &headers[2]},
+		{Method: "chain_getHeader", Args: []interface{}{testHeaderHash(10).Hex()}, Result: &headers[3]},
+	}
+
+	err = cl.BatchCall(batch)
+	assert.NoError(t, err)
+
+	for i, number := range []types.BlockNumber{1, 2, 4} {
+		assert.NoError(t, batch[i].Error)
+		assert.Equal(t, number, headers[i].Number)
+	}
+
+	assert.EqualError(t, batch[3].Error, "header not found")
+
+	// The first request was served from the cache.
+	assert.Equal(t, 1, counter.count("chain_getHeader"))
+	assert.Equal(t, 3, counter.batched)
+
+	err = cl.Call(&header, "chain_getHeader", testHeaderHash(2).Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, counter.count("chain_getHeader"))
+}
+
+// newTestCountingClient connects a countingClient to a new test server that produced 4 blocks.
+func newTestCountingClient(t *testing.T) (*countingClient, *testChainService) {
+	srv, chainService := newTestServer(t)
+
+	chainService.produce(4)
+
+	inner, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(inner.Close)
+
+	return &countingClient{Client: inner, calls: make(map[string]int)}, chainService
+}
+
+// countingClient is a Client that counts the calls made per method.
+type countingClient struct {
+	Client
+
+	mu      sync.Mutex
+	calls   map[string]int
+	batched int
+}
+
+func (c *countingClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	c.mu.Lock()
+	c.calls[method]++
+	c.mu.Unlock()
+
+	return c.Client.CallContext(ctx, result, method, args...)
+}
+
+func (c *countingClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	c.mu.Lock()
+	c.batched += len(b)
+	c.mu.Unlock()
+
+	return c.Client.BatchCallContext(ctx, b)
+}
+
+func (c *countingClient) count(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.calls[method]
+}
@@ -39,6 +39,13 @@ type Client interface {
 		args ...interface{},
 	) error
 
+	// BatchCall sends all given requests as a single batch and waits for the server to return a response for all
+	// of them. The error of each request is set in the Error field of its BatchElem, the returned error is only
+	// set for I/O errors.
+	BatchCall(b []gethrpc.BatchElem) error
+
+	BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error
+
 	Subscribe(
 		ctx context.Context,
 		namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
@@ -67,12 +74,41 @@ func (c client) Close() {
 	c.Client.Close()
 }
 
-// Connect connects to the provided url
-func Connect(url string) (Client, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().DialTimeout)
-	defer cancel()
+// Subscribe calls the subscribe method of the provided namespace and sends the notifications to the channel.
+// A SubscriptionUnsupportedError is returned when the transport of the client does not support subscriptions.
+func (c *client) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	sub, err := c.Client.Subscribe(
+		ctx,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		channel,
+		args...,
+	)
+	if err != nil {
+		return nil, subscribeError(namespace, subscribeMethodSuffix, err)
+	}
+
+	return sub, nil
+}
 
-	c, err := gethrpc.DialContext(ctx, url)
+// Connect connects to the provided url. The transport is selected from the scheme of the url, unless set
+// with WithTransport.
+func Connect(url string, opts ...ConnectOptsFn) (Client, error) {
+	connectOpts := NewDefaultConnectOpts()
+
+	for _, opt := range opts {
+		opt(connectOpts)
+	}
+
+	c, err := dialContext(context.Background(), url, connectOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +116,17 @@ func Connect(url string) (Client, error) {
 	return &cc, nil
 }
 
+// SubscribeContext returns the context used for setting up a subscription. The deadline of the provided context
+// is kept if it has one, which allows configuring the subscription setup timeout per call. Otherwise, the default
+// subscribe timeout is applied.
+func SubscribeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, config.Default().SubscribeTimeout)
+}
+
 func CallWithBlockHash(c Client, target interface{}, method string, blockHash *types.Hash, args ...interface{}) error {
 	ctx := context.Background()
 
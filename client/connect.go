@@ -0,0 +1,166 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/gorilla/websocket"
+)
+
+// SubscriptionUnsupportedError is returned when subscribing over a Transport that does not support
+// subscriptions, such as TransportHTTP.
+type SubscriptionUnsupportedError struct {
+	Transport Transport
+	Method    string
+}
+
+func (e *SubscriptionUnsupportedError) Error() string {
+	return fmt.Sprintf("subscription %s is not supported over the %s transport", e.Method, e.Transport)
+}
+
+// Unwrap returns gethrpc.ErrNotificationsUnsupported.
+func (e *SubscriptionUnsupportedError) Unwrap() error {
+	return gethrpc.ErrNotificationsUnsupported
+}
+
+// subscribeError returns a SubscriptionUnsupportedError if the provided subscribe error is caused by a
+// connection without notifications, which is only the case of HTTP connections.
+func subscribeError(namespace, subscribeMethodSuffix string, err error) error {
+	if !errors.Is(err, gethrpc.ErrNotificationsUnsupported) {
+		return err
+	}
+
+	return &SubscriptionUnsupportedError{
+		Transport: TransportHTTP,
+		Method:    namespace + "_" + subscribeMethodSuffix,
+	}
+}
+
+// dialContext dials the provided url, applying the dial timeout of the options if the context has no deadline.
+func dialContext(ctx context.Context, url string, opts *ConnectOpts) (*gethrpc.Client, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.dialTimeout)
+		defer cancel()
+	}
+
+	dialURL, err := transportURL(url, opts.transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return gethrpc.DialOptions(ctx, dialURL, opts.clientOptions()...)
+}
+
+// transportURL adjusts the scheme of the provided url to the transport.
+func transportURL(rawURL string, transport Transport) (string, error) {
+	if transport == TransportAuto {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	secure := u.Scheme == "wss" || u.Scheme == "https"
+
+	switch transport {
+	case TransportWebsocket:
+		u.Scheme = "ws"
+
+		if secure {
+			u.Scheme = "wss"
+		}
+	case TransportHTTP:
+		u.Scheme = "http"
+
+		if secure {
+			u.Scheme = "https"
+		}
+	case TransportIPC:
+		if u.Scheme == "" {
+			return rawURL, nil
+		}
+
+		if u.Scheme != "ipc" && u.Scheme != "unix" {
+			return "", fmt.Errorf("url %q is not a valid IPC endpoint", rawURL)
+		}
+
+		return u.Path, nil
+	default:
+		return "", fmt.Errorf("unknown transport %q", transport)
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("url %q has no host for the %s transport", rawURL, transport)
+	}
+
+	return u.String(), nil
+}
+
+// wsWriteBufferPools holds the pools of the write buffers of the websocket connections, per write buffer size.
+var wsWriteBufferPools sync.Map
+
+// wsWriteBufferPool returns the pool of the write buffers of the provided size, shared by the websocket
+// connections.
+func wsWriteBufferPool(size int) websocket.BufferPool {
+	pool, _ := wsWriteBufferPools.LoadOrStore(size, new(sync.Pool))
+
+	return pool.(*sync.Pool)
+}
+
+// websocketDialer returns the dialer of the websocket connections.
+func (o *ConnectOpts) websocketDialer() websocket.Dialer {
+	return websocket.Dialer{
+		TLSClientConfig:   o.tlsConfig,
+		ReadBufferSize:    o.wsReadBufferSize,
+		WriteBufferSize:   o.wsWriteBufferSize,
+		WriteBufferPool:   wsWriteBufferPool(o.wsWriteBufferSize),
+		EnableCompression: o.compression,
+	}
+}
+
+// clientOptions returns the options of the gethrpc client.
+func (o *ConnectOpts) clientOptions() []gethrpc.ClientOption {
+	options := []gethrpc.ClientOption{
+		gethrpc.WithWebsocketDialer(o.websocketDialer()),
+		gethrpc.WithWebsocketPing(o.pingInterval, o.pongTimeout),
+		gethrpc.WithHeaders(o.headers),
+	}
+
+	if o.wsReadLimit != nil {
+		options = append(options, gethrpc.WithWebsocketMessageSizeLimit(*o.wsReadLimit))
+	}
+
+	if o.tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = o.tlsConfig
+
+		options = append(options, gethrpc.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+
+	return options
+}
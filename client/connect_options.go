@@ -0,0 +1,144 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+)
+
+// Transport is the transport used by a client for reaching the node.
+type Transport string
+
+const (
+	// TransportAuto selects the transport from the scheme of the URL: ws and wss for websocket, http and https
+	// for HTTP, and a path for IPC.
+	TransportAuto Transport = ""
+	// TransportWebsocket connects to the node over a websocket, which supports subscriptions.
+	TransportWebsocket Transport = "websocket"
+	// TransportHTTP sends every request to the node in its own HTTP request. Subscriptions are not supported.
+	TransportHTTP Transport = "http"
+	// TransportIPC connects to the node over a unix socket, or a named pipe on Windows.
+	TransportIPC Transport = "ipc"
+)
+
+const defaultWebsocketBufferSize = 1024
+
+// ConnectOpts holds the options used for connecting to a node.
+type ConnectOpts struct {
+	transport   Transport
+	dialTimeout time.Duration
+
+	headers   http.Header
+	tlsConfig *tls.Config
+
+	wsReadLimit       *int64
+	wsReadBufferSize  int
+	wsWriteBufferSize int
+
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+
+	compression bool
+}
+
+// NewDefaultConnectOpts returns the default options used for connecting to a node:
+//
+//   - the transport is selected from the scheme of the URL.
+//   - the dial timeout is the one of the default config.
+//   - websocket messages are limited to the size of the largest request accepted by the RPC server.
+//   - no websocket keepalive pings are sent.
+//   - websocket compression is disabled.
+func NewDefaultConnectOpts() *ConnectOpts {
+	return &ConnectOpts{
+		dialTimeout:       config.Default().DialTimeout,
+		headers:           make(http.Header),
+		wsReadBufferSize:  defaultWebsocketBufferSize,
+		wsWriteBufferSize: defaultWebsocketBufferSize,
+	}
+}
+
+// ConnectOptsFn is function that sets an option used for connecting to a node.
+type ConnectOptsFn func(opts *ConnectOpts)
+
+// WithTransport sets the Transport used for connecting to the node. The scheme of the URL is adjusted to the
+// transport, e.g. wss://node becomes https://node with TransportHTTP.
+func WithTransport(transport Transport) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.transport = transport
+	}
+}
+
+// WithDialTimeout sets the timeout for establishing the connection.
+func WithDialTimeout(timeout time.Duration) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.dialTimeout = timeout
+	}
+}
+
+// WithHeader adds an HTTP header to the requests sent to the node, e.g. an authorization token. The header is
+// sent with every HTTP request, and with the handshake of websocket connections.
+func WithHeader(key, value string) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.headers.Add(key, value)
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for https and wss connections.
+func WithTLSConfig(tlsConfig *tls.Config) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.tlsConfig = tlsConfig
+	}
+}
+
+// WithWebsocketReadLimit sets the maximum size in bytes of a message received over a websocket. The connection
+// is closed when a larger message is received. A limit of 0 means no limit.
+func WithWebsocketReadLimit(limit int64) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.wsReadLimit = &limit
+	}
+}
+
+// WithWebsocketBufferSizes sets the sizes in bytes of the read and write buffers of websocket connections. The
+// buffer sizes do not limit the size of the messages.
+func WithWebsocketBufferSizes(read, write int) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.wsReadBufferSize = read
+		opts.wsWriteBufferSize = write
+	}
+}
+
+// WithKeepalive sets the keepalive of websocket connections. A ping is sent when nothing was written to the
+// connection for the provided interval, and the peer is considered dead and the connection closed if the pong
+// is not received within the timeout. The pings are disabled by default, and an interval of 0 disables them.
+func WithKeepalive(interval, timeout time.Duration) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.pingInterval = interval
+		opts.pongTimeout = timeout
+	}
+}
+
+// WithCompression enables the negotiation of the permessage-deflate compression of websocket messages. HTTP
+// responses are always requested with gzip compression.
+func WithCompression(enabled bool) ConnectOptsFn {
+	return func(opts *ConnectOpts) {
+		opts.compression = enabled
+	}
+}
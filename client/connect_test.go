@@ -0,0 +1,247 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnect_HTTPTransport(t *testing.T) {
+	srv, headers := newTestHTTPServer(t)
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	cl, err := Connect(wsURL, WithTransport(TransportHTTP), WithHeader("Authorization", "Bearer token"))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	var header types.Header
+
+	err = cl.Call(&header, "chain_getHeader")
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(0), header.Number)
+
+	assert.Equal(t, "Bearer token", headers.get().Get("Authorization"))
+	assert.False(t, websocket.IsWebSocketUpgrade(&http.Request{Header: headers.get()}))
+
+	_, err = cl.Subscribe(
+		context.Background(),
+		"chain",
+		"subscribeNewHead",
+		"unsubscribeNewHead",
+		"newHead",
+		make(chan types.Header),
+	)
+
+	var unsupportedErr *SubscriptionUnsupportedError
+
+	require.True(t, errors.As(err, &unsupportedErr))
+	assert.Equal(t, TransportHTTP, unsupportedErr.Transport)
+	assert.Equal(t, "chain_subscribeNewHead", unsupportedErr.Method)
+	assert.ErrorIs(t, err, gethrpc.ErrNotificationsUnsupported)
+}
+
+func TestConnect_WebsocketHeaders(t *testing.T) {
+	srv, headers := newTestHTTPServer(t)
+
+	cl, err := Connect(srv.URL, WithTransport(TransportWebsocket), WithHeader("Authorization", "Bearer token"))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	var header types.Header
+
+	err = cl.Call(&header, "chain_getHeader")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Bearer token", headers.get().Get("Authorization"))
+	assert.True(t, websocket.IsWebSocketUpgrade(&http.Request{Header: headers.get()}))
+}
+
+func TestConnect_WebsocketReadLimit(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	cl, err := Connect(srv.URL, WithWebsocketReadLimit(16))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	var header types.Header
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	err = cl.CallContext(ctx, &header, "chain_getHeader")
+	assert.Error(t, err)
+	assert.NoError(t, ctx.Err())
+}
+
+func TestConnectOpts_WebsocketDialer(t *testing.T) {
+	opts := NewDefaultConnectOpts()
+
+	dialer := opts.websocketDialer()
+	assert.Equal(t, defaultWebsocketBufferSize, dialer.WriteBufferSize)
+
+	// The write buffers are shared by the connections with the same write buffer size.
+	assert.Same(t, dialer.WriteBufferPool, NewDefaultConnectOpts().websocketDialer().WriteBufferPool)
+
+	WithWebsocketBufferSizes(4096, 4096)(opts)
+
+	assert.NotSame(t, dialer.WriteBufferPool, opts.websocketDialer().WriteBufferPool)
+}
+
+func TestNewDefaultConnectOpts_NoKeepalive(t *testing.T) {
+	// The keepalive pings are only sent when enabled with WithKeepalive.
+	assert.Equal(t, time.Duration(0), NewDefaultConnectOpts().pingInterval)
+}
+
+func TestConnect_KeepaliveDeadPeer(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	// The server neither answers the pings nor the requests.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		conn.SetPingHandler(func(string) error { return nil })
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	cl, err := Connect(wsURL, WithKeepalive(50*time.Millisecond, 50*time.Millisecond))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	var result string
+
+	// The request is written right away, so the ping is sent 50ms later and the connection is closed after
+	// another 50ms.
+	err = cl.CallContext(ctx, &result, "system_health")
+	assert.Error(t, err)
+	assert.NoError(t, ctx.Err())
+}
+
+func TestTransportURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		transport Transport
+		expected  string
+		err       bool
+	}{
+		{url: "wss://node:443/path", transport: TransportAuto, expected: "wss://node:443/path"},
+		{url: "wss://node:443/path", transport: TransportHTTP, expected: "https://node:443/path"},
+		{url: "ws://node:9944", transport: TransportHTTP, expected: "http://node:9944"},
+		{url: "https://node", transport: TransportWebsocket, expected: "wss://node"},
+		{url: "http://node", transport: TransportWebsocket, expected: "ws://node"},
+		{url: "/tmp/node.ipc", transport: TransportIPC, expected: "/tmp/node.ipc"},
+		{url: "ipc:///tmp/node.ipc", transport: TransportIPC, expected: "/tmp/node.ipc"},
+		{url: "ws://node", transport: TransportIPC, err: true},
+		{url: "/tmp/node.ipc", transport: TransportHTTP, err: true},
+		{url: "ws://node", transport: Transport("quic"), err: true},
+	}
+
+	for _, test := range tests {
+		res, err := transportURL(test.url, test.transport)
+
+		if test.err {
+			assert.Error(t, err, test.url)
+			continue
+		}
+
+		assert.NoError(t, err, test.url)
+		assert.Equal(t, test.expected, res)
+	}
+}
+
+// recordedHeader holds the headers of the last request received by a test server.
+type recordedHeader struct {
+	mu     sync.Mutex
+	header http.Header
+}
+
+func (h *recordedHeader) set(header http.Header) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.header = header.Clone()
+}
+
+func (h *recordedHeader) get() http.Header {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.header
+}
+
+// newTestHTTPServer returns a test server that serves the test chain service over HTTP and websocket.
+func newTestHTTPServer(t *testing.T) (*httptest.Server, *recordedHeader) {
+	rpcServer := gethrpc.NewServer()
+
+	err := rpcServer.RegisterName("chain", newTestChainService())
+	require.NoError(t, err)
+
+	wsHandler := rpcServer.WebsocketHandler([]string{"*"})
+	headers := &recordedHeader{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers.set(r.Header)
+
+		if websocket.IsWebSocketUpgrade(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+
+		rpcServer.ServeHTTP(w, r)
+	}))
+
+	t.Cleanup(func() {
+		srv.Close()
+		rpcServer.Stop()
+	})
+
+	return srv, headers
+}
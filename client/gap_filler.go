@@ -0,0 +1,92 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ErrGapTooLarge is returned by the headers GapFiller when more headers were missed than it is allowed to retrieve.
+var ErrGapTooLarge = errors.New("gap too large")
+
+// GapFiller retrieves the notifications that a subscription missed while the client was reconnecting, given the
+// last notification delivered before the connection was lost and the first one received after the subscription
+// was issued again. The returned notifications are delivered in order, before the latter.
+//
+// A GapFiller can return both notifications and an error, when only part of the gap could be filled.
+type GapFiller func(ctx context.Context, c Client, last, next json.RawMessage) ([]json.RawMessage, error)
+
+// NewHeadersGapFiller returns a GapFiller for the headers subscriptions that retrieves the headers between the last
+// delivered header and the next one by following the parent hashes of the latter. At most maxGap headers are
+// retrieved, the most recent ones, in which case ErrGapTooLarge is returned along with them.
+func NewHeadersGapFiller(maxGap uint32) GapFiller {
+	return func(ctx context.Context, c Client, last, next json.RawMessage) ([]json.RawMessage, error) {
+		var lastHeader, nextHeader types.Header
+
+		if err := json.Unmarshal(last, &lastHeader); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(next, &nextHeader); err != nil {
+			return nil, err
+		}
+
+		if nextHeader.Number <= lastHeader.Number+1 {
+			return nil, nil
+		}
+
+		missed := uint32(nextHeader.Number - lastHeader.Number - 1)
+
+		count := missed
+
+		if count > maxGap {
+			count = maxGap
+		}
+
+		headers := make([]json.RawMessage, count)
+
+		parentHash := nextHeader.ParentHash
+
+		for i := int(count) - 1; i >= 0; i-- {
+			var res json.RawMessage
+
+			if err := c.CallContext(ctx, &res, "chain_getHeader", parentHash.Hex()); err != nil {
+				return headers[i+1:], err
+			}
+
+			var header types.Header
+
+			if err := json.Unmarshal(res, &header); err != nil {
+				return headers[i+1:], err
+			}
+
+			headers[i] = res
+			parentHash = header.ParentHash
+		}
+
+		if count < missed {
+			return headers, fmt.Errorf("%w: missed %d headers, retrieved %d", ErrGapTooLarge, missed, count)
+		}
+
+		return headers, nil
+	}
+}
@@ -0,0 +1,321 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// CallKind is the kind of operation seen by an Interceptor.
+type CallKind int
+
+const (
+	// CallKindCall is a single RPC call.
+	CallKindCall CallKind = iota
+	// CallKindBatch is a batch of RPC calls.
+	CallKindBatch
+	// CallKindSubscribe is the request that sets up a subscription.
+	CallKindSubscribe
+	// CallKindNotification is a notification received by a subscription.
+	CallKindNotification
+)
+
+func (k CallKind) String() string {
+	switch k {
+	case CallKindCall:
+		return "call"
+	case CallKindBatch:
+		return "batch"
+	case CallKindSubscribe:
+		return "subscribe"
+	case CallKindNotification:
+		return "notification"
+	default:
+		return "unknown"
+	}
+}
+
+// BatchMethod is the method of the CallInfo of a batch, whose requests are found in CallInfo.Batch.
+const BatchMethod = "batch"
+
+// CallInfo describes an operation seen by an Interceptor. The result size, latency and error are only set once
+// the operation ended.
+type CallInfo struct {
+	Kind CallKind
+	// Method is the RPC method of the call. For subscriptions and their notifications, it is the subscribe method.
+	Method string
+	// ParamsSize is the size in bytes of the JSON encoded params.
+	ParamsSize int
+	// ResultSize is the size in bytes of the JSON encoded result.
+	ResultSize int
+	Latency    time.Duration
+	Err        error
+	// Batch holds the requests of a batch, which share its latency.
+	Batch []*CallInfo
+}
+
+// Interceptor observes the calls, subscriptions and notifications of a client, e.g. for recording metrics or
+// tracing the calls.
+type Interceptor interface {
+	// Start is called before the operation is sent, or when a notification is received. The returned context is
+	// used for sending the operation and is provided to End.
+	Start(ctx context.Context, info *CallInfo) context.Context
+	// End is called once the operation ended, with the context returned by Start.
+	End(ctx context.Context, info *CallInfo)
+}
+
+// interceptedClient is a Client that reports its operations to interceptors.
+type interceptedClient struct {
+	Client
+
+	interceptors []Interceptor
+}
+
+// NewInterceptedClient wraps the provided client so that all its operations are reported to the interceptors,
+// which are started in order and ended in reverse order. The context returned by the interceptors is propagated
+// to the underlying client, which allows e.g. the HTTP transport to propagate the trace context.
+func NewInterceptedClient(c Client, interceptors ...Interceptor) Client {
+	return &interceptedClient{
+		Client:       c,
+		interceptors: interceptors,
+	}
+}
+
+func (c *interceptedClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *interceptedClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	info := &CallInfo{
+		Kind:       CallKindCall,
+		Method:     method,
+		ParamsSize: paramsSize(args),
+	}
+
+	ctx, end := c.start(ctx, info)
+
+	var raw json.RawMessage
+
+	err := c.Client.CallContext(ctx, &raw, method, args...)
+
+	if err == nil {
+		err = json.Unmarshal(raw, &result)
+	}
+
+	info.ResultSize = len(raw)
+	info.Err = err
+
+	end()
+
+	return err
+}
+
+func (c *interceptedClient) BatchCall(b []gethrpc.BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+func (c *interceptedClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	info := &CallInfo{
+		Kind:   CallKindBatch,
+		Method: BatchMethod,
+		Batch:  make([]*CallInfo, len(b)),
+	}
+
+	batch := make([]gethrpc.BatchElem, len(b))
+
+	for i, elem := range b {
+		info.Batch[i] = &CallInfo{
+			Kind:       CallKindCall,
+			Method:     elem.Method,
+			ParamsSize: paramsSize(elem.Args),
+		}
+
+		info.ParamsSize += info.Batch[i].ParamsSize
+
+		batch[i] = gethrpc.BatchElem{
+			Method: elem.Method,
+			Args:   elem.Args,
+			Result: new(json.RawMessage),
+		}
+	}
+
+	ctx, end := c.start(ctx, info)
+
+	err := c.Client.BatchCallContext(ctx, batch)
+
+	if err == nil {
+		for i := range b {
+			raw := *batch[i].Result.(*json.RawMessage)
+
+			b[i].Error = batch[i].Error
+
+			if b[i].Error == nil && b[i].Result != nil {
+				b[i].Error = json.Unmarshal(raw, b[i].Result)
+			}
+
+			info.Batch[i].ResultSize = len(raw)
+			info.Batch[i].Err = b[i].Error
+			info.ResultSize += len(raw)
+		}
+	} else {
+		for _, elemInfo := range info.Batch {
+			elemInfo.Err = err
+		}
+	}
+
+	info.Err = err
+
+	end()
+
+	return err
+}
+
+// Subscribe sets up the subscription and reports each of its notifications to the interceptors, with a context
+// that holds the values of the provided context but is not canceled with it.
+func (c *interceptedClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	method := namespace + "_" + subscribeMethodSuffix
+
+	info := &CallInfo{
+		Kind:       CallKindSubscribe,
+		Method:     method,
+		ParamsSize: paramsSize(args),
+	}
+
+	subCtx, end := c.start(ctx, info)
+
+	notifications := make(chan json.RawMessage)
+
+	inner, err := c.Client.Subscribe(
+		subCtx,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		notifications,
+		args...,
+	)
+
+	info.Err = err
+
+	end()
+
+	if err != nil {
+		return nil, err
+	}
+
+	feed := gethrpc.NewSubscriptionFeed(channel, func() error {
+		inner.Unsubscribe()
+
+		return nil
+	})
+
+	feed.SetID(inner.ID())
+
+	go c.forward(context.WithoutCancel(ctx), method, inner, feed, notifications)
+
+	return feed.Subscription(), nil
+}
+
+// forward delivers the notifications of the underlying subscription to the feed and reports them to
+// the interceptors.
+func (c *interceptedClient) forward(
+	ctx context.Context,
+	method string,
+	inner *gethrpc.ClientSubscription,
+	feed *gethrpc.SubscriptionFeed,
+	notifications chan json.RawMessage,
+) {
+	for {
+		select {
+		case notification := <-notifications:
+			info := &CallInfo{
+				Kind:       CallKindNotification,
+				Method:     method,
+				ResultSize: len(notification),
+			}
+
+			_, end := c.start(ctx, info)
+
+			ok := feed.Send(notification)
+
+			end()
+
+			if !ok {
+				inner.Unsubscribe()
+				return
+			}
+		case err := <-inner.Err():
+			feed.Close(err)
+			return
+		case <-feed.Done():
+			return
+		}
+	}
+}
+
+// start starts the interceptors and returns the context to use for the operation, along with the function that
+// sets the latency and ends the interceptors.
+func (c *interceptedClient) start(ctx context.Context, info *CallInfo) (context.Context, func()) {
+	contexts := make([]context.Context, len(c.interceptors))
+
+	for i, interceptor := range c.interceptors {
+		ctx = interceptor.Start(ctx, info)
+		contexts[i] = ctx
+	}
+
+	start := time.Now()
+
+	return ctx, func() {
+		info.Latency = time.Since(start)
+
+		for _, elemInfo := range info.Batch {
+			elemInfo.Latency = info.Latency
+		}
+
+		for i := len(c.interceptors) - 1; i >= 0; i-- {
+			c.interceptors[i].End(contexts[i], info)
+		}
+	}
+}
+
+func paramsSize(args []interface{}) int {
+	if len(args) == 0 {
+		return 0
+	}
+
+	encoded, err := json.Marshal(args)
+
+	if err != nil {
+		return 0
+	}
+
+	return len(encoded)
+}
@@ -0,0 +1,245 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testContextKey struct{}
+
+func TestInterceptedClient_Call(t *testing.T) {
+	cl, first, second, inner := newTestInterceptedClient(t)
+
+	var header types.Header
+
+	err := cl.Call(&header, "chain_getHeader", testHeaderHash(1).Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(1), header.Number)
+
+	err = cl.Call(&header, "chain_getHeader", testHeaderHash(10).Hex())
+	assert.EqualError(t, err, "header not found")
+
+	// The interceptors are started in order and ended in reverse order.
+	assert.Equal(t, []string{"first start", "second start", "second end", "first end"}, first.order.get()[:4])
+
+	ended := first.endedInfos()
+	require.Len(t, ended, 2)
+	assert.Equal(t, ended, second.endedInfos())
+
+	info := ended[0]
+	assert.Equal(t, CallKindCall, info.Kind)
+	assert.Equal(t, "chain_getHeader", info.Method)
+	assert.Equal(t, len(`["`+testHeaderHash(1).Hex()+`"]`), info.ParamsSize)
+	assert.Greater(t, info.ResultSize, 0)
+	assert.Greater(t, info.Latency, time.Duration(0))
+	assert.NoError(t, info.Err)
+
+	assert.EqualError(t, ended[1].Err, "header not found")
+
+	// The context returned by the interceptors is propagated to the underlying client.
+	assert.Equal(t, []string{"second", "second"}, inner.contextValues())
+}
+
+func TestInterceptedClient_BatchCall(t *testing.T) {
+	cl, first, _, _ := newTestInterceptedClient(t)
+
+	headers := make([]types.Header, 2)
+
+	batch := []gethrpc.BatchElem{
+		{Method: "chain_getHeader", Args: []interface{}{testHeaderHash(2).Hex()}, Result: &headers[0]},
+		{Method: "chain_getHeader", Args: []interface{}{testHeaderHash(10).Hex()}, Result: &headers[1]},
+	}
+
+	err := cl.BatchCall(batch)
+	assert.NoError(t, err)
+	assert.NoError(t, batch[0].Error)
+	assert.Equal(t, types.BlockNumber(2), headers[0].Number)
+	assert.EqualError(t, batch[1].Error, "header not found")
+
+	ended := first.endedInfos()
+	require.Len(t, ended, 1)
+
+	info := ended[0]
+	assert.Equal(t, CallKindBatch, info.Kind)
+	assert.Equal(t, BatchMethod, info.Method)
+	assert.NoError(t, info.Err)
+	require.Len(t, info.Batch, 2)
+	assert.Equal(t, info.Batch[0].ParamsSize+info.Batch[1].ParamsSize, info.ParamsSize)
+	assert.Equal(t, info.Batch[0].ResultSize+info.Batch[1].ResultSize, info.ResultSize)
+	assert.NoError(t, info.Batch[0].Err)
+	assert.EqualError(t, info.Batch[1].Err, "header not found")
+
+	for _, elemInfo := range info.Batch {
+		assert.Equal(t, "chain_getHeader", elemInfo.Method)
+		assert.Equal(t, info.Latency, elemInfo.Latency)
+	}
+}
+
+func TestInterceptedClient_Subscribe(t *testing.T) {
+	cl, first, _, _ := newTestInterceptedClient(t)
+
+	ch := make(chan types.Header)
+
+	sub, err := cl.Subscribe(
+		context.Background(),
+		"chain",
+		"subscribeNewHead",
+		"unsubscribeNewHead",
+		"newHead",
+		ch,
+	)
+	require.NoError(t, err)
+
+	chainService := first.chainService
+
+	waitForSubscriptions(t, chainService, 1)
+
+	go chainService.produce(2)
+
+	assertHeaders(t, ch, 5, 6)
+
+	sub.Unsubscribe()
+
+	waitForSubscriptions(t, chainService, 0)
+
+	infos := first.endedInfos()
+	require.Len(t, infos, 3)
+
+	assert.Equal(t, CallKindSubscribe, infos[0].Kind)
+	assert.Equal(t, "chain_subscribeNewHead", infos[0].Method)
+	assert.NoError(t, infos[0].Err)
+
+	for _, info := range infos[1:] {
+		assert.Equal(t, CallKindNotification, info.Kind)
+		assert.Equal(t, "chain_subscribeNewHead", info.Method)
+		assert.Greater(t, info.ResultSize, 0)
+	}
+}
+
+func newTestInterceptedClient(
+	t *testing.T,
+) (Client, *testInterceptor, *testInterceptor, *contextRecordingClient) {
+	srv, chainService := newTestServer(t)
+
+	chainService.produce(4)
+
+	conn, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(conn.Close)
+
+	inner := &contextRecordingClient{Client: conn}
+
+	order := &interceptorOrder{}
+
+	first := &testInterceptor{name: "first", order: order, chainService: chainService}
+	second := &testInterceptor{name: "second", order: order, chainService: chainService}
+
+	return NewInterceptedClient(inner, first, second), first, second, inner
+}
+
+// interceptorOrder records the order in which the interceptors are called.
+type interceptorOrder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (o *interceptorOrder) add(call string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.calls = append(o.calls, call)
+}
+
+func (o *interceptorOrder) get() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]string{}, o.calls...)
+}
+
+// testInterceptor records the operations it sees and sets its name in the context.
+type testInterceptor struct {
+	name         string
+	order        *interceptorOrder
+	chainService *testChainService
+
+	mu    sync.Mutex
+	ended []CallInfo
+}
+
+func (i *testInterceptor) Start(ctx context.Context, _ *CallInfo) context.Context {
+	i.order.add(i.name + " start")
+
+	return context.WithValue(ctx, testContextKey{}, i.name)
+}
+
+func (i *testInterceptor) End(ctx context.Context, info *CallInfo) {
+	i.order.add(i.name + " end")
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// Each interceptor is ended with the context returned by its own Start.
+	if ctx.Value(testContextKey{}) == i.name {
+		i.ended = append(i.ended, *info)
+	}
+}
+
+func (i *testInterceptor) endedInfos() []CallInfo {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return append([]CallInfo{}, i.ended...)
+}
+
+// contextRecordingClient records the value set by the interceptors in the context of the calls.
+type contextRecordingClient struct {
+	Client
+
+	mu     sync.Mutex
+	values []string
+}
+
+func (c *contextRecordingClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	c.mu.Lock()
+	c.values = append(c.values, ctx.Value(testContextKey{}).(string))
+	c.mu.Unlock()
+
+	return c.Client.CallContext(ctx, result, method, args...)
+}
+
+func (c *contextRecordingClient) contextValues() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.values
+}
@@ -14,6 +14,34 @@ type Client struct {
 	mock.Mock
 }
 
+// BatchCall provides a mock function with given fields: b
+func (_m *Client) BatchCall(b []rpc.BatchElem) error {
+	ret := _m.Called(b)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]rpc.BatchElem) error); ok {
+		r0 = rf(b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BatchCallContext provides a mock function with given fields: ctx, b
+func (_m *Client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	ret := _m.Called(ctx, b)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []rpc.BatchElem) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Call provides a mock function with given fields: result, method, args
 func (_m *Client) Call(result interface{}, method string, args ...interface{}) error {
 	var _ca []interface{}
@@ -0,0 +1,773 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	// ErrNoEndpoints is returned when creating a multi-endpoint client without endpoints.
+	ErrNoEndpoints = errors.New("no endpoints")
+	// ErrNoHealthyEndpoints is returned when none of the nodes of a multi-endpoint client is healthy.
+	ErrNoHealthyEndpoints = errors.New("no healthy endpoints")
+	// ErrEndpointSyncing is the reason a node is ejected for when it reports that it is syncing.
+	ErrEndpointSyncing = errors.New("endpoint is syncing")
+	// ErrEndpointLagging is the reason a node is ejected for when its best block lags behind the other nodes.
+	ErrEndpointLagging = errors.New("endpoint is lagging")
+)
+
+// EndpointStatus is the status of a node of a multi-endpoint client.
+type EndpointStatus struct {
+	URL string
+	// Healthy is true if the node serves requests.
+	Healthy bool
+	// Latency is the average latency of the health checks of the node.
+	Latency time.Duration
+	// BestBlock is the best block number of the node, as of the last health check.
+	BestBlock uint64
+	// Err is the reason the node was ejected for, if it is not healthy.
+	Err error
+}
+
+// MultiClient is a Client that routes requests to several nodes according to a RoutingPolicy.
+//
+// The nodes are health-checked periodically with system_health and their best block, and ejected when they are
+// syncing, lag behind the other nodes or fail due to the connection. Ejected nodes are admitted again once healthy.
+// Calls that fail due to the connection are attempted on the next node. Subscriptions stick to one node and move
+// to another, as done by ReconnectingClient, when their node fails or is ejected.
+type MultiClient interface {
+	Client
+
+	// ConnectionEvents returns the channel that receives the ConnectionEvent of the nodes of the client. A node
+	// that is ejected emits ConnectionStateDisconnected, and ConnectionStateConnected once admitted again.
+	// Events are dropped if the channel buffer is full. The ConnectionStateClosed event is the last one.
+	ConnectionEvents() <-chan ConnectionEvent
+
+	// Endpoints returns the status of the nodes, in the order of the provided endpoints.
+	Endpoints() []EndpointStatus
+
+	// CheckHealth runs the health checks of the nodes.
+	CheckHealth(ctx context.Context)
+}
+
+type multiClient struct {
+	opts      *MultiOpts
+	endpoints []*endpoint
+
+	next       atomic.Uint32
+	generation atomic.Uint64
+
+	mu     sync.Mutex
+	subs   map[*resumableSubscription]struct{}
+	moving map[*resumableSubscription]struct{}
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	events chan ConnectionEvent
+}
+
+// ConnectMulti connects to the provided urls and returns a MultiClient. The client is returned as long as one of
+// the nodes is healthy, the others are connected to again during the next health checks.
+func ConnectMulti(urls []string, opts ...MultiOptsFn) (MultiClient, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	multiOpts := NewDefaultMultiOpts()
+
+	for _, opt := range opts {
+		opt(multiOpts)
+	}
+
+	c := &multiClient{
+		opts:   multiOpts,
+		subs:   make(map[*resumableSubscription]struct{}),
+		moving: make(map[*resumableSubscription]struct{}),
+		closed: make(chan struct{}),
+		events: make(chan ConnectionEvent, multiOpts.eventBufferSize),
+	}
+
+	for _, url := range urls {
+		c.endpoints = append(c.endpoints, &endpoint{url: url, connectOpts: multiOpts.connectOpts})
+	}
+
+	c.CheckHealth(context.Background())
+
+	if !c.hasHealthyEndpoint() {
+		err := c.endpoints[0].status().Err
+
+		c.Close()
+
+		return nil, fmt.Errorf("%w: %v", ErrNoHealthyEndpoints, err)
+	}
+
+	if multiOpts.healthCheckInterval > 0 {
+		go c.healthCheckLoop()
+	}
+
+	return c, nil
+}
+
+// URL returns the URL of the node that the next request would be routed to, or the URL of the first node if
+// none is healthy.
+func (c *multiClient) URL() string {
+	candidates := c.candidates(false)
+
+	if len(candidates) == 0 {
+		return c.endpoints[0].url
+	}
+
+	return candidates[0].url
+}
+
+func (c *multiClient) ConnectionEvents() <-chan ConnectionEvent {
+	return c.events
+}
+
+func (c *multiClient) Endpoints() []EndpointStatus {
+	statuses := make([]EndpointStatus, 0, len(c.endpoints))
+
+	for _, e := range c.endpoints {
+		statuses = append(statuses, e.status())
+	}
+
+	return statuses
+}
+
+func (c *multiClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *multiClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.do(ctx, func(conn *gethrpc.Client) error {
+		return conn.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (c *multiClient) BatchCall(b []gethrpc.BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+// BatchCallContext sends the batch to a single node, and to the next one if it fails due to the connection.
+func (c *multiClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	return c.do(ctx, func(conn *gethrpc.Client) error {
+		return conn.BatchCallContext(ctx, b)
+	})
+}
+
+// do runs the request on the healthy nodes, in the order of the routing policy, until it does not fail due to
+// the connection.
+func (c *multiClient) do(ctx context.Context, request func(conn *gethrpc.Client) error) error {
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+
+	candidates := c.candidates(true)
+
+	if len(candidates) == 0 {
+		return ErrNoHealthyEndpoints
+	}
+
+	if c.opts.callAttempts > 0 && c.opts.callAttempts < len(candidates) {
+		candidates = candidates[:c.opts.callAttempts]
+	}
+
+	var err error
+
+	for _, e := range candidates {
+		var conn *gethrpc.Client
+
+		conn, err = e.connection(ctx)
+		if err == nil {
+			err = request(conn)
+		}
+
+		if err == nil || !isConnectionError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		c.eject(e, err)
+	}
+
+	return err
+}
+
+func (c *multiClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	if c.isClosed() {
+		return nil, ErrClientClosed
+	}
+
+	sub := newResumableSubscription(
+		c,
+		c.opts.subscriptionOpts,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		channel,
+		args...,
+	)
+
+	e, err := c.subscribe(ctx, sub, nil)
+	if err != nil {
+		sub.feed.Close(nil)
+
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.subs[sub] = struct{}{}
+	c.mu.Unlock()
+
+	// The node was ejected while subscribing.
+	if !e.isHealthy() {
+		go c.move(sub)
+	}
+
+	return sub.feed.Subscription(), nil
+}
+
+// subscribe issues the subscription on the first healthy node that is not excluded.
+func (c *multiClient) subscribe(ctx context.Context, sub *resumableSubscription, exclude *endpoint) (*endpoint, error) {
+	err := ErrNoHealthyEndpoints
+
+	for _, e := range c.candidates(true) {
+		if e == exclude {
+			continue
+		}
+
+		var conn *gethrpc.Client
+
+		conn, err = e.connection(ctx)
+		if err == nil {
+			err = sub.subscribe(ctx, conn, e.url, c.generation.Add(1))
+		}
+
+		if err == nil {
+			return e, nil
+		}
+
+		if !isConnectionError(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		c.eject(e, err)
+	}
+
+	return nil, err
+}
+
+// move issues the subscription on another node, if its node is not healthy.
+func (c *multiClient) move(sub *resumableSubscription) {
+	c.mu.Lock()
+
+	if _, ok := c.moving[sub]; ok || c.isClosed() {
+		c.mu.Unlock()
+		return
+	}
+
+	c.moving[sub] = struct{}{}
+
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.moving, sub)
+		c.mu.Unlock()
+	}()
+
+	if sub.isEnded() {
+		return
+	}
+
+	url, _ := sub.current()
+
+	current := c.endpoint(url)
+
+	if current != nil && current.isHealthy() {
+		return
+	}
+
+	if sub.nonResumable {
+		sub.feed.Close(fmt.Errorf("%w: %s: node %s failed", ErrSubscriptionNotResumable, sub.method, url))
+		return
+	}
+
+	ctx, cancel := SubscribeContext(context.Background())
+	defer cancel()
+
+	_, err := c.subscribe(ctx, sub, current)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNoHealthyEndpoints) || isConnectionError(err):
+		// The subscription is moved after the next health checks.
+	default:
+		sub.feed.Close(err)
+	}
+}
+
+// CheckHealth runs the health checks of the nodes, ejects the ones that fail or lag behind, admits the healthy
+// ones and moves the subscriptions of the unhealthy nodes.
+func (c *multiClient) CheckHealth(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.healthCheckTimeout)
+	defer cancel()
+
+	results := make([]healthCheckResult, len(c.endpoints))
+
+	var wg sync.WaitGroup
+
+	for i, e := range c.endpoints {
+		wg.Add(1)
+
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+
+			results[i] = e.checkHealth(ctx)
+		}(i, e)
+	}
+
+	wg.Wait()
+
+	var maxBestBlock uint64
+
+	for _, result := range results {
+		if result.err == nil && result.bestBlock > maxBestBlock {
+			maxBestBlock = result.bestBlock
+		}
+	}
+
+	for i, e := range c.endpoints {
+		result := results[i]
+
+		switch {
+		case result.err != nil:
+			c.eject(e, result.err)
+		case maxBestBlock-result.bestBlock > c.opts.maxBlockLag:
+			c.eject(e, fmt.Errorf("%w: best block %d, highest best block %d",
+				ErrEndpointLagging, result.bestBlock, maxBestBlock))
+		default:
+			c.admit(e)
+		}
+	}
+
+	c.mu.Lock()
+	subs := make([]*resumableSubscription, 0, len(c.subs))
+	for sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		go c.move(sub)
+	}
+}
+
+func (c *multiClient) healthCheckLoop() {
+	ticker := time.NewTicker(c.opts.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.CheckHealth(context.Background())
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// candidates returns the healthy nodes, in the order of the routing policy. The round-robin position is advanced
+// if requested.
+func (c *multiClient) candidates(advance bool) []*endpoint {
+	healthy := make([]*endpoint, 0, len(c.endpoints))
+
+	for _, e := range c.endpoints {
+		if e.isHealthy() {
+			healthy = append(healthy, e)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	switch c.opts.policy {
+	case RoutingRoundRobin:
+		next := c.next.Load()
+
+		if advance {
+			next = c.next.Add(1) - 1
+		}
+
+		start := int(next % uint32(len(healthy)))
+
+		return append(healthy[start:], healthy[:start]...)
+	case RoutingLowestLatency:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].getLatency() < healthy[j].getLatency()
+		})
+	case RoutingPrimaryFallback:
+	}
+
+	return healthy
+}
+
+func (c *multiClient) eject(e *endpoint, err error) {
+	if !e.eject(err) {
+		return
+	}
+
+	c.emit(ConnectionEvent{URL: e.url, State: ConnectionStateDisconnected, Err: err})
+
+	c.mu.Lock()
+	subs := make([]*resumableSubscription, 0, len(c.subs))
+	for sub := range c.subs {
+		if url, _ := sub.current(); url == e.url {
+			subs = append(subs, sub)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		go c.move(sub)
+	}
+}
+
+func (c *multiClient) admit(e *endpoint) {
+	if e.admit() {
+		c.emit(ConnectionEvent{URL: e.url, State: ConnectionStateConnected})
+	}
+}
+
+func (c *multiClient) endpoint(url string) *endpoint {
+	for _, e := range c.endpoints {
+		if e.url == url {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (c *multiClient) hasHealthyEndpoint() bool {
+	for _, e := range c.endpoints {
+		if e.isHealthy() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *multiClient) subscriptionLost(sub *resumableSubscription, generation uint64, err error) {
+	url, current := sub.current()
+
+	if current != generation {
+		return
+	}
+
+	if e := c.endpoint(url); e != nil && err != nil {
+		c.eject(e, err)
+	}
+
+	go c.move(sub)
+}
+
+func (c *multiClient) removeSubscription(sub *resumableSubscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.subs, sub)
+}
+
+func (c *multiClient) emit(event ConnectionEvent) {
+	select {
+	case c.events <- event:
+	default:
+	}
+}
+
+func (c *multiClient) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the connections to all the nodes, ending all the subscriptions.
+func (c *multiClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		c.mu.Lock()
+		subs := make([]*resumableSubscription, 0, len(c.subs))
+		for sub := range c.subs {
+			subs = append(subs, sub)
+		}
+		c.mu.Unlock()
+
+		for _, sub := range subs {
+			sub.feed.Close(gethrpc.ErrClientQuit)
+		}
+
+		for _, e := range c.endpoints {
+			e.close()
+		}
+
+		c.emit(ConnectionEvent{State: ConnectionStateClosed})
+	})
+}
+
+// endpoint is a node of a multi-endpoint client.
+type endpoint struct {
+	url         string
+	connectOpts *ConnectOpts
+
+	mu   sync.Mutex
+	conn *gethrpc.Client
+	// dialing is closed once the pending dial of the node finishes, it is nil if no dial is pending. The node is
+	// dialed without holding mu, so that a slow node does not block the routing of the requests.
+	dialing   chan struct{}
+	closed    bool
+	healthy   bool
+	latency   time.Duration
+	bestBlock uint64
+	err       error
+}
+
+type healthCheckResult struct {
+	bestBlock uint64
+	err       error
+}
+
+// checkHealth checks that the node is not syncing and retrieves its best block.
+func (e *endpoint) checkHealth(ctx context.Context) healthCheckResult {
+	conn, err := e.connection(ctx)
+	if err != nil {
+		return healthCheckResult{err: err}
+	}
+
+	start := time.Now()
+
+	var health types.Health
+
+	if err := conn.CallContext(ctx, &health, "system_health"); err != nil {
+		return healthCheckResult{err: err}
+	}
+
+	e.observeLatency(time.Since(start))
+
+	if health.IsSyncing {
+		return healthCheckResult{err: ErrEndpointSyncing}
+	}
+
+	var header types.Header
+
+	if err := conn.CallContext(ctx, &header, "chain_getHeader"); err != nil {
+		return healthCheckResult{err: err}
+	}
+
+	e.mu.Lock()
+	e.bestBlock = uint64(header.Number)
+	e.mu.Unlock()
+
+	return healthCheckResult{bestBlock: uint64(header.Number)}
+}
+
+// connection returns the connection to the node, dialing it if needed. Concurrent callers wait for the pending
+// dial instead of dialing the node again.
+func (e *endpoint) connection(ctx context.Context) (*gethrpc.Client, error) {
+	for {
+		e.mu.Lock()
+
+		if e.closed {
+			e.mu.Unlock()
+			return nil, ErrClientClosed
+		}
+
+		if e.conn != nil {
+			conn := e.conn
+			e.mu.Unlock()
+
+			return conn, nil
+		}
+
+		dialing := e.dialing
+		if dialing == nil {
+			break
+		}
+
+		e.mu.Unlock()
+
+		select {
+		case <-dialing:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	dialing := make(chan struct{})
+	e.dialing = dialing
+	e.mu.Unlock()
+
+	defer close(dialing)
+
+	conn, err := dialContext(ctx, e.url, e.connectOpts)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dialing = nil
+
+	if err != nil {
+		return nil, dialError{err: err}
+	}
+
+	if e.closed {
+		conn.Close()
+		return nil, ErrClientClosed
+	}
+
+	e.conn = conn
+
+	return conn, nil
+}
+
+// dialError is returned when the connection to a node cannot be established, whatever the reason.
+type dialError struct {
+	err error
+}
+
+func (e dialError) Error() string {
+	return e.err.Error()
+}
+
+func (e dialError) Unwrap() error {
+	return e.err
+}
+
+// eject marks the node as unhealthy, closing its connection if the error is caused by the connection. It returns
+// true if the node was healthy.
+func (e *endpoint) eject(err error) bool {
+	e.mu.Lock()
+
+	wasHealthy := e.healthy
+
+	e.healthy = false
+	e.err = err
+
+	var conn *gethrpc.Client
+
+	if isConnectionError(err) {
+		conn = e.conn
+		e.conn = nil
+	}
+
+	e.mu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+
+	return wasHealthy
+}
+
+// admit marks the node as healthy. It returns true if the node was not healthy.
+func (e *endpoint) admit() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	wasHealthy := e.healthy
+
+	e.healthy = true
+	e.err = nil
+
+	return !wasHealthy
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.healthy
+}
+
+func (e *endpoint) getLatency() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.latency
+}
+
+// observeLatency updates the exponential moving average of the latency of the node.
+func (e *endpoint) observeLatency(latency time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.latency == 0 {
+		e.latency = latency
+		return
+	}
+
+	e.latency = (7*e.latency + 3*latency) / 10
+}
+
+func (e *endpoint) status() EndpointStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return EndpointStatus{
+		URL:       e.url,
+		Healthy:   e.healthy,
+		Latency:   e.latency,
+		BestBlock: e.bestBlock,
+		Err:       e.err,
+	}
+}
+
+func (e *endpoint) close() {
+	e.mu.Lock()
+	conn := e.conn
+	e.conn = nil
+	e.closed = true
+	e.healthy = false
+	e.mu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+}
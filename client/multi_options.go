@@ -0,0 +1,134 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+)
+
+// RoutingPolicy is the policy used by a multi-endpoint client for choosing the node that serves a request.
+type RoutingPolicy uint8
+
+const (
+	// RoutingRoundRobin spreads the requests over the healthy nodes in turn.
+	RoutingRoundRobin RoutingPolicy = iota
+	// RoutingLowestLatency sends the requests to the healthy node with the lowest latency.
+	RoutingLowestLatency
+	// RoutingPrimaryFallback sends the requests to the first healthy node, in the order of the provided endpoints.
+	RoutingPrimaryFallback
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+	defaultMaxBlockLag         = 5
+)
+
+// MultiOpts holds the options of a multi-endpoint client.
+type MultiOpts struct {
+	policy RoutingPolicy
+
+	healthCheckInterval time.Duration
+	healthCheckTimeout  time.Duration
+	maxBlockLag         uint64
+
+	callAttempts int
+
+	eventBufferSize int
+
+	subscriptionOpts *ReconnectOpts
+
+	connectOpts *ConnectOpts
+}
+
+// NewDefaultMultiOpts returns the default options of a multi-endpoint client:
+//
+//   - requests are spread over the healthy nodes in a round-robin fashion.
+//   - failed calls are attempted on every healthy node.
+//   - nodes are checked every 10s, and ejected when syncing or lagging more than 5 blocks behind the best node.
+//   - subscriptions follow the defaults of NewDefaultReconnectOpts when they move to another node.
+func NewDefaultMultiOpts() *MultiOpts {
+	return &MultiOpts{
+		policy:              RoutingRoundRobin,
+		healthCheckInterval: defaultHealthCheckInterval,
+		healthCheckTimeout:  defaultHealthCheckTimeout,
+		maxBlockLag:         defaultMaxBlockLag,
+		eventBufferSize:     defaultEventBufferSize,
+		subscriptionOpts:    NewDefaultReconnectOpts(),
+		connectOpts:         NewDefaultConnectOpts(),
+	}
+}
+
+// MultiOptsFn is function that sets an option of a multi-endpoint client.
+type MultiOptsFn func(opts *MultiOpts)
+
+// WithRoutingPolicy sets the RoutingPolicy of the client.
+func WithRoutingPolicy(policy RoutingPolicy) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		opts.policy = policy
+	}
+}
+
+// WithHealthCheck sets the interval and the timeout of the health checks. A zero interval disables the periodic
+// health checks, which can still be run with MultiClient.CheckHealth.
+func WithHealthCheck(interval, timeout time.Duration) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		opts.healthCheckInterval = interval
+		opts.healthCheckTimeout = timeout
+	}
+}
+
+// WithMaxBlockLag sets the number of blocks that a node can lag behind the best node before being ejected.
+func WithMaxBlockLag(maxBlockLag uint64) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		opts.maxBlockLag = maxBlockLag
+	}
+}
+
+// WithCallAttempts sets the maximum number of nodes that a call is attempted on when failing due to the connection.
+// A value of 0 means that the call is attempted on every healthy node.
+func WithCallAttempts(callAttempts int) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		opts.callAttempts = callAttempts
+	}
+}
+
+// WithMultiEventBufferSize sets the size of the buffer of the connection events channel.
+func WithMultiEventBufferSize(size int) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		opts.eventBufferSize = size
+	}
+}
+
+// WithSubscriptionOpts sets the options used for the subscriptions that move to another node, such as WithGapFiller
+// and WithNonResumable. The backoff and connection related options are not used by the multi-endpoint client.
+func WithSubscriptionOpts(subscriptionOpts ...ReconnectOptsFn) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		for _, opt := range subscriptionOpts {
+			opt(opts.subscriptionOpts)
+		}
+	}
+}
+
+// WithMultiConnectOpts sets the options used for connecting to the nodes.
+func WithMultiConnectOpts(connectOpts ...ConnectOptsFn) MultiOptsFn {
+	return func(opts *MultiOpts) {
+		for _, opt := range connectOpts {
+			opt(opts.connectOpts)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiClient_RoundRobin(t *testing.T) {
+	nodes, urls := newTestNodes(t, 3)
+
+	cl, err := ConnectMulti(urls, WithHealthCheck(0, time.Second), WithRoutingPolicy(RoutingRoundRobin))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	for i := 0; i < 6; i++ {
+		var name string
+
+		err := cl.Call(&name, "system_name")
+		assert.NoError(t, err)
+	}
+
+	for _, node := range nodes {
+		assert.Equal(t, 2, node.system.nameCalls())
+	}
+}
+
+func TestMultiClient_LowestLatency(t *testing.T) {
+	nodes, urls := newTestNodes(t, 3)
+
+	nodes[0].system.setDelay(100 * time.Millisecond)
+	nodes[1].system.setDelay(50 * time.Millisecond)
+
+	cl, err := ConnectMulti(urls, WithHealthCheck(0, time.Second), WithRoutingPolicy(RoutingLowestLatency))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	assert.Equal(t, nodes[2].srv.URL, cl.URL())
+
+	var name string
+
+	err = cl.Call(&name, "system_name")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nodes[2].system.nameCalls())
+}
+
+func TestMultiClient_PrimaryFallback(t *testing.T) {
+	nodes, urls := newTestNodes(t, 2)
+
+	cl, err := ConnectMulti(urls, WithHealthCheck(0, time.Second), WithRoutingPolicy(RoutingPrimaryFallback))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	var name string
+
+	err = cl.Call(&name, "system_name")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nodes[0].system.nameCalls())
+
+	require.NoError(t, nodes[0].srv.Close())
+
+	// The call fails on the primary node, which is ejected, and is attempted on the fallback node.
+	err = cl.Call(&name, "system_name")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nodes[1].system.nameCalls())
+
+	event := waitForMultiEvent(t, cl, func(event ConnectionEvent) bool {
+		return event.State == ConnectionStateDisconnected
+	})
+	assert.Equal(t, nodes[0].srv.URL, event.URL)
+	assert.Error(t, event.Err)
+
+	assert.Equal(t, nodes[1].srv.URL, cl.URL())
+
+	require.NoError(t, nodes[0].srv.Restart())
+
+	cl.CheckHealth(context.Background())
+
+	event = waitForMultiEvent(t, cl, func(event ConnectionEvent) bool {
+		return event.State == ConnectionStateConnected
+	})
+	assert.Equal(t, nodes[0].srv.URL, event.URL)
+
+	err = cl.Call(&name, "system_name")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, nodes[0].system.nameCalls())
+}
+
+func TestMultiClient_CheckHealth(t *testing.T) {
+	nodes, urls := newTestNodes(t, 3)
+
+	cl, err := ConnectMulti(urls, WithHealthCheck(0, time.Second), WithMaxBlockLag(2))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	nodes[0].chain.produce(5)
+	nodes[1].chain.produce(3)
+	nodes[2].chain.produce(2)
+	nodes[2].system.setSyncing(true)
+
+	cl.CheckHealth(context.Background())
+
+	endpoints := cl.Endpoints()
+
+	assert.True(t, endpoints[0].Healthy)
+	assert.Equal(t, uint64(5), endpoints[0].BestBlock)
+	assert.NotZero(t, endpoints[0].Latency)
+
+	assert.True(t, endpoints[1].Healthy)
+	assert.Equal(t, uint64(3), endpoints[1].BestBlock)
+
+	assert.False(t, endpoints[2].Healthy)
+	assert.ErrorIs(t, endpoints[2].Err, ErrEndpointSyncing)
+
+	nodes[0].chain.produce(1)
+
+	cl.CheckHealth(context.Background())
+
+	endpoints = cl.Endpoints()
+
+	assert.True(t, endpoints[0].Healthy)
+	assert.False(t, endpoints[1].Healthy)
+	assert.ErrorIs(t, endpoints[1].Err, ErrEndpointLagging)
+	assert.False(t, endpoints[2].Healthy)
+
+	for i := 0; i < 3; i++ {
+		var name string
+
+		err := cl.Call(&name, "system_name")
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, nodes[0].system.nameCalls())
+
+	nodes[1].chain.produce(3)
+	nodes[2].system.setSyncing(false)
+	nodes[2].chain.produce(4)
+
+	cl.CheckHealth(context.Background())
+
+	for _, endpoint := range cl.Endpoints() {
+		assert.True(t, endpoint.Healthy)
+		assert.NoError(t, endpoint.Err)
+	}
+}
+
+func TestMultiClient_SubscriptionMoves(t *testing.T) {
+	nodes, urls := newTestNodes(t, 2)
+
+	cl, err := ConnectMulti(urls, WithHealthCheck(0, time.Second), WithRoutingPolicy(RoutingPrimaryFallback))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	ch := make(chan types.Header)
+
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+
+	defer sub.Unsubscribe()
+
+	waitForSubscriptions(t, nodes[0].chain, 1)
+	assert.Equal(t, 0, nodes[1].chain.subscriptionCount())
+
+	produceOnNodes(nodes, 2)
+
+	assertHeaders(t, ch, 1, 2)
+
+	// The primary node falls behind before failing.
+	nodes[1].chain.produce(3)
+
+	require.NoError(t, nodes[0].srv.Close())
+
+	waitForSubscriptions(t, nodes[1].chain, 1)
+
+	nodes[1].chain.produce(1)
+
+	// The headers missed by the primary node are retrieved from the fallback node.
+	assertHeaders(t, ch, 3, 4, 5, 6)
+
+	event := waitForMultiEvent(t, cl, func(event ConnectionEvent) bool {
+		return event.Gap != nil
+	})
+	assert.Equal(t, nodes[1].srv.URL, event.URL)
+	assert.Equal(t, 3, event.Gap.Missed)
+
+	assert.False(t, cl.Endpoints()[0].Healthy)
+
+	sub.Unsubscribe()
+
+	waitForSubscriptions(t, nodes[1].chain, 0)
+}
+
+func TestMultiClient_BlockingDial(t *testing.T) {
+	nodes, urls := newTestNodes(t, 2)
+
+	cl, err := ConnectMulti(urls, WithHealthCheck(0, time.Second), WithRoutingPolicy(RoutingPrimaryFallback))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	// The listener accepts the connections without ever completing the websocket handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			defer conn.Close()
+		}
+	}()
+
+	blocking := cl.(*multiClient).endpoints[1]
+	blocking.close()
+
+	blocking.mu.Lock()
+	blocking.url = "ws://" + listener.Addr().String()
+	blocking.closed = false
+	blocking.healthy = true
+	blocking.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	dialed := make(chan error)
+
+	go func() {
+		_, err := blocking.connection(ctx)
+		dialed <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		blocking.mu.Lock()
+		defer blocking.mu.Unlock()
+
+		return blocking.dialing != nil
+	}, testTimeout, time.Millisecond)
+
+	// The routing and the status of the nodes are not blocked by the pending dial.
+	var name string
+
+	err = cl.Call(&name, "system_name")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nodes[0].system.nameCalls())
+	assert.True(t, cl.Endpoints()[1].Healthy)
+
+	select {
+	case <-dialed:
+		t.Fatal("routing waited for the pending dial")
+	default:
+	}
+
+	// The concurrent callers wait for the pending dial, until their context is done.
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer waitCancel()
+
+	_, err = blocking.connection(waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case err := <-dialed:
+		assert.Error(t, err)
+	case <-time.After(testTimeout):
+		t.Fatal("dial not timed out")
+	}
+
+	blocking.mu.Lock()
+	defer blocking.mu.Unlock()
+
+	assert.Nil(t, blocking.dialing)
+	assert.Nil(t, blocking.conn)
+}
+
+func TestConnectMulti_Errors(t *testing.T) {
+	cl, err := ConnectMulti(nil)
+	assert.ErrorIs(t, err, ErrNoEndpoints)
+	assert.Nil(t, cl)
+
+	srv := rpcmocksrv.New()
+	require.NoError(t, srv.Close())
+
+	cl, err = ConnectMulti([]string{srv.URL}, WithHealthCheck(0, time.Second))
+	assert.ErrorIs(t, err, ErrNoHealthyEndpoints)
+	assert.Nil(t, cl)
+}
+
+type testNode struct {
+	srv    *rpcmocksrv.Server
+	chain  *testChainService
+	system *testSystemService
+}
+
+// newTestNodes starts count test nodes and returns them along with their URLs.
+func newTestNodes(t *testing.T, count int) ([]*testNode, []string) {
+	var (
+		nodes []*testNode
+		urls  []string
+	)
+
+	for i := 0; i < count; i++ {
+		srv, chainService := newTestServer(t)
+
+		systemService := &testSystemService{}
+
+		err := srv.RegisterName("system", systemService)
+		require.NoError(t, err)
+
+		nodes = append(nodes, &testNode{
+			srv:    srv,
+			chain:  chainService,
+			system: systemService,
+		})
+		urls = append(urls, srv.URL)
+	}
+
+	return nodes, urls
+}
+
+func produceOnNodes(nodes []*testNode, count int) {
+	for _, node := range nodes {
+		node.chain.produce(count)
+	}
+}
+
+func waitForMultiEvent(t *testing.T, cl MultiClient, match func(event ConnectionEvent) bool) ConnectionEvent {
+	timeout := time.After(testTimeout)
+
+	for {
+		select {
+		case event := <-cl.ConnectionEvents():
+			if match(event) {
+				return event
+			}
+		case <-timeout:
+			t.Fatal("expected connection event not received")
+		}
+	}
+}
+
+// testSystemService is a system RPC service that counts the calls of system_name.
+type testSystemService struct {
+	mu      sync.Mutex
+	syncing bool
+	delay   time.Duration
+	calls   int
+}
+
+func (s *testSystemService) Health() types.Health {
+	s.mu.Lock()
+	syncing, delay := s.syncing, s.delay
+	s.mu.Unlock()
+
+	time.Sleep(delay)
+
+	return types.Health{Peers: 1, IsSyncing: syncing, ShouldHavePeers: true}
+}
+
+func (s *testSystemService) Name() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	return "test"
+}
+
+func (s *testSystemService) setSyncing(syncing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.syncing = syncing
+}
+
+func (s *testSystemService) setDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delay = delay
+}
+
+func (s *testSystemService) nameCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
@@ -0,0 +1,179 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+// PrometheusInterceptor is a client.Interceptor that records Prometheus metrics about the RPC calls, subscriptions
+// and notifications, labeled by method and kind:
+//
+//   - <namespace>_rpc_requests_total, with a status label that is either "ok" or "error".
+//   - <namespace>_rpc_request_duration_seconds.
+//   - <namespace>_rpc_request_size_bytes and <namespace>_rpc_response_size_bytes.
+//   - <namespace>_rpc_requests_in_flight.
+//
+// The requests of a batch are recorded individually, with the kind "batch" and the latency of the whole batch.
+type PrometheusInterceptor struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	requestSize  *prometheus.HistogramVec
+	responseSize *prometheus.HistogramVec
+	inFlight     *prometheus.GaugeVec
+}
+
+// NewPrometheusInterceptor creates a PrometheusInterceptor and registers its metrics, whose names are prefixed
+// by the provided namespace, with the registerer. Metrics that are already registered, e.g. by another interceptor
+// with the same namespace, are shared.
+func NewPrometheusInterceptor(registerer prometheus.Registerer, namespace string) (*PrometheusInterceptor, error) {
+	labels := []string{"method", "kind"}
+	sizeBuckets := prometheus.ExponentialBuckets(64, 4, 10)
+
+	p := &PrometheusInterceptor{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "rpc",
+			Name:      "requests_total",
+			Help:      "Number of RPC requests and notifications.",
+		}, append(labels, "status")),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "rpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the RPC requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "rpc",
+			Name:      "request_size_bytes",
+			Help:      "Size of the JSON encoded params of the RPC requests.",
+			Buckets:   sizeBuckets,
+		}, labels),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "rpc",
+			Name:      "response_size_bytes",
+			Help:      "Size of the JSON encoded results of the RPC requests and notifications.",
+			Buckets:   sizeBuckets,
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "rpc",
+			Name:      "requests_in_flight",
+			Help:      "Number of RPC requests waiting for a response.",
+		}, []string{"kind"}),
+	}
+
+	var err error
+
+	if p.requests, err = register(registerer, p.requests); err != nil {
+		return nil, err
+	}
+
+	if p.duration, err = register(registerer, p.duration); err != nil {
+		return nil, err
+	}
+
+	if p.requestSize, err = register(registerer, p.requestSize); err != nil {
+		return nil, err
+	}
+
+	if p.responseSize, err = register(registerer, p.responseSize); err != nil {
+		return nil, err
+	}
+
+	if p.inFlight, err = register(registerer, p.inFlight); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Start increments the number of requests in flight.
+func (p *PrometheusInterceptor) Start(ctx context.Context, info *client.CallInfo) context.Context {
+	if info.Kind != client.CallKindNotification {
+		p.inFlight.WithLabelValues(info.Kind.String()).Inc()
+	}
+
+	return ctx
+}
+
+// End records the metrics of the ended operation.
+func (p *PrometheusInterceptor) End(_ context.Context, info *client.CallInfo) {
+	kind := info.Kind.String()
+
+	if info.Kind != client.CallKindNotification {
+		p.inFlight.WithLabelValues(kind).Dec()
+	}
+
+	if info.Kind != client.CallKindBatch {
+		p.record(kind, info)
+		return
+	}
+
+	for _, elemInfo := range info.Batch {
+		p.record(kind, elemInfo)
+	}
+}
+
+func (p *PrometheusInterceptor) record(kind string, info *client.CallInfo) {
+	status := statusOK
+
+	if info.Err != nil {
+		status = statusError
+	}
+
+	p.requests.WithLabelValues(info.Method, kind, status).Inc()
+	p.responseSize.WithLabelValues(info.Method, kind).Observe(float64(info.ResultSize))
+
+	if info.Kind == client.CallKindNotification {
+		return
+	}
+
+	p.duration.WithLabelValues(info.Method, kind).Observe(info.Latency.Seconds())
+	p.requestSize.WithLabelValues(info.Method, kind).Observe(float64(info.ParamsSize))
+}
+
+// register registers the collector, or returns the existing one if an identical collector is already registered.
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+
+	if err == nil {
+		return collector, nil
+	}
+
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+
+	return collector, err
+}
@@ -0,0 +1,83 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusInterceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	interceptor, err := NewPrometheusInterceptor(registry, "test")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	call := &client.CallInfo{Kind: client.CallKindCall, Method: "chain_getHeader", ParamsSize: 10}
+
+	ctx = interceptor.Start(ctx, call)
+	assert.Equal(t, 1.0, testutil.ToFloat64(interceptor.inFlight.WithLabelValues("call")))
+
+	call.ResultSize = 100
+	call.Latency = time.Millisecond
+
+	interceptor.End(ctx, call)
+	assert.Equal(t, 0.0, testutil.ToFloat64(interceptor.inFlight.WithLabelValues("call")))
+
+	batch := &client.CallInfo{
+		Kind:   client.CallKindBatch,
+		Method: client.BatchMethod,
+		Batch: []*client.CallInfo{
+			{Kind: client.CallKindCall, Method: "chain_getHeader"},
+			{Kind: client.CallKindCall, Method: "state_getStorage", Err: errors.New("error")},
+		},
+	}
+
+	interceptor.End(interceptor.Start(ctx, batch), batch)
+
+	notification := &client.CallInfo{Kind: client.CallKindNotification, Method: "chain_subscribeNewHeads"}
+
+	interceptor.End(interceptor.Start(ctx, notification), notification)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(interceptor.requests.WithLabelValues("chain_getHeader", "call", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(interceptor.requests.WithLabelValues("chain_getHeader", "batch", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(interceptor.requests.WithLabelValues("state_getStorage", "batch", "error")))
+	assert.Equal(
+		t,
+		1.0,
+		testutil.ToFloat64(interceptor.requests.WithLabelValues("chain_subscribeNewHeads", "notification", "ok")),
+	)
+
+	// Notifications have no latency.
+	assert.Equal(t, 3, testutil.CollectAndCount(interceptor.duration))
+	assert.Equal(t, 4, testutil.CollectAndCount(interceptor.responseSize))
+
+	// The metrics are shared by the interceptors with the same namespace.
+	other, err := NewPrometheusInterceptor(registry, "test")
+	require.NoError(t, err)
+	assert.Same(t, interceptor.requests, other.requests)
+}
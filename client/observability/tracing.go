@@ -0,0 +1,103 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/centrifuge/go-substrate-rpc-client/v4/client/observability"
+
+// TracingInterceptor is a client.Interceptor that creates an OpenTelemetry client span for every RPC call, batch and
+// subscription request. The span is set in the context used for the request, which lets the HTTP transport propagate
+// the trace context to the node. Every notification is recorded in a consumer span, which is a child of the span of
+// the subscription request.
+type TracingInterceptor struct {
+	tracer trace.Tracer
+}
+
+// NewTracingInterceptor creates a TracingInterceptor that uses a tracer of the provided provider.
+func NewTracingInterceptor(provider trace.TracerProvider) *TracingInterceptor {
+	return &TracingInterceptor{
+		tracer: provider.Tracer(tracerName),
+	}
+}
+
+// Start starts the span of the operation.
+func (t *TracingInterceptor) Start(ctx context.Context, info *client.CallInfo) context.Context {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", info.Method),
+		attribute.String("rpc.gsrpc.kind", info.Kind.String()),
+		attribute.Int("rpc.gsrpc.params_size", info.ParamsSize),
+	}
+
+	if info.Kind == client.CallKindBatch {
+		attrs = append(attrs, attribute.Int("rpc.gsrpc.batch_size", len(info.Batch)))
+	}
+
+	kind := trace.SpanKindClient
+
+	if info.Kind == client.CallKindNotification {
+		kind = trace.SpanKindConsumer
+	}
+
+	ctx, _ = t.tracer.Start(ctx, info.Method, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+
+	return ctx
+}
+
+// End ends the span of the operation, recording its result size and error.
+func (t *TracingInterceptor) End(ctx context.Context, info *client.CallInfo) {
+	span := trace.SpanFromContext(ctx)
+
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("rpc.gsrpc.result_size", info.ResultSize))
+
+	if info.Kind == client.CallKindBatch {
+		failed := 0
+
+		for _, elemInfo := range info.Batch {
+			if elemInfo.Err != nil {
+				failed++
+			}
+		}
+
+		span.SetAttributes(attribute.Int("rpc.gsrpc.batch_failed", failed))
+	}
+
+	if info.Err == nil {
+		return
+	}
+
+	var rpcErr gethrpc.Error
+
+	if errors.As(info.Err, &rpcErr) {
+		span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", rpcErr.ErrorCode()))
+	}
+
+	span.RecordError(info.Err)
+	span.SetStatus(codes.Error, info.Err.Error())
+}
@@ -0,0 +1,99 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	interceptor := NewTracingInterceptor(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	call := &client.CallInfo{Kind: client.CallKindCall, Method: "chain_getHeader", ParamsSize: 10}
+
+	ctx := interceptor.Start(context.Background(), call)
+
+	assert.True(t, trace.SpanFromContext(ctx).IsRecording())
+	assert.Empty(t, recorder.Ended())
+
+	call.ResultSize = 100
+	call.Err = errors.New("error")
+
+	interceptor.End(ctx, call)
+
+	require.Len(t, recorder.Ended(), 1)
+
+	span := recorder.Ended()[0]
+	assert.Equal(t, "chain_getHeader", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Contains(t, span.Attributes(), attribute.String("rpc.method", "chain_getHeader"))
+	assert.Contains(t, span.Attributes(), attribute.Int("rpc.gsrpc.params_size", 10))
+	assert.Contains(t, span.Attributes(), attribute.Int("rpc.gsrpc.result_size", 100))
+	assert.Equal(t, codes.Error, span.Status().Code)
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "exception", span.Events()[0].Name)
+}
+
+func TestTracingInterceptor_Notifications(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	interceptor := NewTracingInterceptor(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	subscribe := &client.CallInfo{Kind: client.CallKindSubscribe, Method: "chain_subscribeNewHeads"}
+
+	ctx := interceptor.Start(context.Background(), subscribe)
+	interceptor.End(ctx, subscribe)
+
+	// The notifications are received after the span of the subscription request ended.
+	for _, size := range []int{100, 200} {
+		notification := &client.CallInfo{
+			Kind:       client.CallKindNotification,
+			Method:     "chain_subscribeNewHeads",
+			ResultSize: size,
+		}
+
+		interceptor.End(interceptor.Start(ctx, notification), notification)
+	}
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	subscribeSpan := spans[0]
+	assert.Equal(t, trace.SpanKindClient, subscribeSpan.SpanKind())
+
+	for i, span := range spans[1:] {
+		assert.Equal(t, "chain_subscribeNewHeads", span.Name())
+		assert.Equal(t, trace.SpanKindConsumer, span.SpanKind())
+		assert.Equal(t, subscribeSpan.SpanContext().TraceID(), span.SpanContext().TraceID())
+		assert.Equal(t, subscribeSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), attribute.String("rpc.gsrpc.kind", "notification"))
+		assert.Contains(t, span.Attributes(), attribute.Int("rpc.gsrpc.result_size", 100*(i+1)))
+	}
+}
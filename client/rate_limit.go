@@ -0,0 +1,410 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/gorilla/websocket"
+)
+
+// ErrThrottled is returned when a request is still throttled by the provider after all its retries.
+var ErrThrottled = errors.New("request throttled")
+
+// rateLimitedClient is a Client that limits the rate and the concurrency of the requests, and retries the requests
+// throttled by the provider.
+type rateLimitedClient struct {
+	Client
+
+	opts *RateLimitOpts
+
+	// reconnects is true if the wrapped client is a ReconnectingClient, which is the only client that can retry the
+	// requests that failed because the node closed the connection.
+	reconnects bool
+
+	// bucket is nil if the request rate is not limited.
+	bucket *tokenBucket
+	// slots is nil if the number of concurrent requests is not limited.
+	slots chan struct{}
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// NewRateLimitedClient wraps the provided client with a limiter of the requests, as configured by the options.
+// The returned client can be used by all the rpc packages, which then share the limits.
+//
+// Throttling responses of the provider pause all the requests of the client for the delay found in their
+// Retry-After header, if any, or for an exponential backoff, after which the throttled requests are retried.
+//
+// The websocket close codes are only recognized as throttling responses if the provided client is a
+// ReconnectingClient, since the other clients cannot send the retried requests once the connection is closed.
+func NewRateLimitedClient(c Client, opts ...RateLimitOptsFn) Client {
+	rateLimitOpts := NewDefaultRateLimitOpts()
+
+	for _, opt := range opts {
+		opt(rateLimitOpts)
+	}
+
+	_, reconnects := c.(ReconnectingClient)
+
+	rl := &rateLimitedClient{
+		Client:     c,
+		opts:       rateLimitOpts,
+		reconnects: reconnects,
+	}
+
+	if rateLimitOpts.requestsPerSecond > 0 {
+		rl.bucket = newTokenBucket(rateLimitOpts.requestsPerSecond, rateLimitOpts.burst)
+	}
+
+	if rateLimitOpts.maxConcurrent > 0 {
+		rl.slots = make(chan struct{}, rateLimitOpts.maxConcurrent)
+	}
+
+	return rl
+}
+
+func (c *rateLimitedClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *rateLimitedClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	return c.do(ctx, c.opts.weight(method), func() error {
+		return c.Client.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (c *rateLimitedClient) BatchCall(b []gethrpc.BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+// BatchCallContext sends the batch as a single request that weighs as much as all its requests. The requests of the
+// batch that are throttled are retried in a new batch.
+func (c *rateLimitedClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	pending := make([]int, len(b))
+
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for retry := 0; ; retry++ {
+		batch := make([]gethrpc.BatchElem, len(pending))
+
+		var weight float64
+
+		for j, i := range pending {
+			batch[j] = gethrpc.BatchElem{
+				Method: b[i].Method,
+				Args:   b[i].Args,
+				Result: b[i].Result,
+			}
+
+			weight += c.opts.weight(b[i].Method)
+		}
+
+		release, err := c.acquire(ctx, weight)
+
+		if err != nil {
+			return err
+		}
+
+		err = c.Client.BatchCallContext(ctx, batch)
+
+		release()
+
+		retryAfter, throttled := c.isThrottled(err)
+
+		switch {
+		case err != nil && !throttled:
+			return err
+		case err != nil && retry == c.opts.maxRetries:
+			return fmt.Errorf("%w: %w", ErrThrottled, err)
+		case err == nil:
+			var next []int
+
+			for j, i := range pending {
+				b[i].Error = batch[j].Error
+
+				elemRetryAfter, elemThrottled := c.isThrottled(batch[j].Error)
+
+				if !elemThrottled {
+					continue
+				}
+
+				if retry == c.opts.maxRetries {
+					b[i].Error = fmt.Errorf("%w: %w", ErrThrottled, batch[j].Error)
+					continue
+				}
+
+				next = append(next, i)
+
+				if elemRetryAfter > retryAfter {
+					retryAfter = elemRetryAfter
+				}
+			}
+
+			if len(next) == 0 {
+				return nil
+			}
+
+			pending = next
+		}
+
+		c.pause(retry+1, retryAfter)
+	}
+}
+
+func (c *rateLimitedClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	var sub *gethrpc.ClientSubscription
+
+	err := c.do(ctx, c.opts.weight(namespace+"_"+subscribeMethodSuffix), func() error {
+		var err error
+
+		sub, err = c.Client.Subscribe(
+			ctx,
+			namespace,
+			subscribeMethodSuffix,
+			unsubscribeMethodSuffix,
+			notificationMethodSuffix,
+			channel,
+			args...,
+		)
+
+		return err
+	})
+
+	return sub, err
+}
+
+// do sends the request once it is allowed by the limits, and retries it while it is throttled.
+func (c *rateLimitedClient) do(ctx context.Context, weight float64, request func() error) error {
+	for retry := 0; ; retry++ {
+		release, err := c.acquire(ctx, weight)
+
+		if err != nil {
+			return err
+		}
+
+		err = request()
+
+		release()
+
+		retryAfter, throttled := c.isThrottled(err)
+
+		if !throttled {
+			return err
+		}
+
+		if retry == c.opts.maxRetries {
+			return fmt.Errorf("%w: %w", ErrThrottled, err)
+		}
+
+		c.pause(retry+1, retryAfter)
+	}
+}
+
+// acquire waits until the client is not paused, the request rate allows the provided weight and a concurrency slot
+// is free. The returned function releases the concurrency slot.
+func (c *rateLimitedClient) acquire(ctx context.Context, weight float64) (func(), error) {
+	for {
+		c.mu.Lock()
+		delay := time.Until(c.pausedUntil)
+		c.mu.Unlock()
+
+		if delay <= 0 {
+			break
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.bucket != nil {
+		if err := c.bucket.wait(ctx, weight); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// pause pauses all the requests of the client for the delay requested by the provider, or for the backoff of the
+// retry if there is none.
+func (c *rateLimitedClient) pause(retry int, retryAfter time.Duration) {
+	delay := retryAfter
+
+	if delay <= 0 {
+		delay = c.opts.backoff(retry)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if until := time.Now().Add(delay); until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+}
+
+// isThrottled returns true if the error is a throttling response of the provider, along with the delay requested
+// by the provider, if any.
+func (c *rateLimitedClient) isThrottled(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+
+	var httpErr gethrpc.HTTPError
+
+	if errors.As(err, &httpErr) {
+		if _, ok := c.opts.throttleStatusCodes[httpErr.StatusCode]; ok {
+			return parseRetryAfter(httpErr.Header.Get("Retry-After")), true
+		}
+
+		return 0, false
+	}
+
+	var rpcErr gethrpc.Error
+
+	if errors.As(err, &rpcErr) {
+		_, ok := c.opts.throttleErrorCodes[rpcErr.ErrorCode()]
+
+		return 0, ok
+	}
+
+	var closeErr *websocket.CloseError
+
+	if c.reconnects && errors.As(err, &closeErr) {
+		_, ok := c.opts.throttleWSCloseCodes[closeErr.Code]
+
+		return 0, ok
+	}
+
+	var handshakeErr interface{ StatusCode() int }
+
+	if errors.As(err, &handshakeErr) {
+		_, ok := c.opts.throttleStatusCodes[handshakeErr.StatusCode()]
+
+		return 0, ok
+	}
+
+	return 0, false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tokenBucket limits the rate of the requests. Requests reserve their weight from the bucket, and wait for the
+// tokens they are missing to be refilled.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait reserves the provided number of tokens and waits until they are available.
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	b.mu.Lock()
+
+	now := time.Now()
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= n
+
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, delay); err != nil {
+		b.mu.Lock()
+		b.tokens += n
+		b.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
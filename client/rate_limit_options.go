@@ -0,0 +1,162 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultThrottleMinBackoff    = time.Second
+	defaultThrottleMaxBackoff    = 30 * time.Second
+	defaultThrottleBackoffFactor = 2
+	defaultMaxThrottleRetries    = 5
+)
+
+// RateLimitOpts holds the options of a rate limited client.
+type RateLimitOpts struct {
+	requestsPerSecond float64
+	burst             float64
+	maxConcurrent     int
+	weights           map[string]float64
+
+	maxRetries           int
+	minBackoff           time.Duration
+	maxBackoff           time.Duration
+	backoffFactor        float64
+	throttleStatusCodes  map[int]struct{}
+	throttleErrorCodes   map[int]struct{}
+	throttleWSCloseCodes map[int]struct{}
+}
+
+// NewDefaultRateLimitOpts returns the default options of a rate limited client:
+//
+//   - the request rate and the number of concurrent requests are not limited.
+//   - the storage key and storage query methods, which are expensive for the node, weigh 5 requests.
+//   - throttled requests are retried up to 5 times, after the delay requested by the provider, if any, or after
+//     an exponential backoff between 1s and 30s.
+//   - the HTTP 429 and 503 statuses, the 429 and -32005 JSON-RPC error codes and, for a ReconnectingClient, the
+//     websocket policy violation and try again later close codes are recognized as throttling responses.
+func NewDefaultRateLimitOpts() *RateLimitOpts {
+	return &RateLimitOpts{
+		weights: map[string]float64{
+			"state_getKeys":        5,
+			"state_getKeysPaged":   5,
+			"state_getPairs":       5,
+			"state_queryStorage":   5,
+			"state_queryStorageAt": 5,
+		},
+		maxRetries:    defaultMaxThrottleRetries,
+		minBackoff:    defaultThrottleMinBackoff,
+		maxBackoff:    defaultThrottleMaxBackoff,
+		backoffFactor: defaultThrottleBackoffFactor,
+		throttleStatusCodes: map[int]struct{}{
+			http.StatusTooManyRequests:    {},
+			http.StatusServiceUnavailable: {},
+		},
+		throttleErrorCodes: map[int]struct{}{
+			http.StatusTooManyRequests: {},
+			-32005:                     {},
+		},
+		throttleWSCloseCodes: map[int]struct{}{
+			websocket.ClosePolicyViolation: {},
+			websocket.CloseTryAgainLater:   {},
+		},
+	}
+}
+
+// RateLimitOptsFn is function that sets an option of a rate limited client.
+type RateLimitOptsFn func(opts *RateLimitOpts)
+
+// WithRequestsPerSecond limits the rate of the requests, allowing bursts of up to burst requests. A rate of 0
+// disables the limit. A burst lower than 1 is set to the rate, rounded up.
+func WithRequestsPerSecond(requestsPerSecond float64, burst int) RateLimitOptsFn {
+	return func(opts *RateLimitOpts) {
+		opts.requestsPerSecond = requestsPerSecond
+		opts.burst = float64(burst)
+
+		if burst < 1 {
+			opts.burst = math.Max(1, math.Ceil(requestsPerSecond))
+		}
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests in flight. A batch counts as a single request.
+// A limit of 0 disables it.
+func WithMaxConcurrentRequests(maxConcurrent int) RateLimitOptsFn {
+	return func(opts *RateLimitOpts) {
+		opts.maxConcurrent = maxConcurrent
+	}
+}
+
+// WithMethodWeight sets the number of requests that a call to the method counts as against the requests per second
+// limit. The methods without a weight count as one request.
+func WithMethodWeight(method string, weight float64) RateLimitOptsFn {
+	return func(opts *RateLimitOpts) {
+		opts.weights[method] = weight
+	}
+}
+
+// WithMaxThrottleRetries sets the number of retries of a throttled request. 0 disables the retries.
+func WithMaxThrottleRetries(maxRetries int) RateLimitOptsFn {
+	return func(opts *RateLimitOpts) {
+		opts.maxRetries = maxRetries
+	}
+}
+
+// WithThrottleBackoff sets the exponential backoff applied between the retries of a throttled request, when the
+// provider does not specify a delay. The delay starts at min and is multiplied by factor after every retry, up to max.
+func WithThrottleBackoff(min, max time.Duration, factor float64) RateLimitOptsFn {
+	return func(opts *RateLimitOpts) {
+		opts.minBackoff = min
+		opts.maxBackoff = max
+		opts.backoffFactor = factor
+	}
+}
+
+// WithThrottleErrorCodes adds JSON-RPC error codes that are recognized as throttling responses.
+func WithThrottleErrorCodes(codes ...int) RateLimitOptsFn {
+	return func(opts *RateLimitOpts) {
+		for _, code := range codes {
+			opts.throttleErrorCodes[code] = struct{}{}
+		}
+	}
+}
+
+// weight returns the number of requests that a call to the method counts as.
+func (o *RateLimitOpts) weight(method string) float64 {
+	if weight, ok := o.weights[method]; ok {
+		return weight
+	}
+
+	return 1
+}
+
+// backoff returns the delay before the provided retry, starting at 1.
+func (o *RateLimitOpts) backoff(retry int) time.Duration {
+	delay := float64(o.minBackoff) * math.Pow(o.backoffFactor, float64(retry-1))
+
+	if delay > float64(o.maxBackoff) {
+		return o.maxBackoff
+	}
+
+	return time.Duration(delay)
+}
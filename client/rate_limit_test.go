@@ -0,0 +1,331 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedClient_RequestsPerSecond(t *testing.T) {
+	inner, _ := newTestRateServer(t)
+
+	cl := NewRateLimitedClient(inner, WithRequestsPerSecond(50, 1), WithMethodWeight("rate_heavy", 5))
+
+	start := time.Now()
+
+	for i := 0; i < 6; i++ {
+		err := cl.Call(nil, "rate_light")
+		assert.NoError(t, err)
+	}
+
+	// The first request uses the burst, the following ones wait for 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	start = time.Now()
+
+	err := cl.Call(nil, "rate_heavy")
+	assert.NoError(t, err)
+
+	err = cl.Call(nil, "rate_light")
+	assert.NoError(t, err)
+
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRateLimitedClient_MaxConcurrentRequests(t *testing.T) {
+	inner, service := newTestRateServer(t)
+
+	cl := NewRateLimitedClient(inner, WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := cl.Call(nil, "rate_slow")
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(2), service.maxInFlight.Load())
+}
+
+func TestRateLimitedClient_ThrottledErrorCode(t *testing.T) {
+	inner, service := newTestRateServer(t)
+
+	cl := NewRateLimitedClient(inner, WithThrottleBackoff(10*time.Millisecond, 10*time.Millisecond, 1))
+
+	service.throttled.Store(2)
+
+	var res string
+
+	err := cl.Call(&res, "rate_throttled")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", res)
+	assert.Equal(t, int32(3), service.calls.Load())
+
+	service.calls.Store(0)
+	service.throttled.Store(10)
+
+	err = cl.Call(&res, "rate_throttled")
+	assert.ErrorIs(t, err, ErrThrottled)
+
+	var rpcErr gethrpc.Error
+
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, -32005, rpcErr.ErrorCode())
+	assert.Equal(t, int32(defaultMaxThrottleRetries+1), service.calls.Load())
+
+	// Other errors are not retried.
+	service.calls.Store(0)
+
+	err = cl.Call(&res, "rate_failing")
+	assert.EqualError(t, err, "failure")
+	assert.Equal(t, int32(1), service.calls.Load())
+}
+
+func TestRateLimitedClient_BatchCall(t *testing.T) {
+	inner, service := newTestRateServer(t)
+
+	cl := NewRateLimitedClient(inner, WithThrottleBackoff(10*time.Millisecond, 10*time.Millisecond, 1))
+
+	service.throttled.Store(1)
+
+	res := make([]string, 3)
+
+	batch := []gethrpc.BatchElem{
+		{Method: "rate_throttled", Result: &res[0]},
+		{Method: "rate_failing", Result: &res[1]},
+		{Method: "rate_throttled", Result: &res[2]},
+	}
+
+	err := cl.BatchCall(batch)
+	assert.NoError(t, err)
+
+	// The first request is throttled and sent again along with the last one.
+	assert.NoError(t, batch[0].Error)
+	assert.Equal(t, "ok", res[0])
+	assert.EqualError(t, batch[1].Error, "failure")
+	assert.NoError(t, batch[2].Error)
+	assert.Equal(t, "ok", res[2])
+	assert.Equal(t, int32(4), service.calls.Load())
+}
+
+func TestRateLimitedClient_HTTPRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"ok"}`)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	inner, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(inner.Close)
+
+	cl := NewRateLimitedClient(inner, WithThrottleBackoff(time.Millisecond, time.Millisecond, 1))
+
+	start := time.Now()
+
+	var res string
+
+	err = cl.Call(&res, "rate_throttled")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", res)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRateLimitedClient_ThrottledCloseCode(t *testing.T) {
+	closeErr := &websocket.CloseError{Code: websocket.CloseTryAgainLater}
+
+	// The close codes are only retried by a client that reconnects.
+	for _, test := range []struct {
+		reconnects bool
+		calls      int32
+	}{
+		{reconnects: false, calls: 1},
+		{reconnects: true, calls: 3},
+	} {
+		closing := &closingClient{err: closeErr}
+
+		var inner Client = closing
+
+		if test.reconnects {
+			inner = &closingReconnectingClient{closingClient: closing}
+		}
+
+		cl := NewRateLimitedClient(
+			inner,
+			WithMaxThrottleRetries(2),
+			WithThrottleBackoff(time.Millisecond, time.Millisecond, 1),
+		)
+
+		err := cl.Call(nil, "rate_closing")
+		assert.ErrorIs(t, err, closeErr)
+		assert.Equal(t, test.calls, closing.calls.Load())
+	}
+}
+
+// closingClient is a Client whose calls fail with the provided error.
+type closingClient struct {
+	Client
+
+	err   error
+	calls atomic.Int32
+}
+
+func (c *closingClient) CallContext(_ context.Context, _ interface{}, _ string, _ ...interface{}) error {
+	c.calls.Add(1)
+
+	return c.err
+}
+
+// closingReconnectingClient is a ReconnectingClient whose calls fail with the error of the closingClient.
+type closingReconnectingClient struct {
+	*closingClient
+}
+
+func (c *closingReconnectingClient) ConnectionEvents() <-chan ConnectionEvent {
+	return nil
+}
+
+func (c *closingReconnectingClient) ConnectionState() ConnectionState {
+	return ConnectionStateConnected
+}
+
+func TestRateLimitedClient_ContextCanceled(t *testing.T) {
+	inner, _ := newTestRateServer(t)
+
+	cl := NewRateLimitedClient(inner, WithRequestsPerSecond(1, 1))
+
+	err := cl.Call(nil, "rate_light")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = cl.CallContext(ctx, nil, "rate_light")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	assert.InDelta(t, time.Minute, parseRetryAfter(date), float64(2*time.Second))
+}
+
+// newTestRateServer connects a client to a new test server that serves a testRateService.
+func newTestRateServer(t *testing.T) (Client, *testRateService) {
+	srv := rpcmocksrv.New()
+
+	service := &testRateService{}
+
+	err := srv.RegisterName("rate", service)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	inner, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	t.Cleanup(inner.Close)
+
+	return inner, service
+}
+
+// testRateService is an RPC service that throttles its requests on demand.
+type testRateService struct {
+	calls       atomic.Int32
+	throttled   atomic.Int32
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+type testThrottledError struct{}
+
+func (testThrottledError) Error() string { return "too many requests" }
+
+func (testThrottledError) ErrorCode() int { return -32005 }
+
+func (s *testRateService) Light() {}
+
+func (s *testRateService) Heavy() {}
+
+func (s *testRateService) Slow() {
+	inFlight := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+
+	for {
+		maxInFlight := s.maxInFlight.Load()
+
+		if inFlight <= maxInFlight || s.maxInFlight.CompareAndSwap(maxInFlight, inFlight) {
+			break
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+}
+
+func (s *testRateService) Throttled() (string, error) {
+	s.calls.Add(1)
+
+	if s.throttled.Add(-1) >= 0 {
+		return "", testThrottledError{}
+	}
+
+	return "ok", nil
+}
+
+func (s *testRateService) Failing() error {
+	s.calls.Add(1)
+
+	return errors.New("failure")
+}
@@ -0,0 +1,497 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// ErrClientClosed is returned when using a reconnecting client that was closed.
+	ErrClientClosed = errors.New("client closed")
+	// ErrReconnectFailed is returned when a reconnecting client gave up reconnecting.
+	ErrReconnectFailed = errors.New("reconnect failed")
+	// ErrSubscriptionNotResumable is received on the error channel of a non-resumable subscription when
+	// the connection is lost.
+	ErrSubscriptionNotResumable = errors.New("subscription not resumable")
+)
+
+// ConnectionState is the state of the connection of a reconnecting client.
+type ConnectionState uint8
+
+const (
+	// ConnectionStateConnected is the state of a client that is connected to the node.
+	ConnectionStateConnected ConnectionState = iota
+	// ConnectionStateDisconnected is the state of a client that lost the connection to the node.
+	ConnectionStateDisconnected
+	// ConnectionStateReconnecting is the state of a client that is attempting to connect to the node again.
+	ConnectionStateReconnecting
+	// ConnectionStateClosed is the final state of a client, either closed or out of reconnection attempts.
+	ConnectionStateClosed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionStateConnected:
+		return "connected"
+	case ConnectionStateDisconnected:
+		return "disconnected"
+	case ConnectionStateReconnecting:
+		return "reconnecting"
+	case ConnectionStateClosed:
+		return "closed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// ConnectionEvent is emitted by a reconnecting client when its connection state changes, or when a subscription
+// was issued again after reconnecting.
+type ConnectionEvent struct {
+	// URL is the URL of the node that the event relates to.
+	URL string
+	// State is the connection state of the client.
+	State ConnectionState
+	// Attempt is the number of the reconnection attempt, set for ConnectionStateReconnecting.
+	Attempt int
+	// Err is the error that caused the state change, if any, or the error that occurred while filling a gap.
+	Err error
+	// Gap is set when a subscription was issued again after reconnecting.
+	Gap *SubscriptionGap
+}
+
+// SubscriptionGap describes the notifications that a subscription missed while the client was reconnecting.
+type SubscriptionGap struct {
+	// Method is the subscribe method of the subscription, e.g. chain_subscribeNewHeads.
+	Method string
+	// Missed is the number of missed notifications that were retrieved and delivered to the subscription.
+	Missed int
+}
+
+// ReconnectingClient is a Client that connects to the node again, with a backoff, whenever the connection is lost.
+//
+// Active subscriptions are issued again after reconnecting, without ending the subscriptions returned to the
+// callers. The notifications missed in the meantime are retrieved and delivered for the subscriptions that have
+// a GapFiller. Calls are not retried, they fail if the connection is lost while they are in-flight, and calls
+// made while reconnecting wait for the connection to be established again.
+type ReconnectingClient interface {
+	Client
+
+	// ConnectionEvents returns the channel that receives the ConnectionEvent of the client. Events are dropped
+	// if the channel buffer is full. The ConnectionStateClosed event is the last one.
+	ConnectionEvents() <-chan ConnectionEvent
+
+	// ConnectionState returns the current connection state of the client.
+	ConnectionState() ConnectionState
+}
+
+type reconnectingClient struct {
+	url  string
+	opts *ReconnectOpts
+
+	mu         sync.Mutex
+	conn       *gethrpc.Client
+	generation uint64
+	state      ConnectionState
+	connected  chan struct{} // closed when the client is connected
+	closed     chan struct{} // closed when the client is closed
+	closeErr   error
+	subs       map[*resumableSubscription]struct{}
+
+	events chan ConnectionEvent
+}
+
+// ConnectWithReconnect connects to the provided url and returns a ReconnectingClient. The initial connection
+// is not retried.
+func ConnectWithReconnect(url string, opts ...ReconnectOptsFn) (ReconnectingClient, error) {
+	reconnectOpts := NewDefaultReconnectOpts()
+
+	for _, opt := range opts {
+		opt(reconnectOpts)
+	}
+
+	conn, err := dial(url, reconnectOpts.connectOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	connected := make(chan struct{})
+	close(connected)
+
+	return &reconnectingClient{
+		url:       url,
+		opts:      reconnectOpts,
+		conn:      conn,
+		state:     ConnectionStateConnected,
+		connected: connected,
+		closed:    make(chan struct{}),
+		subs:      make(map[*resumableSubscription]struct{}),
+		events:    make(chan ConnectionEvent, reconnectOpts.eventBufferSize),
+	}, nil
+}
+
+func dial(url string, opts *ConnectOpts) (*gethrpc.Client, error) {
+	return dialContext(context.Background(), url, opts)
+}
+
+// URL returns the URL the client connects to
+func (c *reconnectingClient) URL() string {
+	return c.url
+}
+
+func (c *reconnectingClient) ConnectionEvents() <-chan ConnectionEvent {
+	return c.events
+}
+
+func (c *reconnectingClient) ConnectionState() ConnectionState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.state
+}
+
+func (c *reconnectingClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *reconnectingClient) CallContext(
+	ctx context.Context,
+	result interface{},
+	method string,
+	args ...interface{},
+) error {
+	return c.do(ctx, func(conn *gethrpc.Client) error {
+		return conn.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (c *reconnectingClient) BatchCall(b []gethrpc.BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+func (c *reconnectingClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	return c.do(ctx, func(conn *gethrpc.Client) error {
+		return conn.BatchCallContext(ctx, b)
+	})
+}
+
+// do runs the request on the current connection, and starts reconnecting if it fails due to the connection.
+func (c *reconnectingClient) do(ctx context.Context, request func(conn *gethrpc.Client) error) error {
+	conn, generation, err := c.connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = request(conn)
+	if err != nil && isConnectionError(err) {
+		c.reconnect(generation, err)
+	}
+
+	return err
+}
+
+func (c *reconnectingClient) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	conn, generation, err := c.connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := newResumableSubscription(
+		c,
+		c.opts,
+		namespace,
+		subscribeMethodSuffix,
+		unsubscribeMethodSuffix,
+		notificationMethodSuffix,
+		channel,
+		args...,
+	)
+
+	if err := sub.subscribe(ctx, conn, c.url, generation); err != nil {
+		sub.feed.Close(nil)
+
+		if isConnectionError(err) {
+			c.reconnect(generation, err)
+		}
+
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == ConnectionStateClosed {
+		go sub.feed.Close(gethrpc.ErrClientQuit)
+
+		return sub.feed.Subscription(), nil
+	}
+
+	c.subs[sub] = struct{}{}
+
+	// The connection was lost while subscribing, the subscription is issued again once reconnected.
+	if c.generation != generation && c.state == ConnectionStateConnected {
+		go c.resubscribe(sub, c.conn, c.generation)
+	}
+
+	return sub.feed.Subscription(), nil
+}
+
+// Close closes the client, ending all of its subscriptions.
+func (c *reconnectingClient) Close() {
+	c.close(nil)
+}
+
+func (c *reconnectingClient) close(err error) {
+	c.mu.Lock()
+
+	if c.state == ConnectionStateClosed {
+		c.mu.Unlock()
+		return
+	}
+
+	c.state = ConnectionStateClosed
+	c.closeErr = err
+	close(c.closed)
+
+	conn := c.conn
+	subs := c.subscriptions()
+
+	c.mu.Unlock()
+
+	subErr := err
+	if subErr == nil {
+		subErr = gethrpc.ErrClientQuit
+	}
+
+	for _, sub := range subs {
+		sub.feed.Close(subErr)
+	}
+
+	conn.Close()
+
+	c.emit(ConnectionEvent{State: ConnectionStateClosed, Err: err})
+}
+
+// connection returns the current connection and its generation, waiting for the client to reconnect if needed.
+func (c *reconnectingClient) connection(ctx context.Context) (*gethrpc.Client, uint64, error) {
+	for {
+		c.mu.Lock()
+
+		if c.state == ConnectionStateClosed {
+			err := c.closeErr
+			c.mu.Unlock()
+
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return nil, 0, ErrClientClosed
+		}
+
+		if c.state == ConnectionStateConnected {
+			conn, generation := c.conn, c.generation
+			c.mu.Unlock()
+
+			return conn, generation, nil
+		}
+
+		connected := c.connected
+		c.mu.Unlock()
+
+		select {
+		case <-connected:
+		case <-c.closed:
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
+}
+
+// reconnect starts reconnecting if the connection of the provided generation is still the current one.
+func (c *reconnectingClient) reconnect(generation uint64, cause error) {
+	c.mu.Lock()
+
+	if c.state != ConnectionStateConnected || c.generation != generation {
+		c.mu.Unlock()
+		return
+	}
+
+	c.state = ConnectionStateDisconnected
+	c.connected = make(chan struct{})
+
+	conn := c.conn
+
+	c.mu.Unlock()
+
+	// Closing the lost connection ends the subscriptions that did not notice it yet.
+	conn.Close()
+
+	c.emit(ConnectionEvent{State: ConnectionStateDisconnected, Err: cause})
+
+	go c.reconnectLoop()
+}
+
+func (c *reconnectingClient) reconnectLoop() {
+	for attempt := 1; ; attempt++ {
+		if !c.setState(ConnectionStateReconnecting) {
+			return
+		}
+
+		c.emit(ConnectionEvent{State: ConnectionStateReconnecting, Attempt: attempt})
+
+		conn, err := dial(c.url, c.opts.connectOpts)
+		if err == nil {
+			c.reconnected(conn)
+			return
+		}
+
+		if c.opts.maxAttempts > 0 && attempt >= c.opts.maxAttempts {
+			c.close(fmt.Errorf("%w after %d attempts: %v", ErrReconnectFailed, attempt, err))
+			return
+		}
+
+		select {
+		case <-time.After(c.opts.backoff(attempt)):
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+func (c *reconnectingClient) reconnected(conn *gethrpc.Client) {
+	c.mu.Lock()
+
+	if c.state == ConnectionStateClosed {
+		c.mu.Unlock()
+		conn.Close()
+		return
+	}
+
+	c.conn = conn
+	c.generation++
+	c.state = ConnectionStateConnected
+	close(c.connected)
+
+	generation := c.generation
+	subs := c.subscriptions()
+
+	c.mu.Unlock()
+
+	c.emit(ConnectionEvent{State: ConnectionStateConnected})
+
+	for _, sub := range subs {
+		go c.resubscribe(sub, conn, generation)
+	}
+}
+
+// resubscribe issues the subscription again on the connection of the provided generation.
+func (c *reconnectingClient) resubscribe(sub *resumableSubscription, conn *gethrpc.Client, generation uint64) {
+	ctx, cancel := SubscribeContext(context.Background())
+	defer cancel()
+
+	err := sub.subscribe(ctx, conn, c.url, generation)
+
+	switch {
+	case err == nil:
+	case isConnectionError(err):
+		c.reconnect(generation, err)
+	default:
+		sub.feed.Close(err)
+	}
+}
+
+func (c *reconnectingClient) setState(state ConnectionState) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == ConnectionStateClosed {
+		return false
+	}
+
+	c.state = state
+
+	return true
+}
+
+// subscriptions returns the active subscriptions, the caller must hold the lock.
+func (c *reconnectingClient) subscriptions() []*resumableSubscription {
+	subs := make([]*resumableSubscription, 0, len(c.subs))
+
+	for sub := range c.subs {
+		subs = append(subs, sub)
+	}
+
+	return subs
+}
+
+func (c *reconnectingClient) subscriptionLost(_ *resumableSubscription, generation uint64, err error) {
+	c.reconnect(generation, err)
+}
+
+func (c *reconnectingClient) removeSubscription(sub *resumableSubscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.subs, sub)
+}
+
+func (c *reconnectingClient) emit(event ConnectionEvent) {
+	event.URL = c.url
+
+	select {
+	case c.events <- event:
+	default:
+	}
+}
+
+// isConnectionError returns true if the error is caused by the loss of the connection, rather than returned by
+// the node, by the transport or caused by the caller.
+func isConnectionError(err error) bool {
+	var (
+		netErr   net.Error
+		closeErr *websocket.CloseError
+		dialErr  dialError
+	)
+
+	switch {
+	case err == nil:
+		return false
+	case errors.As(err, &netErr), errors.As(err, &closeErr), errors.As(err, &dialErr):
+		return true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.Is(err, net.ErrClosed), errors.Is(err, websocket.ErrCloseSent), errors.Is(err, gethrpc.ErrClientQuit):
+		return true
+	default:
+		return false
+	}
+}
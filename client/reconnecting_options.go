@@ -0,0 +1,157 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	defaultMinBackoff      = 500 * time.Millisecond
+	defaultMaxBackoff      = 30 * time.Second
+	defaultBackoffFactor   = 2
+	defaultEventBufferSize = 64
+	defaultMaxGap          = 256
+	defaultGapFillTimeout  = 30 * time.Second
+)
+
+// ReconnectOpts holds the options of a reconnecting client.
+type ReconnectOpts struct {
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	backoffFactor float64
+	maxAttempts   int
+
+	eventBufferSize int
+
+	gapFillTimeout time.Duration
+	gapFillers     map[string]GapFiller
+	nonResumable   map[string]struct{}
+
+	connectOpts *ConnectOpts
+}
+
+// NewDefaultReconnectOpts returns the default options of a reconnecting client:
+//
+//   - reconnection attempts are unlimited and separated by an exponential backoff between 500ms and 30s.
+//   - the headers missed by the new, finalized and all heads subscriptions are retrieved, up to 256 of them.
+//   - extrinsic watch subscriptions are not resumed, since that would require submitting the extrinsic again.
+//   - chainHead follow subscriptions are not resumed, since their pinned blocks and operations are lost with the
+//     connection.
+//   - archive storage and storage diff subscriptions are not resumed, since that would report their items again.
+func NewDefaultReconnectOpts() *ReconnectOpts {
+	headersGapFiller := NewHeadersGapFiller(defaultMaxGap)
+
+	return &ReconnectOpts{
+		minBackoff:      defaultMinBackoff,
+		maxBackoff:      defaultMaxBackoff,
+		backoffFactor:   defaultBackoffFactor,
+		eventBufferSize: defaultEventBufferSize,
+		gapFillTimeout:  defaultGapFillTimeout,
+		gapFillers: map[string]GapFiller{
+			"chain_subscribeNewHead":        headersGapFiller,
+			"chain_subscribeNewHeads":       headersGapFiller,
+			"chain_subscribeFinalizedHeads": headersGapFiller,
+			"chain_subscribeFinalisedHeads": headersGapFiller,
+			"chain_subscribeAllHeads":       headersGapFiller,
+		},
+		nonResumable: map[string]struct{}{
+			"author_submitAndWatchExtrinsic": {},
+			"chainHead_v1_follow":            {},
+			"archive_v1_storage":             {},
+			"archive_v1_storageDiff":         {},
+		},
+		connectOpts: NewDefaultConnectOpts(),
+	}
+}
+
+// ReconnectOptsFn is function that sets an option of a reconnecting client.
+type ReconnectOptsFn func(opts *ReconnectOpts)
+
+// WithBackoff sets the exponential backoff applied between reconnection attempts. The delay starts at min and is
+// multiplied by factor after every failed attempt, up to max.
+func WithBackoff(min, max time.Duration, factor float64) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		opts.minBackoff = min
+		opts.maxBackoff = max
+		opts.backoffFactor = factor
+	}
+}
+
+// WithMaxReconnectAttempts sets the number of consecutive failed reconnection attempts after which the client
+// is closed. A value of 0 means that the attempts are unlimited.
+func WithMaxReconnectAttempts(maxAttempts int) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		opts.maxAttempts = maxAttempts
+	}
+}
+
+// WithEventBufferSize sets the size of the buffer of the connection events channel.
+func WithEventBufferSize(size int) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		opts.eventBufferSize = size
+	}
+}
+
+// WithGapFiller sets the GapFiller used for the subscriptions of the provided subscribe method,
+// e.g. chain_subscribeNewHeads. A nil GapFiller disables gap filling for the method.
+func WithGapFiller(subscribeMethod string, gapFiller GapFiller) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		if gapFiller == nil {
+			delete(opts.gapFillers, subscribeMethod)
+			return
+		}
+
+		opts.gapFillers[subscribeMethod] = gapFiller
+	}
+}
+
+// WithGapFillTimeout sets the timeout for retrieving the notifications missed by a subscription.
+func WithGapFillTimeout(timeout time.Duration) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		opts.gapFillTimeout = timeout
+	}
+}
+
+// WithNonResumable marks the subscriptions of the provided subscribe method as non-resumable. Such subscriptions
+// end with ErrSubscriptionNotResumable when the connection is lost, instead of being issued again.
+func WithNonResumable(subscribeMethod string) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		opts.nonResumable[subscribeMethod] = struct{}{}
+	}
+}
+
+// WithConnectOpts sets the options used for connecting, and reconnecting, to the node.
+func WithConnectOpts(connectOpts ...ConnectOptsFn) ReconnectOptsFn {
+	return func(opts *ReconnectOpts) {
+		for _, opt := range connectOpts {
+			opt(opts.connectOpts)
+		}
+	}
+}
+
+// backoff returns the delay before the next reconnection attempt.
+func (o *ReconnectOpts) backoff(attempt int) time.Duration {
+	delay := float64(o.minBackoff) * math.Pow(o.backoffFactor, float64(attempt-1))
+
+	if delay > float64(o.maxBackoff) {
+		return o.maxBackoff
+	}
+
+	return time.Duration(delay)
+}
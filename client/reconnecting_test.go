@@ -0,0 +1,538 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTimeout  = 5 * time.Second
+	testInterval = 10 * time.Millisecond
+
+	// testMinBackoff and testMaxBackoff keep the reconnection delays of the tests short.
+	testMinBackoff = 10 * time.Millisecond
+	testMaxBackoff = 50 * time.Millisecond
+)
+
+func TestReconnectingClient_Resubscribe(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Opts            []ReconnectOptsFn
+		ExpectedNumbers []types.BlockNumber
+		ExpectedMissed  int
+		ExpectedErr     error
+	}{
+		{
+			Name:            "gap filled",
+			ExpectedNumbers: []types.BlockNumber{3, 4, 5, 6},
+			ExpectedMissed:  3,
+		},
+		{
+			Name:            "gap too large",
+			Opts:            []ReconnectOptsFn{WithGapFiller("chain_subscribeNewHead", NewHeadersGapFiller(2))},
+			ExpectedNumbers: []types.BlockNumber{4, 5, 6},
+			ExpectedMissed:  2,
+			ExpectedErr:     ErrGapTooLarge,
+		},
+		{
+			Name:            "gap filling disabled",
+			Opts:            []ReconnectOptsFn{WithGapFiller("chain_subscribeNewHead", nil)},
+			ExpectedNumbers: []types.BlockNumber{6},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			srv, chainService := newTestServer(t)
+
+			opts := append([]ReconnectOptsFn{WithBackoff(testMinBackoff, testMaxBackoff, 2)}, testCase.Opts...)
+
+			cl, err := ConnectWithReconnect(srv.URL, opts...)
+			require.NoError(t, err)
+
+			defer cl.Close()
+
+			ch := make(chan types.Header)
+
+			sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+			require.NoError(t, err)
+
+			waitForSubscriptions(t, chainService, 1)
+
+			chainService.produce(2)
+
+			assertHeaders(t, ch, 1, 2)
+
+			require.NoError(t, srv.Close())
+
+			event := waitForEvent(t, cl, func(event ConnectionEvent) bool {
+				return event.State == ConnectionStateDisconnected
+			})
+			assert.Error(t, event.Err)
+
+			// Headers produced while the client is disconnected.
+			chainService.produce(3)
+
+			require.NoError(t, srv.Restart())
+
+			waitForEvent(t, cl, func(event ConnectionEvent) bool {
+				return event.State == ConnectionStateConnected
+			})
+
+			waitForSubscriptions(t, chainService, 1)
+
+			chainService.produce(1)
+
+			assertHeaders(t, ch, testCase.ExpectedNumbers...)
+
+			event = waitForEvent(t, cl, func(event ConnectionEvent) bool {
+				return event.Gap != nil
+			})
+			assert.Equal(t, "chain_subscribeNewHead", event.Gap.Method)
+			assert.Equal(t, testCase.ExpectedMissed, event.Gap.Missed)
+			assert.ErrorIs(t, event.Err, testCase.ExpectedErr)
+
+			var header types.Header
+
+			err = cl.Call(&header, "chain_getHeader", testHeaderHash(6).Hex())
+			assert.NoError(t, err)
+			assert.Equal(t, types.BlockNumber(6), header.Number)
+
+			sub.Unsubscribe()
+
+			waitForSubscriptions(t, chainService, 0)
+
+			cl.Close()
+
+			waitForEvent(t, cl, func(event ConnectionEvent) bool {
+				return event.State == ConnectionStateClosed
+			})
+
+			assert.Equal(t, ConnectionStateClosed, cl.ConnectionState())
+
+			err = cl.Call(&header, "chain_getHeader", testHeaderHash(6).Hex())
+			assert.ErrorIs(t, err, ErrClientClosed)
+		})
+	}
+}
+
+func TestReconnectingClient_ReconnectFailed(t *testing.T) {
+	srv, chainService := newTestServer(t)
+
+	cl, err := ConnectWithReconnect(
+		srv.URL,
+		WithBackoff(testMinBackoff, testMaxBackoff, 2),
+		WithMaxReconnectAttempts(3),
+	)
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	ch := make(chan types.Header)
+
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+
+	waitForSubscriptions(t, chainService, 1)
+
+	require.NoError(t, srv.Close())
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, ErrReconnectFailed)
+	case <-time.After(testTimeout):
+		t.Fatal("subscription did not end")
+	}
+
+	event := waitForEvent(t, cl, func(event ConnectionEvent) bool {
+		return event.State == ConnectionStateClosed
+	})
+	assert.ErrorIs(t, event.Err, ErrReconnectFailed)
+
+	var header types.Header
+
+	err = cl.Call(&header, "chain_getHeader", testHeaderHash(0).Hex())
+	assert.ErrorIs(t, err, ErrReconnectFailed)
+}
+
+func TestReconnectingClient_NonResumable(t *testing.T) {
+	srv, chainService := newTestServer(t)
+
+	cl, err := ConnectWithReconnect(
+		srv.URL,
+		WithBackoff(testMinBackoff, testMaxBackoff, 2),
+		WithNonResumable("chain_subscribeNewHead"),
+	)
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	ch := make(chan types.Header)
+
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+
+	waitForSubscriptions(t, chainService, 1)
+
+	require.NoError(t, srv.Close())
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, ErrSubscriptionNotResumable)
+	case <-time.After(testTimeout):
+		t.Fatal("subscription did not end")
+	}
+
+	require.NoError(t, srv.Restart())
+
+	waitForEvent(t, cl, func(event ConnectionEvent) bool {
+		return event.State == ConnectionStateConnected
+	})
+
+	// Give the client the time to issue subscriptions, if any.
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, 0, chainService.subscriptionCount())
+}
+
+func TestReconnectingClient_CallWaitsForReconnect(t *testing.T) {
+	srv, chainService := newTestServer(t)
+
+	cl, err := ConnectWithReconnect(srv.URL, WithBackoff(testMinBackoff, testMaxBackoff, 2))
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	ch := make(chan types.Header)
+
+	_, err = cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	require.NoError(t, err)
+
+	waitForSubscriptions(t, chainService, 1)
+
+	require.NoError(t, srv.Close())
+
+	waitForEvent(t, cl, func(event ConnectionEvent) bool {
+		return event.State == ConnectionStateDisconnected
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var header types.Header
+
+	err = cl.CallContext(ctx, &header, "chain_getHeader", testHeaderHash(0).Hex())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	restarted := make(chan struct{})
+
+	go func() {
+		defer close(restarted)
+
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, srv.Restart())
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	err = cl.CallContext(ctx, &header, "chain_getHeader", testHeaderHash(0).Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(0), header.Number)
+
+	<-restarted
+
+	err = cl.CallContext(ctx, &header, "chain_getHeader", "0x00")
+	assert.Error(t, err)
+	assert.Equal(t, ConnectionStateConnected, cl.ConnectionState())
+}
+
+func TestReconnectingClient_RequestErrors(t *testing.T) {
+	srv, _ := newTestServer(t)
+	httpSrv, _ := newTestHTTPServer(t)
+
+	unauthorizedSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+	}))
+	defer unauthorizedSrv.Close()
+
+	testCases := []struct {
+		Name    string
+		URL     string
+		Request func(cl ReconnectingClient) error
+	}{
+		{
+			Name: "marshal error",
+			URL:  srv.URL,
+			Request: func(cl ReconnectingClient) error {
+				var header types.Header
+
+				return cl.Call(&header, "chain_getHeader", make(chan int))
+			},
+		},
+		{
+			Name: "HTTP 4xx",
+			URL:  unauthorizedSrv.URL,
+			Request: func(cl ReconnectingClient) error {
+				var header types.Header
+
+				return cl.Call(&header, "chain_getHeader")
+			},
+		},
+		{
+			Name: "HTTP subscription",
+			URL:  httpSrv.URL,
+			Request: func(cl ReconnectingClient) error {
+				ch := make(chan types.Header)
+
+				_, err := cl.Subscribe(
+					context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch,
+				)
+
+				return err
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			cl, err := ConnectWithReconnect(testCase.URL, WithBackoff(testMinBackoff, testMaxBackoff, 2))
+			require.NoError(t, err)
+
+			defer cl.Close()
+
+			assert.Error(t, testCase.Request(cl))
+
+			// The connection is not closed by errors that are not caused by the connection.
+			assert.Equal(t, ConnectionStateConnected, cl.ConnectionState())
+
+			for len(cl.ConnectionEvents()) > 0 {
+				event := <-cl.ConnectionEvents()
+				assert.NotEqual(t, ConnectionStateDisconnected, event.State)
+			}
+		})
+	}
+}
+
+func TestReconnectOpts_Backoff(t *testing.T) {
+	opts := NewDefaultReconnectOpts()
+
+	WithBackoff(100*time.Millisecond, time.Second, 3)(opts)
+
+	assert.Equal(t, 100*time.Millisecond, opts.backoff(1))
+	assert.Equal(t, 300*time.Millisecond, opts.backoff(2))
+	assert.Equal(t, 900*time.Millisecond, opts.backoff(3))
+	assert.Equal(t, time.Second, opts.backoff(4))
+}
+
+func newTestServer(t *testing.T) (*rpcmocksrv.Server, *testChainService) {
+	srv := rpcmocksrv.New()
+
+	chainService := newTestChainService()
+
+	err := srv.RegisterName("chain", chainService)
+	require.NoError(t, err)
+
+	err = srv.RegisterSubscription("chain_subscribeNewHead", "chain_unsubscribeNewHead")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	return srv, chainService
+}
+
+func waitForEvent(t *testing.T, cl ReconnectingClient, match func(event ConnectionEvent) bool) ConnectionEvent {
+	timeout := time.After(testTimeout)
+
+	for {
+		select {
+		case event := <-cl.ConnectionEvents():
+			if match(event) {
+				return event
+			}
+		case <-timeout:
+			t.Fatal("expected connection event not received")
+		}
+	}
+}
+
+func waitForSubscriptions(t *testing.T, chainService *testChainService, count int) {
+	assert.Eventually(t, func() bool {
+		return chainService.subscriptionCount() == count
+	}, testTimeout, testInterval)
+}
+
+func assertHeaders(t *testing.T, ch chan types.Header, numbers ...types.BlockNumber) {
+	for _, number := range numbers {
+		select {
+		case header := <-ch:
+			assert.Equal(t, number, header.Number)
+		case <-time.After(testTimeout):
+			t.Fatalf("header #%d not received", number)
+		}
+	}
+}
+
+func testHeaderHash(number types.BlockNumber) types.Hash {
+	return types.Hash{byte(number), 1}
+}
+
+// testChainService is a chain RPC service that produces headers on demand.
+type testChainService struct {
+	mu        sync.Mutex
+	genesis   types.Hash
+	headers   map[types.Hash]types.Header
+	best      types.BlockNumber
+	finalized types.BlockNumber
+	notifiers map[gethrpc.ID]*gethrpc.Notifier
+}
+
+func newTestChainService() *testChainService {
+	return &testChainService{
+		genesis: testHeaderHash(0),
+		headers: map[types.Hash]types.Header{
+			testHeaderHash(0): {Number: 0},
+		},
+		notifiers: make(map[gethrpc.ID]*gethrpc.Notifier),
+	}
+}
+
+func (s *testChainService) GetHeader(hash *string) (*types.Header, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hash == nil {
+		header := s.headers[testHeaderHash(s.best)]
+
+		return &header, nil
+	}
+
+	h, err := types.NewHashFromHexString(*hash)
+	if err != nil {
+		return nil, err
+	}
+
+	header, ok := s.headers[h]
+	if !ok {
+		return nil, errors.New("header not found")
+	}
+
+	return &header, nil
+}
+
+func (s *testChainService) GetBlockHash(number *uint64) (*string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if number == nil {
+		hash := testHeaderHash(s.best).Hex()
+
+		return &hash, nil
+	}
+
+	if types.BlockNumber(*number) > s.best {
+		return nil, nil
+	}
+
+	if *number == 0 {
+		hash := s.genesis.Hex()
+
+		return &hash, nil
+	}
+
+	hash := testHeaderHash(types.BlockNumber(*number)).Hex()
+
+	return &hash, nil
+}
+
+func (s *testChainService) GetFinalizedHead() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return testHeaderHash(s.finalized).Hex()
+}
+
+func (s *testChainService) SubscribeNewHead(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, ok := gethrpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+
+	s.mu.Lock()
+	s.notifiers[sub.ID] = notifier
+	s.mu.Unlock()
+
+	go func() {
+		<-sub.Err()
+
+		s.mu.Lock()
+		delete(s.notifiers, sub.ID)
+		s.mu.Unlock()
+	}()
+
+	return sub, nil
+}
+
+func (s *testChainService) produce(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < count; i++ {
+		header := types.Header{
+			ParentHash: testHeaderHash(s.best),
+			Number:     s.best + 1,
+		}
+
+		s.best = header.Number
+		s.headers[testHeaderHash(header.Number)] = header
+
+		for id, notifier := range s.notifiers {
+			_ = notifier.Notify(id, header)
+		}
+	}
+}
+
+func (s *testChainService) finalize(number types.BlockNumber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.finalized = number
+}
+
+func (s *testChainService) subscriptionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.notifiers)
+}
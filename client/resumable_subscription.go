@@ -0,0 +1,267 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// subscriptionHost is a client that issues resumable subscriptions on its connections.
+type subscriptionHost interface {
+	Client
+
+	// subscriptionLost is called when the inner subscription of the provided generation ended due to the loss
+	// of its connection. The host is expected to issue the subscription again, unless it has ended.
+	subscriptionLost(sub *resumableSubscription, generation uint64, err error)
+
+	// removeSubscription is called once the subscription has ended.
+	removeSubscription(sub *resumableSubscription)
+
+	emit(event ConnectionEvent)
+}
+
+// resumableSubscription feeds a single ClientSubscription with the notifications of the subscriptions issued on
+// successive connections, e.g. after reconnecting or when moving to another node.
+type resumableSubscription struct {
+	host subscriptionHost
+	feed *gethrpc.SubscriptionFeed
+
+	method                   string
+	namespace                string
+	subscribeMethodSuffix    string
+	unsubscribeMethodSuffix  string
+	notificationMethodSuffix string
+	args                     []interface{}
+
+	gapFiller      GapFiller
+	gapFillTimeout time.Duration
+	nonResumable   bool
+
+	mu         sync.Mutex
+	inner      *gethrpc.ClientSubscription
+	url        string
+	generation uint64
+	last       json.RawMessage
+	ended      bool
+}
+
+func newResumableSubscription(
+	host subscriptionHost,
+	opts *ReconnectOpts,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) *resumableSubscription {
+	method := namespace + "_" + subscribeMethodSuffix
+
+	sub := &resumableSubscription{
+		host:                     host,
+		method:                   method,
+		namespace:                namespace,
+		subscribeMethodSuffix:    subscribeMethodSuffix,
+		unsubscribeMethodSuffix:  unsubscribeMethodSuffix,
+		notificationMethodSuffix: notificationMethodSuffix,
+		args:                     args,
+		gapFiller:                opts.gapFillers[method],
+		gapFillTimeout:           opts.gapFillTimeout,
+	}
+
+	_, sub.nonResumable = opts.nonResumable[method]
+
+	sub.feed = gethrpc.NewSubscriptionFeed(channel, sub.unsubscribe)
+
+	return sub
+}
+
+// subscribe issues the subscription on the connection of the provided generation, replacing the previous inner
+// subscription, if any.
+func (s *resumableSubscription) subscribe(
+	ctx context.Context,
+	conn *gethrpc.Client,
+	url string,
+	generation uint64,
+) error {
+	ch := make(chan json.RawMessage)
+
+	inner, err := conn.Subscribe(
+		ctx,
+		s.namespace,
+		s.subscribeMethodSuffix,
+		s.unsubscribeMethodSuffix,
+		s.notificationMethodSuffix,
+		ch,
+		s.args...,
+	)
+	if err != nil {
+		return subscribeError(s.namespace, s.subscribeMethodSuffix, err)
+	}
+
+	s.mu.Lock()
+
+	if s.ended {
+		s.mu.Unlock()
+		inner.Unsubscribe()
+		return nil
+	}
+
+	previous := s.inner
+
+	s.inner = inner
+	s.url = url
+	s.generation = generation
+
+	s.feed.SetID(inner.ID())
+
+	fillGap := s.last != nil
+
+	s.mu.Unlock()
+
+	if previous != nil {
+		go previous.Unsubscribe()
+	}
+
+	go s.forward(inner, ch, generation, fillGap)
+
+	return nil
+}
+
+// current returns the URL and the generation of the connection that the subscription is issued on.
+func (s *resumableSubscription) current() (string, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.url, s.generation
+}
+
+func (s *resumableSubscription) isEnded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ended
+}
+
+// forward delivers the notifications of the inner subscription until it ends.
+func (s *resumableSubscription) forward(
+	inner *gethrpc.ClientSubscription,
+	ch chan json.RawMessage,
+	generation uint64,
+	fillGap bool,
+) {
+	for {
+		select {
+		case result := <-ch:
+			if fillGap {
+				fillGap = false
+
+				if !s.fillGap(generation, result) {
+					return
+				}
+			}
+
+			if !s.deliver(generation, result) {
+				return
+			}
+		case err := <-inner.Err():
+			_, current := s.current()
+
+			switch {
+			case s.isEnded() || current != generation:
+				// The subscription ended or was issued again on another connection.
+			case err != nil && !isConnectionError(err):
+				s.feed.Close(err)
+			case s.nonResumable:
+				s.feed.Close(fmt.Errorf("%w: %s: connection lost", ErrSubscriptionNotResumable, s.method))
+				s.host.subscriptionLost(s, generation, err)
+			default:
+				s.host.subscriptionLost(s, generation, err)
+			}
+
+			return
+		case <-s.feed.Done():
+			return
+		}
+	}
+}
+
+// fillGap delivers the notifications that were missed before the provided one, which is the first notification
+// received after the subscription was issued again.
+func (s *resumableSubscription) fillGap(generation uint64, next json.RawMessage) bool {
+	gap := &SubscriptionGap{Method: s.method}
+
+	var err error
+
+	s.mu.Lock()
+	last, url := s.last, s.url
+	s.mu.Unlock()
+
+	if s.gapFiller != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), s.gapFillTimeout)
+		defer cancel()
+
+		var missed []json.RawMessage
+
+		missed, err = s.gapFiller(ctx, s.host, last, next)
+
+		for _, result := range missed {
+			if !s.deliver(generation, result) {
+				return false
+			}
+		}
+
+		gap.Missed = len(missed)
+	}
+
+	s.host.emit(ConnectionEvent{URL: url, State: ConnectionStateConnected, Err: err, Gap: gap})
+
+	return true
+}
+
+// deliver delivers the notification if it was received on the current inner subscription.
+func (s *resumableSubscription) deliver(generation uint64, result json.RawMessage) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended || s.generation != generation {
+		return false
+	}
+
+	s.last = result
+
+	return s.feed.Send(result)
+}
+
+// unsubscribe is called once the subscription has ended, either unsubscribed by the caller or due to an error.
+func (s *resumableSubscription) unsubscribe() error {
+	s.mu.Lock()
+	s.ended = true
+	inner := s.inner
+	s.mu.Unlock()
+
+	s.host.removeSubscription(s)
+
+	if inner != nil {
+		inner.Unsubscribe()
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// ErrSubscriptionClosed is returned by Next once the subscription was unsubscribed or its client was closed.
+var ErrSubscriptionClosed = errors.New("subscription closed")
+
+// Subscription is a subscription that receives notifications of type T.
+type Subscription[T any] struct {
+	sub     *gethrpc.ClientSubscription
+	in      chan T
+	channel chan T
+	errCh   chan error
+	policy  OverflowPolicy
+	dropped atomic.Uint64
+
+	// ended is closed when the subscription has ended, either because of an error or of Unsubscribe.
+	ended chan struct{}
+	quit  chan struct{}
+	done  chan struct{}
+
+	mu  sync.Mutex
+	err error
+
+	quitOnce sync.Once // ensures quit is closed once
+}
+
+// Subscribe subscribes to the notifications of a pubsub method, which are decoded as T. The args are the
+// parameters of the subscribe method.
+//
+// The subscription is set up with the provided context. If the context has no deadline, the default subscribe
+// timeout is applied.
+func Subscribe[T any](
+	ctx context.Context,
+	c Client,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix string,
+	args []interface{},
+	opts ...SubscriptionOptsFn,
+) (*Subscription[T], error) {
+	options := NewDefaultSubscriptionOpts()
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	ctx, cancel := SubscribeContext(ctx)
+	defer cancel()
+
+	in := make(chan T)
+
+	sub, err := c.Subscribe(
+		ctx, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix, in, args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bufferSize := options.bufferSize
+
+	if options.overflowPolicy != OverflowBlock && bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	s := &Subscription[T]{
+		sub:     sub,
+		in:      in,
+		channel: make(chan T, bufferSize),
+		errCh:   make(chan error, 1),
+		policy:  options.overflowPolicy,
+		ended:   make(chan struct{}),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when Unsubscribe is called on the subscription.
+func (s *Subscription[T]) Chan() <-chan T {
+	return s.channel
+}
+
+// Err returns the subscription error channel. The intended use of Err is to schedule
+// resubscription when the client connection is closed unexpectedly.
+//
+// The error channel receives a value when the subscription has ended due
+// to an error. The received error is nil if Close has been called
+// on the underlying client and no other error has occurred.
+//
+// The error channel is closed when Unsubscribe is called on the subscription.
+func (s *Subscription[T]) Err() <-chan error {
+	return s.errCh
+}
+
+// Next waits for the next notification. Once the subscription has ended, the buffered notifications are still
+// returned, followed by the error that ended the subscription, or ErrSubscriptionClosed if it was unsubscribed or
+// its client was closed.
+func (s *Subscription[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+
+	select {
+	case v, ok := <-s.channel:
+		if ok {
+			return v, nil
+		}
+	case <-s.ended:
+		select {
+		case v, ok := <-s.channel:
+			if ok {
+				return v, nil
+			}
+		default:
+		}
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err == nil {
+		return zero, ErrSubscriptionClosed
+	}
+
+	return zero, s.err
+}
+
+// Dropped returns the number of notifications dropped by the OverflowDropOldest policy.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe unsubscribes the notification and closes the error channel.
+// It can safely be called more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	s.quitOnce.Do(func() {
+		s.sub.Unsubscribe()
+		close(s.quit)
+	})
+
+	<-s.done
+}
+
+func (s *Subscription[T]) run() {
+	defer close(s.done)
+
+	subErr := s.sub.Err()
+
+	running := true
+
+	for running {
+		running = s.receive(subErr)
+	}
+
+	select {
+	case <-s.ended:
+	default:
+		close(s.ended)
+	}
+
+	<-s.quit
+
+	close(s.channel)
+	close(s.errCh)
+}
+
+// receive handles the next notification or error of the underlying subscription. It returns false once the
+// subscription has ended.
+func (s *Subscription[T]) receive(subErr <-chan error) bool {
+	select {
+	case v := <-s.in:
+		if err := s.deliver(v); err != nil {
+			s.sub.Unsubscribe()
+			s.end(err)
+
+			return false
+		}
+
+		return true
+	case err, ok := <-subErr:
+		if ok {
+			s.end(err)
+		}
+
+		return false
+	case <-s.quit:
+		return false
+	}
+}
+
+// deliver sends the notification to the subscription channel, applying the overflow policy if it is full.
+func (s *Subscription[T]) deliver(v T) error {
+	if s.policy == OverflowBlock {
+		select {
+		case s.channel <- v:
+		case <-s.quit:
+		}
+
+		return nil
+	}
+
+	select {
+	case s.channel <- v:
+		return nil
+	default:
+	}
+
+	if s.policy == OverflowError {
+		return gethrpc.ErrSubscriptionQueueOverflow
+	}
+
+	// The subscription is the only sender, so there is room for the notification once the oldest one is dropped,
+	// unless a receiver took it in the meantime.
+	select {
+	case <-s.channel:
+		s.dropped.Add(1)
+	default:
+	}
+
+	select {
+	case s.channel <- v:
+	default:
+		s.dropped.Add(1)
+	}
+
+	return nil
+}
+
+// end records the error that ended the subscription and reports it on the error channel.
+func (s *Subscription[T]) end(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+
+	s.errCh <- err
+
+	close(s.ended)
+}
@@ -0,0 +1,62 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+// OverflowPolicy defines how a subscription handles the notifications that arrive while its buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the buffer to have room. The notifications are then queued by the connection, which
+	// ends the subscription with gethrpc.ErrSubscriptionQueueOverflow once 20000 of them are queued.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest notification of the buffer to make room for the new one.
+	OverflowDropOldest
+	// OverflowError ends the subscription with gethrpc.ErrSubscriptionQueueOverflow.
+	OverflowError
+)
+
+// SubscriptionOpts holds the options of a subscription.
+type SubscriptionOpts struct {
+	bufferSize     int
+	overflowPolicy OverflowPolicy
+}
+
+// NewDefaultSubscriptionOpts returns the default options of a subscription, which has no buffer and blocks when
+// the notifications are not received.
+func NewDefaultSubscriptionOpts() *SubscriptionOpts {
+	return &SubscriptionOpts{
+		overflowPolicy: OverflowBlock,
+	}
+}
+
+// SubscriptionOptsFn is function that sets an option of a subscription.
+type SubscriptionOptsFn func(opts *SubscriptionOpts)
+
+// WithBufferSize sets the number of notifications buffered by the subscription channel.
+func WithBufferSize(size int) SubscriptionOptsFn {
+	return func(opts *SubscriptionOpts) {
+		opts.bufferSize = size
+	}
+}
+
+// WithOverflowPolicy sets how the subscription handles the notifications that arrive while its buffer is full.
+// The OverflowDropOldest and OverflowError policies use a buffer of at least one notification.
+func WithOverflowPolicy(policy OverflowPolicy) SubscriptionOptsFn {
+	return func(opts *SubscriptionOpts) {
+		opts.overflowPolicy = policy
+	}
+}
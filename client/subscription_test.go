@@ -0,0 +1,170 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func nextHeaderNumber(t *testing.T, sub *Subscription[types.Header]) types.BlockNumber {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	header, err := sub.Next(ctx)
+	require.NoError(t, err)
+
+	return header.Number
+}
+
+func TestSubscription_Next(t *testing.T) {
+	srv, chainService := newTestServer(t)
+
+	cl, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	sub, err := Subscribe[types.Header](
+		context.Background(), cl, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", nil,
+	)
+	require.NoError(t, err)
+
+	waitForSubscriptions(t, chainService, 1)
+
+	go chainService.produce(2)
+
+	assert.Equal(t, types.BlockNumber(1), nextHeaderNumber(t, sub))
+	assert.Equal(t, types.BlockNumber(2), nextHeaderNumber(t, sub))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = sub.Next(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	waitForSubscriptions(t, chainService, 0)
+
+	_, err = sub.Next(context.Background())
+	assert.ErrorIs(t, err, ErrSubscriptionClosed)
+
+	_, ok := <-sub.Chan()
+	assert.False(t, ok)
+
+	_, ok = <-sub.Err()
+	assert.False(t, ok)
+}
+
+func TestSubscription_Buffer(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Opts            []SubscriptionOptsFn
+		Produced        int
+		ExpectedNumbers []types.BlockNumber
+		ExpectedDropped uint64
+	}{
+		{
+			Name:            "buffered",
+			Opts:            []SubscriptionOptsFn{WithBufferSize(3)},
+			Produced:        3,
+			ExpectedNumbers: []types.BlockNumber{1, 2, 3},
+		},
+		{
+			Name:            "drop oldest",
+			Opts:            []SubscriptionOptsFn{WithBufferSize(2), WithOverflowPolicy(OverflowDropOldest)},
+			Produced:        5,
+			ExpectedNumbers: []types.BlockNumber{4, 5},
+			ExpectedDropped: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			srv, chainService := newTestServer(t)
+
+			cl, err := Connect(srv.URL)
+			require.NoError(t, err)
+
+			defer cl.Close()
+
+			sub, err := Subscribe[types.Header](
+				context.Background(), cl, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", nil,
+				testCase.Opts...,
+			)
+			require.NoError(t, err)
+
+			defer sub.Unsubscribe()
+
+			waitForSubscriptions(t, chainService, 1)
+
+			chainService.produce(testCase.Produced)
+
+			assert.Eventually(t, func() bool {
+				return len(sub.Chan()) == len(testCase.ExpectedNumbers) && sub.Dropped() == testCase.ExpectedDropped
+			}, testTimeout, testInterval)
+
+			for _, number := range testCase.ExpectedNumbers {
+				assert.Equal(t, number, nextHeaderNumber(t, sub))
+			}
+		})
+	}
+}
+
+func TestSubscription_OverflowError(t *testing.T) {
+	srv, chainService := newTestServer(t)
+
+	cl, err := Connect(srv.URL)
+	require.NoError(t, err)
+
+	defer cl.Close()
+
+	sub, err := Subscribe[types.Header](
+		context.Background(), cl, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", nil, WithBufferSize(1), WithOverflowPolicy(OverflowError),
+	)
+	require.NoError(t, err)
+
+	waitForSubscriptions(t, chainService, 1)
+
+	defer sub.Unsubscribe()
+
+	chainService.produce(2)
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, gethrpc.ErrSubscriptionQueueOverflow)
+	case <-time.After(testTimeout):
+		t.Fatal("overflow error not received")
+	}
+
+	waitForSubscriptions(t, chainService, 0)
+
+	// The buffered notification is still returned before the error.
+	assert.Equal(t, types.BlockNumber(1), nextHeaderNumber(t, sub))
+
+	_, err = sub.Next(context.Background())
+	assert.ErrorIs(t, err, gethrpc.ErrSubscriptionQueueOverflow)
+}
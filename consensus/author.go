@@ -0,0 +1,225 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// SlotClaim is the claim of a slot by a block author, found in the pre-digest of the header.
+type SlotClaim struct {
+	Engine types.ConsensusEngineID
+	Slot   types.U64
+	// BabePreDigest is set for the BABE claims, which hold the index of the author in the epoch authorities.
+	BabePreDigest *types.BabePreDigest
+}
+
+// ClaimOf returns the slot claim found in the BABE or Aura pre-digest of the digest.
+func ClaimOf(digest types.Digest) (SlotClaim, error) {
+	preDigest, ok, err := types.FindBabePreDigest(digest)
+	if err != nil {
+		return SlotClaim{}, ErrDigestDecoding.Wrap(err)
+	}
+
+	if ok {
+		return SlotClaim{Engine: types.BabeEngineID, Slot: preDigest.Slot(), BabePreDigest: &preDigest}, nil
+	}
+
+	slot, ok, err := types.FindAuraSlot(digest)
+	if err != nil {
+		return SlotClaim{}, ErrDigestDecoding.Wrap(err)
+	}
+
+	if ok {
+		return SlotClaim{Engine: types.AuraEngineID, Slot: slot}, nil
+	}
+
+	return SlotClaim{}, ErrMissingPreDigest
+}
+
+// BlockAuthor is the author of a block, with the slot it was authored in.
+type BlockAuthor struct {
+	Engine         types.ConsensusEngineID
+	Slot           types.U64
+	AuthorityIndex uint32
+	AuthorityID    types.AuthorityID
+	// Account is the validator account of the author, nil if the chain has no Session pallet.
+	Account *types.AccountID
+}
+
+// AuthorResolver is the interface used for resolving and verifying the authors of the blocks.
+type AuthorResolver interface {
+	// Author returns the author of the block, after verifying that it sealed the header.
+	Author(ctx context.Context, header types.Header) (*BlockAuthor, error)
+}
+
+// authorResolver implements the AuthorResolver interface.
+type authorResolver struct {
+	stateRPC state.State
+}
+
+// NewAuthorResolver creates a new AuthorResolver.
+func NewAuthorResolver(stateRPC state.State) AuthorResolver {
+	return &authorResolver{stateRPC: stateRPC}
+}
+
+const (
+	babeModule    = "Babe"
+	auraModule    = "Aura"
+	sessionModule = "Session"
+
+	authoritiesMethod = "Authorities"
+	validatorsMethod  = "Validators"
+)
+
+// Author resolves the authority index of the slot claim to the authority and the validator account of the author.
+//
+// The Aura authorities are read at the parent block, since an authority set change only applies to the following
+// blocks. The BABE authorities are read at the block itself, since the first block of an epoch enacts the epoch
+// change, and the session change along with it, in its initialization.
+func (r *authorResolver) Author(ctx context.Context, header types.Header) (*BlockAuthor, error) {
+	claim, err := ClaimOf(header.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	_, seal, err := SplitSeal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if seal.ConsensusEngineID != claim.Engine {
+		return nil, ErrInvalidSeal.WithMsg("seal engine %#x, pre-digest engine %#x", seal.ConsensusEngineID, claim.Engine)
+	}
+
+	stateHash := header.ParentHash
+
+	if claim.Engine == types.BabeEngineID {
+		if stateHash, err = header.Hash(); err != nil {
+			return nil, ErrHeaderHashing.Wrap(err)
+		}
+	}
+
+	meta, err := r.stateRPC.GetMetadata(ctx, stateHash)
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	var authorities []types.AuthorityID
+
+	switch claim.Engine {
+	case types.BabeEngineID:
+		var babeAuthorities []types.BabeAuthority
+
+		if err := r.getStorage(ctx, meta, babeModule, authoritiesMethod, &babeAuthorities, stateHash); err != nil {
+			return nil, err
+		}
+
+		for _, authority := range babeAuthorities {
+			authorities = append(authorities, authority.ID)
+		}
+	default:
+		if err := r.getStorage(ctx, meta, auraModule, authoritiesMethod, &authorities, stateHash); err != nil {
+			return nil, err
+		}
+	}
+
+	index, err := authorityIndex(claim, len(authorities))
+	if err != nil {
+		return nil, err
+	}
+
+	author := &BlockAuthor{
+		Engine:         claim.Engine,
+		Slot:           claim.Slot,
+		AuthorityIndex: index,
+		AuthorityID:    authorities[index],
+	}
+
+	if err := VerifySeal(header, author.AuthorityID); err != nil {
+		return nil, err
+	}
+
+	if !meta.ExistsModuleMetadata(sessionModule) {
+		return author, nil
+	}
+
+	var validators []types.AccountID
+
+	if err := r.getStorage(ctx, meta, sessionModule, validatorsMethod, &validators, stateHash); err != nil {
+		return nil, err
+	}
+
+	// The authorities are set from the session keys of the validators, in the same order.
+	if int(index) < len(validators) {
+		author.Account = &validators[index]
+	}
+
+	return author, nil
+}
+
+// authorityIndex returns the index of the author in the authorities. The BABE authors claim their index, while the
+// Aura slots are assigned round-robin.
+func authorityIndex(claim SlotClaim, authorities int) (uint32, error) {
+	if authorities == 0 {
+		return 0, ErrUnknownAuthority.WithMsg("no authorities")
+	}
+
+	if claim.BabePreDigest == nil {
+		return uint32(uint64(claim.Slot) % uint64(authorities)), nil
+	}
+
+	index := uint32(claim.BabePreDigest.AuthorityIndex())
+	if int(index) >= authorities {
+		return 0, ErrUnknownAuthority.WithMsg("authority index %d out of %d authorities", index, authorities)
+	}
+
+	return index, nil
+}
+
+// getStorage decodes the storage value of the module at the block into target.
+func (r *authorResolver) getStorage(
+	ctx context.Context,
+	meta *types.Metadata,
+	module, method string,
+	target interface{},
+	blockHash types.Hash,
+) error {
+	key, err := types.CreateStorageKey(meta, module, method)
+	if err != nil {
+		return ErrStorageKeyCreation.Wrap(err)
+	}
+
+	raw, err := r.stateRPC.GetStorageRaw(ctx, key, blockHash)
+	if err != nil {
+		return ErrStorageRetrieval.Wrap(err)
+	}
+
+	if raw == nil || len(*raw) == 0 {
+		return ErrStorageRetrieval.WithMsg("%s.%s not found at block %s", module, method, blockHash.Hex())
+	}
+
+	if err := codec.Decode(*raw, target); err != nil {
+		return ErrStorageDecoding.Wrap(err)
+	}
+
+	return nil
+}
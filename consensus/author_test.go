@@ -0,0 +1,249 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	subkey "github.com/vedhavyas/go-subkey/v2"
+)
+
+func decodeTestMetadata(t *testing.T, metaHex string) *types.Metadata {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(metaHex, &meta))
+
+	return &meta
+}
+
+// mockStorage sets the storage value of the module at the block in the state mock.
+func mockStorage(
+	t *testing.T,
+	stateRPCMock *mocks.State,
+	meta *types.Metadata,
+	module, method string,
+	value interface{},
+	blockHash types.Hash,
+) *mock.Call {
+	key, err := types.CreateStorageKey(meta, module, method)
+	require.NoError(t, err)
+
+	enc, err := codec.Encode(value)
+	require.NoError(t, err)
+
+	raw := types.StorageDataRaw(enc)
+
+	return stateRPCMock.On("GetStorageRaw", mock.Anything, key, blockHash).Return(&raw, nil)
+}
+
+func TestClaimOf(t *testing.T) {
+	babe := types.BabePreDigest{
+		IsSecondaryPlain: true,
+		AsSecondaryPlain: types.BabeSecondaryPlainPreDigest{AuthorityIndex: 1, Slot: 7},
+	}
+
+	enc, err := codec.Encode(babe)
+	require.NoError(t, err)
+
+	claim, err := ClaimOf(types.Digest{
+		{IsPreRuntime: true, AsPreRuntime: types.PreRuntime{ConsensusEngineID: types.BabeEngineID, Bytes: enc}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, SlotClaim{Engine: types.BabeEngineID, Slot: 7, BabePreDigest: &babe}, claim)
+
+	claim, err = ClaimOf(types.Digest{
+		{IsPreRuntime: true, AsPreRuntime: types.PreRuntime{ConsensusEngineID: types.AuraEngineID, Bytes: enc[5:]}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, SlotClaim{Engine: types.AuraEngineID, Slot: 7}, claim)
+
+	_, err = ClaimOf(types.Digest{})
+	assert.ErrorIs(t, err, ErrMissingPreDigest)
+
+	_, err = ClaimOf(types.Digest{
+		{IsPreRuntime: true, AsPreRuntime: types.PreRuntime{ConsensusEngineID: types.BabeEngineID, Bytes: []byte{9}}},
+	})
+	assert.ErrorIs(t, err, ErrDigestDecoding)
+}
+
+func TestAuthorResolver_Aura(t *testing.T) {
+	stateRPCMock := mocks.NewState(t)
+	resolver := NewAuthorResolver(stateRPCMock)
+
+	meta := decodeTestMetadata(t, types.MetadataV14Data)
+
+	authorities := []subkey.KeyPair{newTestAuthority(t, 1), newTestAuthority(t, 2), newTestAuthority(t, 3)}
+	validators := []types.AccountID{{0x0a}, {0x0b}, {0x0c}}
+
+	var ids []types.AuthorityID
+
+	for _, kp := range authorities {
+		ids = append(ids, authorityIDOf(kp))
+	}
+
+	// Slot 7 is assigned to the authority 7 % 3 = 1.
+	header := newTestHeader(t, types.AuraEngineID, types.U64(7), authorities[1])
+
+	stateRPCMock.On("GetMetadata", mock.Anything, header.ParentHash).Return(meta, nil)
+	mockStorage(t, stateRPCMock, meta, auraModule, authoritiesMethod, ids, header.ParentHash)
+	mockStorage(t, stateRPCMock, meta, sessionModule, validatorsMethod, validators, header.ParentHash)
+
+	author, err := resolver.Author(context.Background(), header)
+	assert.NoError(t, err)
+	assert.Equal(t, &BlockAuthor{
+		Engine:         types.AuraEngineID,
+		Slot:           7,
+		AuthorityIndex: 1,
+		AuthorityID:    ids[1],
+		Account:        &validators[1],
+	}, author)
+
+	// The block is sealed by an authority that does not own the slot.
+	header = newTestHeader(t, types.AuraEngineID, types.U64(8), authorities[1])
+
+	_, err = resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrInvalidSeal)
+}
+
+func TestAuthorResolver_Babe(t *testing.T) {
+	stateRPCMock := mocks.NewState(t)
+	resolver := NewAuthorResolver(stateRPCMock)
+
+	meta := decodeTestMetadata(t, test.PolkadotMetadataHex)
+
+	previous := []subkey.KeyPair{newTestAuthority(t, 1), newTestAuthority(t, 2)}
+	current := []subkey.KeyPair{newTestAuthority(t, 3), newTestAuthority(t, 4)}
+	previousValidators := []types.AccountID{{0x0a}, {0x0b}}
+	validators := []types.AccountID{{0x0c}, {0x0d}}
+
+	var previousAuthorities, babeAuthorities []types.BabeAuthority
+
+	for i := range current {
+		previousAuthorities = append(previousAuthorities, types.BabeAuthority{ID: authorityIDOf(previous[i]), Weight: 1})
+		babeAuthorities = append(babeAuthorities, types.BabeAuthority{ID: authorityIDOf(current[i]), Weight: 1})
+	}
+
+	preDigest := types.BabePreDigest{
+		IsPrimary: true,
+		AsPrimary: types.BabePrimaryPreDigest{AuthorityIndex: 1, Slot: 11},
+	}
+
+	// The block is the first of an epoch, authored by an authority of the epoch. The parent block still holds the
+	// authorities and validators of the previous epoch, which are replaced in the initialization of the block.
+	header := newTestHeader(t, types.BabeEngineID, preDigest, current[1])
+
+	blockHash, err := header.Hash()
+	require.NoError(t, err)
+
+	stateRPCMock.On("GetMetadata", mock.Anything, header.ParentHash).Return(meta, nil).Maybe()
+	stateRPCMock.On("GetMetadata", mock.Anything, blockHash).Return(meta, nil)
+	mockStorage(t, stateRPCMock, meta, babeModule, authoritiesMethod, previousAuthorities, header.ParentHash).Maybe()
+	mockStorage(t, stateRPCMock, meta, sessionModule, validatorsMethod, previousValidators, header.ParentHash).Maybe()
+	mockStorage(t, stateRPCMock, meta, babeModule, authoritiesMethod, babeAuthorities, blockHash)
+	mockStorage(t, stateRPCMock, meta, sessionModule, validatorsMethod, validators, blockHash)
+
+	author, err := resolver.Author(context.Background(), header)
+	assert.NoError(t, err)
+	assert.Equal(t, &BlockAuthor{
+		Engine:         types.BabeEngineID,
+		Slot:           11,
+		AuthorityIndex: 1,
+		AuthorityID:    babeAuthorities[1].ID,
+		Account:        &validators[1],
+	}, author)
+
+	// The claimed authority index is out of the authorities.
+	preDigest.AsPrimary.AuthorityIndex = 2
+
+	header = newTestHeader(t, types.BabeEngineID, preDigest, current[0])
+
+	blockHash, err = header.Hash()
+	require.NoError(t, err)
+
+	stateRPCMock.On("GetMetadata", mock.Anything, blockHash).Return(meta, nil)
+	mockStorage(t, stateRPCMock, meta, babeModule, authoritiesMethod, babeAuthorities, blockHash)
+
+	_, err = resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrUnknownAuthority)
+}
+
+func TestAuthorResolver_Errors(t *testing.T) {
+	stateRPCMock := mocks.NewState(t)
+	resolver := NewAuthorResolver(stateRPCMock)
+
+	kp := newTestAuthority(t, 1)
+
+	// The seal engine must match the pre-digest engine.
+	header := newTestHeader(t, types.AuraEngineID, types.U64(1), kp)
+	header.Digest[1].AsSeal.ConsensusEngineID = types.BabeEngineID
+
+	_, err := resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrInvalidSeal)
+
+	header = newTestHeader(t, types.AuraEngineID, types.U64(1), kp)
+
+	stateRPCMock.On("GetMetadata", mock.Anything, header.ParentHash).Return(nil, errors.New("error")).Once()
+
+	_, err = resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrMetadataRetrieval)
+
+	// The test metadata has no Babe pallet.
+	meta := decodeTestMetadata(t, types.MetadataV14Data)
+
+	stateRPCMock.On("GetMetadata", mock.Anything, header.ParentHash).Return(meta, nil)
+
+	babeHeader := newTestHeader(t, types.BabeEngineID, types.BabePreDigest{IsSecondaryPlain: true}, kp)
+
+	babeHash, err := babeHeader.Hash()
+	require.NoError(t, err)
+
+	stateRPCMock.On("GetMetadata", mock.Anything, babeHash).Return(meta, nil)
+
+	_, err = resolver.Author(context.Background(), babeHeader)
+	assert.ErrorIs(t, err, ErrStorageKeyCreation)
+
+	key, err := types.CreateStorageKey(meta, auraModule, authoritiesMethod)
+	require.NoError(t, err)
+
+	stateRPCMock.On("GetStorageRaw", mock.Anything, key, header.ParentHash).
+		Return(&types.StorageDataRaw{}, nil).
+		Once()
+
+	_, err = resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+
+	stateRPCMock.On("GetStorageRaw", mock.Anything, key, header.ParentHash).
+		Return(&types.StorageDataRaw{0x04, 0x01}, nil).
+		Once()
+
+	_, err = resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrStorageDecoding)
+
+	mockStorage(t, stateRPCMock, meta, auraModule, authoritiesMethod, []types.AuthorityID{}, header.ParentHash)
+
+	_, err = resolver.Author(context.Background(), header)
+	assert.ErrorIs(t, err, ErrUnknownAuthority)
+}
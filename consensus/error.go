@@ -0,0 +1,32 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrMissingPreDigest   = libErr.Error("missing BABE or Aura pre-digest")
+	ErrMissingSeal        = libErr.Error("missing seal")
+	ErrInvalidSeal        = libErr.Error("invalid seal")
+	ErrUnknownAuthority   = libErr.Error("unknown authority")
+	ErrDigestDecoding     = libErr.Error("digest decoding")
+	ErrHeaderHashing      = libErr.Error("header hashing")
+	ErrMetadataRetrieval  = libErr.Error("metadata retrieval")
+	ErrStorageKeyCreation = libErr.Error("storage key creation")
+	ErrStorageRetrieval   = libErr.Error("storage retrieval")
+	ErrStorageDecoding    = libErr.Error("storage decoding")
+)
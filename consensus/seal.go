@@ -0,0 +1,84 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consensus decodes the slot claims that the BABE and Aura block authors put in the header digests, and
+// resolves and verifies the authors of the blocks.
+package consensus
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+// sealLength is the length of the sr25519 signature held by the seal.
+const sealLength = 64
+
+// SplitSeal returns the header without its seal, which is the last item of the digest, and the seal.
+func SplitSeal(header types.Header) (types.Header, types.Seal, error) {
+	n := len(header.Digest)
+	if n == 0 || !header.Digest[n-1].IsSeal {
+		return types.Header{}, types.Seal{}, ErrMissingSeal
+	}
+
+	seal := header.Digest[n-1].AsSeal
+
+	header.Digest = append(types.Digest{}, header.Digest[:n-1]...)
+
+	return header, seal, nil
+}
+
+// PreHash returns the hash of the header without its seal, which is the message signed by the block author.
+func PreHash(header types.Header) (types.Hash, error) {
+	preHeader, _, err := SplitSeal(header)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	hash, err := preHeader.Hash()
+	if err != nil {
+		return types.Hash{}, ErrHeaderHashing.Wrap(err)
+	}
+
+	return hash, nil
+}
+
+// VerifySeal verifies that the seal of the header is the sr25519 signature of its pre-hash by the authority.
+func VerifySeal(header types.Header, authority types.AuthorityID) error {
+	preHeader, seal, err := SplitSeal(header)
+	if err != nil {
+		return err
+	}
+
+	preHash, err := preHeader.Hash()
+	if err != nil {
+		return ErrHeaderHashing.Wrap(err)
+	}
+
+	if len(seal.Bytes) != sealLength {
+		return ErrInvalidSeal.WithMsg("seal length %d", len(seal.Bytes))
+	}
+
+	pub, err := sr25519.Scheme{}.FromPublicKey(authority[:])
+	if err != nil {
+		return ErrInvalidSeal.Wrap(err)
+	}
+
+	if !pub.Verify(preHash[:], seal.Bytes) {
+		return ErrInvalidSeal.WithMsg("signature of block %s by %#x", preHash.Hex(), authority)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	subkey "github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func newTestAuthority(t *testing.T, seed byte) subkey.KeyPair {
+	kp, err := sr25519.Scheme{}.FromSeed(append([]byte{seed}, make([]byte, 31)...))
+	require.NoError(t, err)
+
+	return kp
+}
+
+func authorityIDOf(kp subkey.KeyPair) types.AuthorityID {
+	var id types.AuthorityID
+
+	copy(id[:], kp.Public())
+
+	return id
+}
+
+// newTestHeader returns a header with the pre-digest of the engine, sealed by the authority.
+func newTestHeader(
+	t *testing.T,
+	engine types.ConsensusEngineID,
+	preDigest interface{},
+	kp subkey.KeyPair,
+) types.Header {
+	enc, err := codec.Encode(preDigest)
+	require.NoError(t, err)
+
+	header := types.Header{
+		ParentHash: types.NewHash([]byte{0x01}),
+		Number:     10,
+		Digest: types.Digest{
+			{IsPreRuntime: true, AsPreRuntime: types.PreRuntime{ConsensusEngineID: engine, Bytes: enc}},
+		},
+	}
+
+	preHash, err := header.Hash()
+	require.NoError(t, err)
+
+	sig, err := kp.Sign(preHash[:])
+	require.NoError(t, err)
+
+	header.Digest = append(header.Digest, types.DigestItem{
+		IsSeal: true,
+		AsSeal: types.Seal{ConsensusEngineID: engine, Bytes: sig},
+	})
+
+	return header
+}
+
+func TestSplitSeal(t *testing.T) {
+	kp := newTestAuthority(t, 1)
+	header := newTestHeader(t, types.AuraEngineID, types.U64(5), kp)
+
+	preHeader, seal, err := SplitSeal(header)
+	assert.NoError(t, err)
+	assert.Len(t, preHeader.Digest, 1)
+	assert.Len(t, header.Digest, 2)
+	assert.Equal(t, types.AuraEngineID, seal.ConsensusEngineID)
+
+	preHash, err := PreHash(header)
+	assert.NoError(t, err)
+
+	expected, err := preHeader.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, preHash)
+
+	_, _, err = SplitSeal(preHeader)
+	assert.ErrorIs(t, err, ErrMissingSeal)
+
+	_, err = PreHash(preHeader)
+	assert.ErrorIs(t, err, ErrMissingSeal)
+}
+
+func TestVerifySeal(t *testing.T) {
+	kp := newTestAuthority(t, 1)
+	header := newTestHeader(t, types.AuraEngineID, types.U64(5), kp)
+
+	assert.NoError(t, VerifySeal(header, authorityIDOf(kp)))
+
+	err := VerifySeal(header, authorityIDOf(newTestAuthority(t, 2)))
+	assert.ErrorIs(t, err, ErrInvalidSeal)
+
+	tampered := header
+	tampered.Number++
+
+	err = VerifySeal(tampered, authorityIDOf(kp))
+	assert.ErrorIs(t, err, ErrInvalidSeal)
+
+	truncated := header
+	truncated.Digest = types.Digest{header.Digest[0], header.Digest[1]}
+	truncated.Digest[1].AsSeal.Bytes = truncated.Digest[1].AsSeal.Bytes[:63]
+
+	err = VerifySeal(truncated, authorityIDOf(kp))
+	assert.ErrorIs(t, err, ErrInvalidSeal)
+
+	err = VerifySeal(types.Header{}, authorityIDOf(kp))
+	assert.ErrorIs(t, err, ErrMissingSeal)
+}
@@ -0,0 +1,225 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// BeefyValidatorSet is a set of BEEFY validators, in the order of the signatures of the signed commitments.
+type BeefyValidatorSet struct {
+	ID         uint64
+	Validators []types.BeefyAuthorityID
+}
+
+// Threshold returns the number of signatures needed to finalize a commitment, which is more than two thirds of the
+// validators.
+func (s BeefyValidatorSet) Threshold() int {
+	if len(s.Validators) == 0 {
+		return 0
+	}
+
+	return len(s.Validators) - (len(s.Validators)-1)/3
+}
+
+// MerkleRoot returns the root of the binary merkle tree of the Ethereum addresses of the validators, as stored in
+// the BeefyNextAuthoritySet of the MMR leaves.
+func (s BeefyValidatorSet) MerkleRoot() (types.H256, error) {
+	leaves := make([]types.H256, 0, len(s.Validators))
+
+	for i, validator := range s.Validators {
+		publicKey, err := crypto.DecompressPubkey(validator[:])
+		if err != nil {
+			return types.H256{}, ErrValidatorSet.WithMsg("invalid public key of validator %d: %v", i, err)
+		}
+
+		address := crypto.PubkeyToAddress(*publicKey)
+
+		leaves = append(leaves, types.NewH256(crypto.Keccak256(address[:])))
+	}
+
+	return binaryMerkleRoot(leaves), nil
+}
+
+// VerifyBeefyValidatorSet verifies that the validator set is the one committed to by the authority set, found in
+// a verified MMR leaf.
+func VerifyBeefyValidatorSet(set BeefyValidatorSet, authoritySet types.BeefyNextAuthoritySet) error {
+	if set.ID != uint64(authoritySet.ID) || len(set.Validators) != int(authoritySet.Len) {
+		return ErrValidatorSet.WithMsg(
+			"set %d of %d validators, expected set %d of %d validators",
+			set.ID,
+			len(set.Validators),
+			authoritySet.ID,
+			authoritySet.Len,
+		)
+	}
+
+	root, err := set.MerkleRoot()
+	if err != nil {
+		return err
+	}
+
+	if root != authoritySet.Root {
+		return ErrValidatorSet.WithMsg("merkle root %#x, expected %#x", root, authoritySet.Root)
+	}
+
+	return nil
+}
+
+// VerifyBeefySignedCommitment verifies that the commitment was signed by the validator set, which means that every
+// signature is the signature of the keccak-256 hash of the encoded commitment by the validator at its index, and
+// that at least the threshold of the validators signed it.
+func VerifyBeefySignedCommitment(set BeefyValidatorSet, signed types.SignedCommitment) error {
+	if signed.Commitment.ValidatorSetID != set.ID {
+		return ErrValidatorSet.WithMsg("commitment of set %d, expected set %d", signed.Commitment.ValidatorSetID, set.ID)
+	}
+
+	if len(signed.Signatures) != len(set.Validators) {
+		return ErrValidatorSet.WithMsg(
+			"%d signatures for %d validators",
+			len(signed.Signatures),
+			len(set.Validators),
+		)
+	}
+
+	enc, err := codec.Encode(signed.Commitment)
+	if err != nil {
+		return ErrEncoding.Wrap(err)
+	}
+
+	digest := crypto.Keccak256(enc)
+
+	var count int
+
+	for i, optionSignature := range signed.Signatures {
+		ok, signature := optionSignature.Unwrap()
+		if !ok {
+			continue
+		}
+
+		signer, err := recoverBeefySigner(digest, signature)
+		if err != nil {
+			return err
+		}
+
+		if signer != set.Validators[i] {
+			return ErrInvalidSignature.WithMsg("signature of validator %d", i)
+		}
+
+		count++
+	}
+
+	if threshold := set.Threshold(); count < threshold {
+		return ErrInsufficientWeight.WithMsg("%d of %d signatures needed", count, threshold)
+	}
+
+	return nil
+}
+
+// BeefyMMRRoot returns the root of the MMR found in the payload of the commitment.
+func BeefyMMRRoot(commitment types.Commitment) (types.H256, error) {
+	for _, item := range commitment.Payload {
+		if item.ID != types.BeefyMMRRootPayloadID {
+			continue
+		}
+
+		var root types.H256
+
+		if err := codec.Decode(item.Data, &root); err != nil {
+			return types.H256{}, ErrEncoding.Wrap(err)
+		}
+
+		return root, nil
+	}
+
+	return types.H256{}, ErrMissingPayload.WithMsg("%s", types.BeefyMMRRootPayloadID[:])
+}
+
+// VerifyBeefyMMRLeaf verifies the proof of the MMR leaf against the root of the MMR found in the payload of the
+// commitment, which should have been verified with VerifyBeefySignedCommitment.
+func VerifyBeefyMMRLeaf(commitment types.Commitment, leaf types.MMRLeaf, proof types.MMRProof) error {
+	root, err := BeefyMMRRoot(commitment)
+	if err != nil {
+		return err
+	}
+
+	leafHash, err := mmr.HashLeaf(leaf)
+	if err != nil {
+		return err
+	}
+
+	return mmr.VerifyProof(root, leafHash, proof)
+}
+
+// VerifyBeefyMMRLeaves verifies the proof of the MMR leaves returned by mmr_generateProof against the root of the
+// MMR found in the payload of the commitment, which should have been verified with VerifyBeefySignedCommitment.
+func VerifyBeefyMMRLeaves(commitment types.Commitment, proof types.MMRLeavesProof) error {
+	root, err := BeefyMMRRoot(commitment)
+	if err != nil {
+		return err
+	}
+
+	return mmr.VerifyLeavesProof(root, proof)
+}
+
+// recoverBeefySigner returns the public key of the signer of the digest.
+func recoverBeefySigner(digest []byte, signature types.BeefySignature) (types.BeefyAuthorityID, error) {
+	// The recovery ID may be in the Ethereum format.
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(digest, signature[:])
+	if err != nil {
+		return types.BeefyAuthorityID{}, ErrInvalidSignature.Wrap(err)
+	}
+
+	var id types.BeefyAuthorityID
+
+	copy(id[:], crypto.CompressPubkey(publicKey))
+
+	return id, nil
+}
+
+// binaryMerkleRoot returns the root of the binary merkle tree of the hashed leaves, where the last node of a level
+// with an odd number of nodes is promoted to the next level.
+func binaryMerkleRoot(nodes []types.H256) types.H256 {
+	if len(nodes) == 0 {
+		return types.H256{}
+	}
+
+	for len(nodes) > 1 {
+		next := make([]types.H256, 0, (len(nodes)+1)/2)
+
+		for i := 0; i < len(nodes); i += 2 {
+			if i+1 == len(nodes) {
+				next = append(next, nodes[i])
+				continue
+			}
+
+			next = append(next, types.NewH256(crypto.Keccak256(nodes[i][:], nodes[i+1][:])))
+		}
+
+		nodes = next
+	}
+
+	return nodes[0]
+}
@@ -0,0 +1,258 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// The BEEFY keys of the development accounts.
+var (
+	testBeefyAlice   = testBeefyAuthorityID("0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1")
+	testBeefyBob     = testBeefyAuthorityID("0x0390084fdbf27d2b79d26a4f13f0ccd982cb755a661969143c37cbc49ef5b91f27")
+	testBeefyCharlie = testBeefyAuthorityID("0x0389411795514af1627765eceffcbd002719f031604fadd7d188e2dc585b4e1afb")
+)
+
+func TestBeefyValidatorSet_Threshold(t *testing.T) {
+	for count, threshold := range map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 7: 5, 10: 7} {
+		set := BeefyValidatorSet{Validators: make([]types.BeefyAuthorityID, count)}
+		assert.Equal(t, threshold, set.Threshold(), "%d validators", count)
+	}
+}
+
+func TestVerifyBeefyValidatorSet(t *testing.T) {
+	set := BeefyValidatorSet{
+		ID:         1,
+		Validators: []types.BeefyAuthorityID{testBeefyAlice, testBeefyBob, testBeefyCharlie},
+	}
+
+	// The next authority set of the MMR leaf returned by mmr_generateProof on a development chain with the Alice, Bob
+	// and Charlie validators.
+	authoritySet := types.BeefyNextAuthoritySet{
+		ID:   1,
+		Len:  3,
+		Root: types.NewH256(codec.MustHexDecodeString("0x42b63941ec636f52303b3c33f53349830d8a466e9456d25d22b28f4bb0ad0365")),
+	}
+
+	merkleRoot, err := set.MerkleRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, authoritySet.Root, merkleRoot)
+
+	assert.NoError(t, VerifyBeefyValidatorSet(set, authoritySet))
+
+	err = VerifyBeefyValidatorSet(set, types.BeefyNextAuthoritySet{ID: 2, Len: 3, Root: authoritySet.Root})
+	assert.ErrorIs(t, err, ErrValidatorSet)
+
+	err = VerifyBeefyValidatorSet(set, types.BeefyNextAuthoritySet{ID: 1, Len: 2, Root: authoritySet.Root})
+	assert.ErrorIs(t, err, ErrValidatorSet)
+
+	// The validators are not in the order of the authority set.
+	set.Validators[0], set.Validators[1] = set.Validators[1], set.Validators[0]
+	assert.ErrorIs(t, VerifyBeefyValidatorSet(set, authoritySet), ErrValidatorSet)
+
+	set.Validators[0][0] = 0xff
+	_, err = set.MerkleRoot()
+	assert.ErrorIs(t, err, ErrValidatorSet)
+}
+
+func TestRecoverBeefySigner(t *testing.T) {
+	// The signatures of the messages by the Alice BEEFY key, from the SignedCommitment tests of Substrate.
+	testCases := []struct {
+		Message   string
+		Signature string
+	}{
+		{
+			Message:   "This is the first message",
+			Signature: "0x558455ad81279df0795cc985580e4fb75d72d948d1107b2ac80a09abed4da8480c746cc321f2319a5e99a830e314d10dd3cd68ce3dc0c33c86e99bcb7816f9ba01", //nolint:lll
+		},
+		{
+			Message:   "This is the second message",
+			Signature: "0x2d6e1f8105c337a86cdd9aaacdc496577f3db8c55ef9e6fd48f2c5c05a2274707491635d8ba3df64f324575b7b2a34487bca2324b6a0046395a71681be3d0c2a00", //nolint:lll
+		},
+	}
+
+	for _, testCase := range testCases {
+		var signature types.BeefySignature
+		copy(signature[:], codec.MustHexDecodeString(testCase.Signature))
+
+		signer, err := recoverBeefySigner(crypto.Keccak256([]byte(testCase.Message)), signature)
+		assert.NoError(t, err)
+		assert.Equal(t, testBeefyAlice, signer)
+
+		signer, err = recoverBeefySigner(crypto.Keccak256([]byte("Another message")), signature)
+		if err == nil {
+			assert.NotEqual(t, testBeefyAlice, signer)
+		}
+	}
+}
+
+func TestVerifyBeefySignedCommitment(t *testing.T) {
+	keys, validators := testBeefyKeys(t, 4)
+	set := BeefyValidatorSet{ID: 1, Validators: validators}
+	commitment := newTestBeefyCommitment(t, 1, types.H256{0x01})
+
+	signed := signTestBeefyCommitment(t, commitment, keys, 0, 1, 3)
+	assert.NoError(t, VerifyBeefySignedCommitment(set, signed))
+
+	// The recovery ID may be in the Ethereum format.
+	_, signature := signed.Signatures[0].Unwrap()
+	signature[64] += 27
+	signed.Signatures[0].SetSome(signature)
+	assert.NoError(t, VerifyBeefySignedCommitment(set, signed))
+
+	signed = signTestBeefyCommitment(t, commitment, keys, 0, 1)
+	assert.ErrorIs(t, VerifyBeefySignedCommitment(set, signed), ErrInsufficientWeight)
+
+	// The signatures are not in the order of the validators.
+	signed = signTestBeefyCommitment(t, commitment, keys, 0, 1, 3)
+	signed.Signatures[2], signed.Signatures[3] = signed.Signatures[3], signed.Signatures[2]
+	assert.ErrorIs(t, VerifyBeefySignedCommitment(set, signed), ErrInvalidSignature)
+
+	signed = signTestBeefyCommitment(t, commitment, keys, 0, 1, 3)
+	signed.Commitment.BlockNumber++
+	assert.ErrorIs(t, VerifyBeefySignedCommitment(set, signed), ErrInvalidSignature)
+
+	signed = signTestBeefyCommitment(t, newTestBeefyCommitment(t, 2, types.H256{0x01}), keys, 0, 1, 3)
+	assert.ErrorIs(t, VerifyBeefySignedCommitment(set, signed), ErrValidatorSet)
+
+	signed = signTestBeefyCommitment(t, commitment, keys[:3], 0, 1, 2)
+	assert.ErrorIs(t, VerifyBeefySignedCommitment(set, signed), ErrValidatorSet)
+}
+
+func TestVerifyBeefyMMRLeaf(t *testing.T) {
+	leaves := []types.MMRLeaf{
+		{Version: 0, ParentNumberAndHash: types.ParentNumberAndHash{ParentNumber: 1}},
+		{Version: 0, ParentNumberAndHash: types.ParentNumberAndHash{ParentNumber: 2}},
+	}
+
+	var hashes [][]byte
+
+	for _, leaf := range leaves {
+		enc, err := codec.Encode(leaf)
+		require.NoError(t, err)
+
+		hashes = append(hashes, crypto.Keccak256(enc))
+	}
+
+	root := types.NewH256(crypto.Keccak256(hashes...))
+	commitment := newTestBeefyCommitment(t, 1, root)
+
+	proof := types.MMRProof{LeafIndex: 1, LeafCount: 2, Items: []types.H256{types.NewH256(hashes[0])}}
+	assert.NoError(t, VerifyBeefyMMRLeaf(commitment, leaves[1], proof))
+	assert.Error(t, VerifyBeefyMMRLeaf(commitment, leaves[0], proof))
+
+	commitment.Payload[0].ID = [2]byte{'x', 'x'}
+	assert.ErrorIs(t, VerifyBeefyMMRLeaf(commitment, leaves[1], proof), ErrMissingPayload)
+}
+
+func TestVerifyBeefyMMRLeaves(t *testing.T) {
+	tree := mmr.NewTree()
+
+	var leaves []types.MMREncodableOpaqueLeaf
+
+	for i := 0; i < 5; i++ {
+		leaf, err := codec.Encode(types.MMRLeaf{ParentNumberAndHash: types.ParentNumberAndHash{ParentNumber: types.U32(i)}})
+		require.NoError(t, err)
+
+		leaves = append(leaves, leaf)
+		tree.Append(mmr.HashOpaqueLeaf(leaf))
+	}
+
+	root, err := tree.Root()
+	require.NoError(t, err)
+
+	proof, err := tree.Proof(1, 4)
+	require.NoError(t, err)
+
+	leavesProof := types.MMRLeavesProof{Leaves: []types.MMREncodableOpaqueLeaf{leaves[1], leaves[4]}, Proof: proof}
+	assert.NoError(t, VerifyBeefyMMRLeaves(newTestBeefyCommitment(t, 1, root), leavesProof))
+
+	err = VerifyBeefyMMRLeaves(newTestBeefyCommitment(t, 1, types.H256{0x01}), leavesProof)
+	assert.ErrorIs(t, err, mmr.ErrRootMismatch)
+}
+
+// testBeefyKeys returns count generated BEEFY keys, and their authority IDs.
+func testBeefyKeys(t *testing.T, count int) ([]*ecdsa.PrivateKey, []types.BeefyAuthorityID) {
+	keys := make([]*ecdsa.PrivateKey, count)
+	ids := make([]types.BeefyAuthorityID, count)
+
+	for i := range keys {
+		seed := make([]byte, 32)
+		seed[31] = byte(i) + 1
+
+		key, err := crypto.ToECDSA(seed)
+		require.NoError(t, err)
+
+		keys[i] = key
+		copy(ids[i][:], crypto.CompressPubkey(&key.PublicKey))
+	}
+
+	return keys, ids
+}
+
+func newTestBeefyCommitment(t *testing.T, setID uint64, mmrRoot types.H256) types.Commitment {
+	data, err := codec.Encode(mmrRoot)
+	require.NoError(t, err)
+
+	return types.Commitment{
+		Payload:        []types.PayloadItem{{ID: types.BeefyMMRRootPayloadID, Data: data}},
+		BlockNumber:    10,
+		ValidatorSetID: setID,
+	}
+}
+
+// signTestBeefyCommitment returns the commitment signed by the keys at the indexes.
+func signTestBeefyCommitment(
+	t *testing.T,
+	commitment types.Commitment,
+	keys []*ecdsa.PrivateKey,
+	indexes ...int,
+) types.SignedCommitment {
+	enc, err := codec.Encode(commitment)
+	require.NoError(t, err)
+
+	signed := types.SignedCommitment{Commitment: commitment}
+
+	for range keys {
+		signed.Signatures = append(signed.Signatures, types.NewOptionBeefySignatureEmpty())
+	}
+
+	for _, i := range indexes {
+		signature, err := crypto.Sign(crypto.Keccak256(enc), keys[i])
+		require.NoError(t, err)
+
+		signed.Signatures[i] = types.NewOptionBeefySignature(types.BeefySignature(signature))
+	}
+
+	return signed
+}
+
+func testBeefyAuthorityID(hex string) types.BeefyAuthorityID {
+	var id types.BeefyAuthorityID
+	copy(id[:], codec.MustHexDecodeString(hex))
+
+	return id
+}
@@ -0,0 +1,179 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package finality implements the verification of the finality proofs produced by the Substrate finality gadgets,
+// for the clients that follow a chain without trusting the nodes they are connected to.
+package finality
+
+import (
+	"crypto/ed25519"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GrandpaAuthoritySet is a set of GRANDPA authorities, with the ID that the authorities sign their votes with.
+type GrandpaAuthoritySet struct {
+	ID          uint64
+	Authorities []types.GrandpaAuthority
+}
+
+// TotalWeight returns the sum of the weights of the authorities.
+func (s GrandpaAuthoritySet) TotalWeight() uint64 {
+	var total uint64
+
+	for _, authority := range s.Authorities {
+		total += uint64(authority.Weight)
+	}
+
+	return total
+}
+
+// Threshold returns the weight of the precommits needed to finalize a block, which is more than two thirds of the
+// total weight.
+func (s GrandpaAuthoritySet) Threshold() uint64 {
+	total := s.TotalWeight()
+	if total == 0 {
+		return 0
+	}
+
+	return total - (total-1)/3
+}
+
+// weight returns the weight of the authority, and false if it is not part of the set.
+func (s GrandpaAuthoritySet) weight(id types.AuthorityID) (uint64, bool) {
+	for _, authority := range s.Authorities {
+		if authority.ID == id {
+			return uint64(authority.Weight), true
+		}
+	}
+
+	return 0, false
+}
+
+// VerifyGrandpaJustification verifies that the justification was signed by the authority set, which means that:
+//   - every precommit is signed by an authority of the set, for the round of the justification and the ID of the set
+//   - every precommit targets the target of the commit or one of its descendants, as proven by the votes ancestries
+//   - every header of the votes ancestries is used to prove the ancestry of a precommit target
+//   - the authorities that signed the precommits hold at least the threshold weight of the set
+//
+// The precommits of an authority are only counted once.
+func VerifyGrandpaJustification(set GrandpaAuthoritySet, justification types.GrandpaJustification) error {
+	ancestry, err := newVotesAncestry(justification.VotesAncestries)
+	if err != nil {
+		return err
+	}
+
+	commit := justification.Commit
+	signers := make(map[types.AuthorityID]struct{})
+
+	var weight uint64
+
+	for _, precommit := range commit.Precommits {
+		authorityWeight, ok := set.weight(precommit.ID)
+		if !ok {
+			return ErrUnknownAuthority.WithMsg("%#x", precommit.ID)
+		}
+
+		payload, err := types.GrandpaPrecommitPayload(precommit.Precommit, justification.Round, types.U64(set.ID))
+		if err != nil {
+			return ErrEncoding.Wrap(err)
+		}
+
+		if !ed25519.Verify(precommit.ID[:], payload, precommit.Signature[:]) {
+			return ErrInvalidSignature.WithMsg("precommit of authority %#x", precommit.ID)
+		}
+
+		if err := ancestry.walk(precommit.Precommit, commit.TargetHash, commit.TargetNumber); err != nil {
+			return err
+		}
+
+		if _, ok := signers[precommit.ID]; ok {
+			continue
+		}
+
+		signers[precommit.ID] = struct{}{}
+		weight += authorityWeight
+	}
+
+	if unused := ancestry.unused(); unused > 0 {
+		return ErrInvalidAncestry.WithMsg("%d unused headers", unused)
+	}
+
+	if threshold := set.Threshold(); weight < threshold {
+		return ErrInsufficientWeight.WithMsg("%d of %d needed", weight, threshold)
+	}
+
+	return nil
+}
+
+// votesAncestry holds the headers of the votes ancestries of a justification, by hash.
+type votesAncestry struct {
+	headers map[types.Hash]types.Header
+	visited map[types.Hash]struct{}
+}
+
+func newVotesAncestry(headers []types.Header) (*votesAncestry, error) {
+	a := &votesAncestry{
+		headers: make(map[types.Hash]types.Header, len(headers)),
+		visited: make(map[types.Hash]struct{}, len(headers)),
+	}
+
+	for _, header := range headers {
+		hash, err := header.Hash()
+		if err != nil {
+			return nil, ErrEncoding.Wrap(err)
+		}
+
+		a.headers[hash] = header
+	}
+
+	return a, nil
+}
+
+// walk follows the parent hashes of the votes ancestries from the target of the precommit to the base block,
+// which is the target of the commit.
+func (a *votesAncestry) walk(precommit types.GrandpaPrecommit, baseHash types.Hash, baseNumber types.U32) error {
+	hash, number := precommit.TargetHash, precommit.TargetNumber
+
+	for hash != baseHash {
+		if number <= baseNumber {
+			return ErrInvalidAncestry.WithMsg(
+				"precommit target %s is not a descendant of the commit target",
+				precommit.TargetHash.Hex(),
+			)
+		}
+
+		header, ok := a.headers[hash]
+		if !ok || types.U32(header.Number) != number {
+			return ErrInvalidAncestry.WithMsg("missing header %s", hash.Hex())
+		}
+
+		a.visited[hash] = struct{}{}
+
+		hash, number = header.ParentHash, number-1
+	}
+
+	if number != baseNumber {
+		return ErrInvalidAncestry.WithMsg("precommit target %s has an invalid number", precommit.TargetHash.Hex())
+	}
+
+	return nil
+}
+
+// unused returns the number of headers that were not walked through.
+func (a *votesAncestry) unused() int {
+	return len(a.headers) - len(a.visited)
+}
@@ -0,0 +1,189 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestGrandpaAuthoritySet_Threshold(t *testing.T) {
+	testCases := []struct {
+		Weights           []types.U64
+		ExpectedThreshold uint64
+	}{
+		{Weights: nil, ExpectedThreshold: 0},
+		{Weights: []types.U64{1}, ExpectedThreshold: 1},
+		{Weights: []types.U64{1, 1}, ExpectedThreshold: 2},
+		{Weights: []types.U64{1, 1, 1}, ExpectedThreshold: 3},
+		{Weights: []types.U64{1, 1, 1, 1}, ExpectedThreshold: 3},
+		{Weights: []types.U64{1, 1, 1, 1, 1, 1, 1}, ExpectedThreshold: 5},
+		{Weights: []types.U64{5, 1, 1}, ExpectedThreshold: 5},
+		{Weights: []types.U64{3, 3, 4}, ExpectedThreshold: 7},
+	}
+
+	for _, testCase := range testCases {
+		var set GrandpaAuthoritySet
+
+		for _, weight := range testCase.Weights {
+			set.Authorities = append(set.Authorities, types.GrandpaAuthority{Weight: weight})
+		}
+
+		assert.Equal(t, testCase.ExpectedThreshold, set.Threshold(), "weights %v", testCase.Weights)
+	}
+}
+
+func TestVerifyGrandpaJustification(t *testing.T) {
+	keys := testGrandpaKeys(0, 4)
+	set := GrandpaAuthoritySet{ID: 3, Authorities: testGrandpaAuthorities(keys)}
+	target := testGrandpaHeader(10, types.Hash{0x01})
+
+	justification := newTestGrandpaJustification(t, set.ID, target, keys[:3], nil)
+	assert.NoError(t, VerifyGrandpaJustification(set, justification))
+
+	justification = newTestGrandpaJustification(t, set.ID, target, keys[:2], nil)
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInsufficientWeight)
+
+	// The precommits of an authority are counted once.
+	justification = newTestGrandpaJustification(t, set.ID, target, append(keys[:2:2], keys[1]), nil)
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInsufficientWeight)
+
+	// The precommits are signed for another authority set.
+	justification = newTestGrandpaJustification(t, set.ID+1, target, keys[:3], nil)
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInvalidSignature)
+
+	otherKeys := testGrandpaKeys(1, 1)
+
+	justification = newTestGrandpaJustification(t, set.ID, target, append(keys[:2:2], otherKeys[0]), nil)
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrUnknownAuthority)
+
+	justification = newTestGrandpaJustification(t, set.ID, target, keys[:3], nil)
+	justification.Commit.Precommits[0].Signature[0] ^= 0xff
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInvalidSignature)
+}
+
+func TestVerifyGrandpaJustification_VotesAncestries(t *testing.T) {
+	keys := testGrandpaKeys(0, 4)
+	set := GrandpaAuthoritySet{Authorities: testGrandpaAuthorities(keys)}
+
+	target := testGrandpaHeader(10, types.Hash{0x01})
+	child := testGrandpaHeader(11, hashTestGrandpaHeader(t, target))
+	grandChild := testGrandpaHeader(12, hashTestGrandpaHeader(t, child))
+	other := testGrandpaHeader(11, types.Hash{0x02})
+
+	justification := newTestGrandpaJustification(t, set.ID, target, keys, map[int]types.Header{1: child, 2: grandChild})
+	justification.VotesAncestries = []types.Header{grandChild, child}
+	assert.NoError(t, VerifyGrandpaJustification(set, justification))
+
+	// The ancestry of the precommit target is missing.
+	justification.VotesAncestries = []types.Header{grandChild}
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInvalidAncestry)
+
+	// The ancestry contains an unused header.
+	justification.VotesAncestries = []types.Header{grandChild, child, other}
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInvalidAncestry)
+
+	// The precommit target is not a descendant of the commit target.
+	justification = newTestGrandpaJustification(t, set.ID, target, keys, map[int]types.Header{0: other})
+	justification.VotesAncestries = []types.Header{other}
+	assert.ErrorIs(t, VerifyGrandpaJustification(set, justification), ErrInvalidAncestry)
+}
+
+// testGrandpaKeys returns count ed25519 keys derived from the seed.
+func testGrandpaKeys(seed byte, count int) []ed25519.PrivateKey {
+	keys := make([]ed25519.PrivateKey, count)
+
+	for i := range keys {
+		keySeed := make([]byte, ed25519.SeedSize)
+		keySeed[0], keySeed[1] = seed, byte(i)
+
+		keys[i] = ed25519.NewKeyFromSeed(keySeed)
+	}
+
+	return keys
+}
+
+// testGrandpaAuthorities returns the authorities of weight 1 of the keys.
+func testGrandpaAuthorities(keys []ed25519.PrivateKey) []types.GrandpaAuthority {
+	authorities := make([]types.GrandpaAuthority, len(keys))
+
+	for i, key := range keys {
+		copy(authorities[i].ID[:], key.Public().(ed25519.PublicKey))
+		authorities[i].Weight = 1
+	}
+
+	return authorities
+}
+
+// newTestGrandpaJustification returns a justification for the target, with a precommit signed by each key for the
+// target, or for the descendant of the target in precommitTargets at the index of the key.
+func newTestGrandpaJustification(
+	t *testing.T,
+	setID uint64,
+	target types.Header,
+	keys []ed25519.PrivateKey,
+	precommitTargets map[int]types.Header,
+) types.GrandpaJustification {
+	const round = 5
+
+	justification := types.GrandpaJustification{
+		Round: round,
+		Commit: types.GrandpaCommit{
+			TargetHash:   hashTestGrandpaHeader(t, target),
+			TargetNumber: types.U32(target.Number),
+		},
+	}
+
+	for i, key := range keys {
+		precommitTarget, ok := precommitTargets[i]
+		if !ok {
+			precommitTarget = target
+		}
+
+		precommit := types.GrandpaPrecommit{
+			TargetHash:   hashTestGrandpaHeader(t, precommitTarget),
+			TargetNumber: types.U32(precommitTarget.Number),
+		}
+
+		payload, err := types.GrandpaPrecommitPayload(precommit, round, types.U64(setID))
+		require.NoError(t, err)
+
+		signed := types.GrandpaSignedPrecommit{Precommit: precommit}
+		copy(signed.Signature[:], ed25519.Sign(key, payload))
+		copy(signed.ID[:], key.Public().(ed25519.PublicKey))
+
+		justification.Commit.Precommits = append(justification.Commit.Precommits, signed)
+	}
+
+	return justification
+}
+
+func testGrandpaHeader(number types.BlockNumber, parentHash types.Hash) types.Header {
+	return types.Header{ParentHash: parentHash, Number: number}
+}
+
+func hashTestGrandpaHeader(t *testing.T, header types.Header) types.Hash {
+	hash, err := header.Hash()
+	require.NoError(t, err)
+
+	return hash
+}
@@ -0,0 +1,130 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GrandpaTracker follows the changes of the GRANDPA authority set from the Consensus digest items of the imported
+// headers, and verifies the justifications against the current authority set.
+//
+// The headers must be imported in order, without gaps, so that no change of the authority set is missed. Pause,
+// Resume and OnDisabled logs do not change the authority set, and are ignored.
+//
+// A GrandpaTracker is not safe for concurrent use.
+type GrandpaTracker struct {
+	set GrandpaAuthoritySet
+
+	head *types.Header
+
+	scheduled *grandpaPendingChange
+	forced    *grandpaPendingChange
+}
+
+// grandpaPendingChange is a change of the authority set, enacted at the block number enactAt.
+type grandpaPendingChange struct {
+	authorities []types.GrandpaAuthority
+	enactAt     types.BlockNumber
+}
+
+// NewGrandpaTracker creates a new GrandpaTracker, starting with the trusted authority set.
+func NewGrandpaTracker(set GrandpaAuthoritySet) *GrandpaTracker {
+	return &GrandpaTracker{set: set}
+}
+
+// AuthoritySet returns the current authority set.
+func (t *GrandpaTracker) AuthoritySet() GrandpaAuthoritySet {
+	return GrandpaAuthoritySet{
+		ID:          t.set.ID,
+		Authorities: append([]types.GrandpaAuthority{}, t.set.Authorities...),
+	}
+}
+
+// ImportHeader records the changes of the authority set signaled in the digest of the header, and enacts the forced
+// changes that are due. The header must be the child of the previously imported header, if any.
+func (t *GrandpaTracker) ImportHeader(header types.Header) error {
+	if t.head != nil {
+		if err := types.VerifyHeaderChain([]types.Header{*t.head, header}); err != nil {
+			return err
+		}
+	}
+
+	logs, err := types.GrandpaConsensusLogs(header.Digest)
+	if err != nil {
+		return err
+	}
+
+	for _, log := range logs {
+		switch {
+		case log.IsScheduledChange:
+			if t.scheduled != nil {
+				return ErrPendingChange.WithMsg("scheduled change signaled at block #%d", header.Number)
+			}
+
+			t.scheduled = newGrandpaPendingChange(header.Number, log.AsScheduledChange)
+		case log.IsForcedChange:
+			if t.forced != nil {
+				return ErrPendingChange.WithMsg("forced change signaled at block #%d", header.Number)
+			}
+
+			t.forced = newGrandpaPendingChange(header.Number, log.AsForcedChange.Change)
+		}
+	}
+
+	if t.forced != nil && header.Number >= t.forced.enactAt {
+		t.enact(t.forced)
+
+		// The forced change replaces the scheduled change that was not enacted yet.
+		t.forced, t.scheduled = nil, nil
+	}
+
+	t.head = &header
+
+	return nil
+}
+
+// VerifyJustification verifies the justification against the current authority set, and enacts the scheduled
+// change of the authority set if the justification finalizes the block it is due at.
+func (t *GrandpaTracker) VerifyJustification(justification types.GrandpaJustification) error {
+	if err := VerifyGrandpaJustification(t.set, justification); err != nil {
+		return fmt.Errorf("authority set %d: %w", t.set.ID, err)
+	}
+
+	if t.scheduled != nil && types.BlockNumber(justification.Commit.TargetNumber) >= t.scheduled.enactAt {
+		t.enact(t.scheduled)
+		t.scheduled = nil
+	}
+
+	return nil
+}
+
+func (t *GrandpaTracker) enact(change *grandpaPendingChange) {
+	t.set = GrandpaAuthoritySet{
+		ID:          t.set.ID + 1,
+		Authorities: change.authorities,
+	}
+}
+
+func newGrandpaPendingChange(number types.BlockNumber, change types.GrandpaScheduledChange) *grandpaPendingChange {
+	return &grandpaPendingChange{
+		authorities: change.NextAuthorities,
+		enactAt:     number + types.BlockNumber(change.Delay),
+	}
+}
@@ -0,0 +1,137 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func TestGrandpaTracker_ScheduledChange(t *testing.T) {
+	keys, nextKeys := testGrandpaKeys(0, 4), testGrandpaKeys(1, 3)
+	set := GrandpaAuthoritySet{Authorities: testGrandpaAuthorities(keys)}
+	nextSet := GrandpaAuthoritySet{ID: 1, Authorities: testGrandpaAuthorities(nextKeys)}
+
+	tracker := NewGrandpaTracker(set)
+
+	headers := testGrandpaChain(t, 3, map[int]types.GrandpaConsensusLog{
+		1: {
+			IsScheduledChange: true,
+			AsScheduledChange: types.GrandpaScheduledChange{NextAuthorities: nextSet.Authorities, Delay: 1},
+		},
+	})
+
+	for _, header := range headers {
+		require.NoError(t, tracker.ImportHeader(header))
+	}
+
+	// The change is not enacted before the block it is due at is finalized.
+	err := tracker.VerifyJustification(newTestGrandpaJustification(t, 0, headers[1], keys, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, set, tracker.AuthoritySet())
+
+	err = tracker.VerifyJustification(newTestGrandpaJustification(t, 0, headers[2], keys, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, nextSet, tracker.AuthoritySet())
+
+	err = tracker.VerifyJustification(newTestGrandpaJustification(t, 0, headers[3], keys, nil))
+	assert.ErrorIs(t, err, ErrUnknownAuthority)
+
+	err = tracker.VerifyJustification(newTestGrandpaJustification(t, 1, headers[3], nextKeys, nil))
+	assert.NoError(t, err)
+}
+
+func TestGrandpaTracker_ForcedChange(t *testing.T) {
+	nextKeys := testGrandpaKeys(1, 3)
+	set := GrandpaAuthoritySet{Authorities: testGrandpaAuthorities(testGrandpaKeys(0, 4))}
+	nextSet := GrandpaAuthoritySet{ID: 1, Authorities: testGrandpaAuthorities(nextKeys)}
+
+	tracker := NewGrandpaTracker(set)
+
+	headers := testGrandpaChain(t, 3, map[int]types.GrandpaConsensusLog{
+		1: {
+			IsForcedChange: true,
+			AsForcedChange: types.GrandpaForcedChange{
+				MedianLastFinalized: 0,
+				Change:              types.GrandpaScheduledChange{NextAuthorities: nextSet.Authorities, Delay: 2},
+			},
+		},
+	})
+
+	for _, header := range headers[:3] {
+		require.NoError(t, tracker.ImportHeader(header))
+	}
+
+	assert.Equal(t, set, tracker.AuthoritySet())
+
+	// The forced change is enacted when the block it is due at is imported.
+	require.NoError(t, tracker.ImportHeader(headers[3]))
+	assert.Equal(t, nextSet, tracker.AuthoritySet())
+
+	err := tracker.VerifyJustification(newTestGrandpaJustification(t, 1, headers[3], nextKeys, nil))
+	assert.NoError(t, err)
+}
+
+func TestGrandpaTracker_ImportHeader(t *testing.T) {
+	set := GrandpaAuthoritySet{Authorities: []types.GrandpaAuthority{{Weight: 1}}}
+	change := types.GrandpaConsensusLog{
+		IsScheduledChange: true,
+		AsScheduledChange: types.GrandpaScheduledChange{NextAuthorities: set.Authorities, Delay: 5},
+	}
+
+	headers := testGrandpaChain(t, 3, map[int]types.GrandpaConsensusLog{1: change, 2: change})
+
+	tracker := NewGrandpaTracker(set)
+
+	require.NoError(t, tracker.ImportHeader(headers[0]))
+	assert.ErrorIs(t, tracker.ImportHeader(headers[2]), types.ErrHeaderNumberMismatch)
+
+	otherHeader := testGrandpaHeader(1, types.Hash{0xff})
+	assert.ErrorIs(t, tracker.ImportHeader(otherHeader), types.ErrParentHashMismatch)
+
+	require.NoError(t, tracker.ImportHeader(headers[1]))
+	assert.ErrorIs(t, tracker.ImportHeader(headers[2]), ErrPendingChange)
+}
+
+// testGrandpaChain returns a chain of count+1 headers starting from the genesis header, with a Consensus digest item
+// holding the GRANDPA log of logs at the index of the header.
+func testGrandpaChain(t *testing.T, count int, logs map[int]types.GrandpaConsensusLog) []types.Header {
+	headers := []types.Header{testGrandpaHeader(0, types.Hash{})}
+
+	for i := 1; i <= count; i++ {
+		header := testGrandpaHeader(types.BlockNumber(i), hashTestGrandpaHeader(t, headers[i-1]))
+
+		if log, ok := logs[i]; ok {
+			enc, err := codec.Encode(log)
+			require.NoError(t, err)
+
+			header.Digest = types.Digest{{
+				IsConsensus: true,
+				AsConsensus: types.Consensus{ConsensusEngineID: types.GrandpaEngineID, Bytes: enc},
+			}}
+		}
+
+		headers = append(headers, header)
+	}
+
+	return headers
+}
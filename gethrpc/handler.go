@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -83,7 +84,7 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		cancelRoot:     cancelRoot,
 		allowSubscribe: true,
 		serverSubs:     make(map[ID]*Subscription),
-		log:            log.Root(),
+		log:            rootLogger(),
 	}
 	if conn.RemoteAddr() != "" {
 		h.log = h.log.New("conn", conn.RemoteAddr())
@@ -315,15 +316,15 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
-	// if msg.isSubscribe() {
-	// 	return h.handleSubscribe(cp, msg)
-	// }
+	if h.reg.isSubscribeMethod(msg.Method) {
+		return h.handleSubscribe(cp, msg)
+	}
 	var callb *callback
-	// if msg.isUnsubscribe() {
-	// callb = h.unsubscribeCb
-	// } else {
-	callb = h.reg.callback(msg.Method)
-	// }
+	if h.reg.isUnsubscribeMethod(msg.Method) {
+		callb = h.unsubscribeCb
+	} else {
+		callb = h.reg.callback(msg.Method)
+	}
 	if callb == nil {
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
@@ -335,38 +336,36 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	return h.runMethod(cp.ctx, msg, callb, args)
 }
 
-// handleSubscribe processes *_subscribe method calls.
-// func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
-// 	if !h.allowSubscribe {
-// 		return msg.errorResponse(ErrNotificationsUnsupported)
-// 	}
-
-// 	// Subscription method name is first argument.
-// 	name, err := parseSubscriptionName(msg.Params)
-// 	if err != nil {
-// 		return msg.errorResponse(&invalidParamsError{err.Error()})
-// 	}
-// 	namespace := msg.namespace()
-// 	callb := h.reg.subscription(namespace, name)
-// 	if callb == nil {
-// 		return msg.errorResponse(&subscriptionNotFoundError{namespace, name})
-// 	}
-
-// 	// Parse subscription name arg too, but remove it before calling the callback.
-// 	argTypes := append([]reflect.Type{stringType}, callb.argTypes...)
-// 	args, err := parsePositionalArguments(msg.Params, argTypes)
-// 	if err != nil {
-// 		return msg.errorResponse(&invalidParamsError{err.Error()})
-// 	}
-// 	args = args[1:]
-
-// 	// Install notifier in context so the subscription handler can find it.
-// 	n := &Notifier{h: h, namespace: namespace}
-// 	cp.notifiers = append(cp.notifiers, n)
-// 	ctx := context.WithValue(cp.ctx, notifierKey{}, n)
-
-// 	return h.runMethod(ctx, msg, callb, args)
-// }
+// handleSubscribe processes the Substrate style subscription calls registered with Server.RegisterSubscription,
+// such as chain_subscribeNewHead.
+func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	namespace := msg.namespace()
+	name := strings.TrimPrefix(msg.Method, namespace+serviceMethodSeparator)
+
+	callb := h.reg.subscription(namespace, name)
+	if callb == nil {
+		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
+	}
+	if !h.allowSubscribe {
+		return msg.errorResponse(ErrNotificationsUnsupported)
+	}
+	args, err := parsePositionalArguments(msg.Params, callb.argTypes)
+	if err != nil {
+		return msg.errorResponse(&invalidParamsError{err.Error()})
+	}
+
+	// Install notifier in context so the subscription handler can find it.
+	n := &Notifier{
+		h:                        h,
+		namespace:                namespace,
+		subscribeMethodSuffix:    name,
+		notificationMethodSuffix: serviceMethodSeparator + name,
+	}
+	cp.notifiers = append(cp.notifiers, n)
+	ctx := context.WithValue(cp.ctx, notifierKey{}, n)
+
+	return h.runMethod(ctx, msg, callb, args)
+}
 
 // runMethod runs the Go callback for an RPC method.
 func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value) *jsonrpcMessage {
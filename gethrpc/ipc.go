@@ -20,7 +20,6 @@ import (
 	"context"
 	"net"
 
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 )
 
@@ -29,12 +28,12 @@ func (s *Server) ServeListener(l net.Listener) error {
 	for {
 		conn, err := l.Accept()
 		if netutil.IsTemporaryError(err) {
-			log.Warn("RPC accept error", "err", err)
+			rootLogger().Warn("RPC accept error", "err", err)
 			continue
 		} else if err != nil {
 			return err
 		}
-		log.Trace("Accepted RPC connection", "conn", conn.RemoteAddr())
+		rootLogger().Trace("Accepted RPC connection", "conn", conn.RemoteAddr())
 		go s.ServeCodec(NewJSONCodec(conn), OptionMethodInvocation|OptionSubscriptions)
 	}
 }
@@ -0,0 +1,51 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// LevelTrace is the level of the trace records, such as the ones logged for every handled message.
+const LevelTrace = log.LevelTrace
+
+var customLogger atomic.Pointer[log.Logger]
+
+// SetLogger routes the records logged by the RPC clients and servers to the given logger.
+// Trace records are logged at LevelTrace, which is below slog.LevelDebug. A nil logger
+// restores the go-ethereum root logger.
+//
+// The logger is picked up by the connections that are established after the call.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		customLogger.Store(nil)
+		return
+	}
+	logger := log.NewLogger(l.Handler())
+	customLogger.Store(&logger)
+}
+
+// rootLogger returns the logger set by SetLogger, or the go-ethereum root logger.
+func rootLogger() log.Logger {
+	if l := customLogger.Load(); l != nil {
+		return *l
+	}
+	return log.Root()
+}
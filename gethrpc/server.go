@@ -22,7 +22,6 @@ import (
 	"sync/atomic"
 
 	mapset "github.com/deckarep/golang-set"
-	"github.com/ethereum/go-ethereum/log"
 )
 
 const MetadataApi = "rpc"
@@ -66,6 +65,17 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 	return s.services.registerName(name, receiver)
 }
 
+// RegisterSubscription makes the method, e.g. chain_subscribeNewHead, start the subscription of the same name
+// of its service, as subscriptions are called on Substrate nodes. The unsubscribe method, e.g.
+// chain_unsubscribeNewHead, ends the subscription with the ID found in its params. It is not registered if empty,
+// e.g. when the service provides its own unsubscribe method.
+//
+// The service must be registered with RegisterName first. The methods that are not registered are not resolved
+// against the subscriptions.
+func (s *Server) RegisterSubscription(method, unsubscribeMethod string) error {
+	return s.services.registerSubscription(method, unsubscribeMethod)
+}
+
 // ServeCodec reads incoming requests from codec, calls the appropriate callback and writes
 // the response back using the given codec. It will block until the codec is closed or the
 // server is stopped. In either case the codec is closed.
@@ -120,7 +130,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 // subscriptions.
 func (s *Server) Stop() {
 	if atomic.CompareAndSwapInt32(&s.run, 1, 0) {
-		log.Debug("RPC server shutting down")
+		rootLogger().Debug("RPC server shutting down")
 		s.codecs.Each(func(c interface{}) bool {
 			c.(ServerCodec).Close()
 			return true
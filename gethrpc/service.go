@@ -26,8 +26,6 @@ import (
 	"sync"
 	"unicode"
 	"unicode/utf8"
-
-	"github.com/ethereum/go-ethereum/log"
 )
 
 var (
@@ -40,6 +38,11 @@ var (
 type serviceRegistry struct {
 	mu       sync.Mutex
 	services map[string]service
+
+	// subscribeMethods and unsubscribeMethods hold the Substrate style subscription methods,
+	// see Server.RegisterSubscription.
+	subscribeMethods   map[string]struct{}
+	unsubscribeMethods map[string]struct{}
 }
 
 // service represents a registered object.
@@ -104,6 +107,45 @@ func (r *serviceRegistry) callback(method string) *callback {
 	return r.services[elem[0]].callbacks[elem[1]]
 }
 
+// registerSubscription registers the Substrate style subscribe and unsubscribe methods of a subscription.
+func (r *serviceRegistry) registerSubscription(method, unsubscribeMethod string) error {
+	elem := strings.SplitN(method, serviceMethodSeparator, 2)
+	if len(elem) != 2 {
+		return fmt.Errorf("invalid subscription method %s", method)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.services[elem[0]].subscriptions[elem[1]] == nil {
+		return fmt.Errorf("no subscription %s in service %s", elem[1], elem[0])
+	}
+	if r.subscribeMethods == nil {
+		r.subscribeMethods = make(map[string]struct{})
+		r.unsubscribeMethods = make(map[string]struct{})
+	}
+	r.subscribeMethods[method] = struct{}{}
+	if unsubscribeMethod != "" {
+		r.unsubscribeMethods[unsubscribeMethod] = struct{}{}
+	}
+	return nil
+}
+
+// isSubscribeMethod returns true if the method is a registered Substrate style subscribe method.
+func (r *serviceRegistry) isSubscribeMethod(method string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.subscribeMethods[method]
+	return ok
+}
+
+// isUnsubscribeMethod returns true if the method is a registered Substrate style unsubscribe method.
+func (r *serviceRegistry) isUnsubscribeMethod(method string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.unsubscribeMethods[method]
+	return ok
+}
+
 // subscription returns a subscription callback in the given service.
 func (r *serviceRegistry) subscription(service, name string) *callback {
 	r.mu.Lock()
@@ -201,7 +243,7 @@ func (c *callback) call(ctx context.Context, method string, args []reflect.Value
 			const size = 64 << 10
 			buf := make([]byte, size)
 			buf = buf[:runtime.Stack(buf, false)]
-			log.Error("RPC method " + method + " crashed: " + fmt.Sprintf("%v\n%s", err, buf))
+			rootLogger().Error("RPC method " + method + " crashed: " + fmt.Sprintf("%v\n%s", err, buf))
 			errRes = errors.New("method handler crashed")
 		}
 	}()
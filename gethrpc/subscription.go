@@ -26,7 +26,9 @@ import (
 	"errors"
 	"math/rand"
 	"reflect"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -70,6 +72,30 @@ func encodeID(b []byte) ID {
 	return ID(uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]))
 }
 
+// String returns the decimal representation of the ID.
+func (id ID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// MarshalJSON marshals the ID as a string, which is how the clients expect subscription IDs.
+func (id ID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON unmarshals the ID from either a string or a number.
+func (id *ID) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return err
+	}
+	*id = ID(v)
+	return nil
+}
+
 type notifierKey struct{}
 
 // NotifierFromContext returns the Notifier value stored in ctx, if any.
@@ -168,7 +194,7 @@ func (n *Notifier) activate() error {
 }
 
 func (n *Notifier) send(sub *Subscription, data json.RawMessage) error {
-	params, _ := json.Marshal(&subscriptionResult{ID: string(sub.ID), Result: data})
+	params, _ := json.Marshal(&subscriptionResult{ID: sub.ID.String(), Result: data})
 	ctx := context.Background()
 	return n.h.conn.Write(ctx, &jsonrpcMessage{
 		Version: vsn,
@@ -209,7 +235,9 @@ type ClientSubscription struct {
 	unsubscribeMethodSuffix  string
 	notificationMethodSuffix string
 	subid                    string
+	feedID                   atomic.Pointer[string] // replaces subid, if set
 	in                       chan json.RawMessage
+	unsubscribe              func() error // replaces the server unsubscribe call, if set
 
 	quitOnce sync.Once     // ensures quit is closed once
 	quit     chan struct{} // quit is closed when the subscription exits
@@ -246,6 +274,15 @@ func (sub *ClientSubscription) Err() <-chan error {
 	return sub.err
 }
 
+// ID returns the ID assigned to the subscription by the server, which identifies the
+// subscription in the server methods that take it as a parameter.
+func (sub *ClientSubscription) ID() string {
+	if id := sub.feedID.Load(); id != nil {
+		return *id
+	}
+	return sub.subid
+}
+
 // Unsubscribe unsubscribes the notification and closes the error channel.
 // It can safely be called more than once.
 func (sub *ClientSubscription) Unsubscribe() {
@@ -330,6 +367,62 @@ func (sub *ClientSubscription) unmarshal(result json.RawMessage) (interface{}, e
 }
 
 func (sub *ClientSubscription) requestUnsubscribe() error {
+	if sub.unsubscribe != nil {
+		return sub.unsubscribe()
+	}
 	var result interface{}
 	return sub.client.Call(&result, sub.namespace+"_"+sub.unsubscribeMethodSuffix, sub.subid)
 }
+
+// SubscriptionFeed feeds the notifications of a ClientSubscription that is not bound to a client connection. It
+// allows keeping a single subscription alive across several underlying subscriptions, e.g. when reconnecting.
+type SubscriptionFeed struct {
+	sub *ClientSubscription
+}
+
+// NewSubscriptionFeed creates a feed for a new ClientSubscription that delivers the notifications sent through
+// the feed to the given channel. The element type of the channel must match the type of the notifications.
+//
+// The unsubscribe function is called once, when the subscription ends due to Unsubscribe, Close or an error.
+func NewSubscriptionFeed(channel interface{}, unsubscribe func() error) *SubscriptionFeed {
+	chanVal := reflect.ValueOf(channel)
+	if chanVal.Kind() != reflect.Chan || chanVal.Type().ChanDir()&reflect.SendDir == 0 {
+		panic("channel given to NewSubscriptionFeed must be a writable channel")
+	}
+	if chanVal.IsNil() {
+		panic("channel given to NewSubscriptionFeed must not be nil")
+	}
+
+	sub := newClientSubscription(nil, "", "", "", "", chanVal)
+	sub.unsubscribe = unsubscribe
+	go sub.start()
+
+	return &SubscriptionFeed{sub: sub}
+}
+
+// Subscription returns the subscription fed by the feed.
+func (f *SubscriptionFeed) Subscription() *ClientSubscription {
+	return f.sub
+}
+
+// Send delivers the raw notification to the subscription. It returns false if the subscription has ended.
+func (f *SubscriptionFeed) Send(result json.RawMessage) bool {
+	return f.sub.deliver(result)
+}
+
+// Close ends the subscription with the provided error, which is received on the error channel of the
+// subscription if it is not nil.
+func (f *SubscriptionFeed) Close(err error) {
+	f.sub.quitWithError(err, true)
+}
+
+// SetID sets the ID returned by the subscription, which is the ID of the underlying
+// subscription currently feeding it.
+func (f *SubscriptionFeed) SetID(id string) {
+	f.sub.feedID.Store(&id)
+}
+
+// Done returns a channel that is closed when the subscription has ended.
+func (f *SubscriptionFeed) Done() <-chan struct{} {
+	return f.sub.quit
+}
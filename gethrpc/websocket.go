@@ -23,17 +23,19 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
-	"github.com/ethereum/go-ethereum/log"
 	"github.com/gorilla/websocket"
 )
 
 const (
-	wsReadBuffer  = 1024
-	wsWriteBuffer = 1024
+	wsReadBuffer       = 1024
+	wsWriteBuffer      = 1024
+	wsPingWriteTimeout = 5 * time.Second
 )
 
 var wsBufferPool = new(sync.Pool)
@@ -59,10 +61,10 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
-			log.Debug("WebSocket upgrade failed", "err", err)
+			rootLogger().Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
-		codec := newWebsocketCodec(conn)
+		codec := newWebsocketCodec(conn, maxRequestContentLength, 0, 0)
 		s.ServeCodec(codec, OptionMethodInvocation|OptionSubscriptions)
 	})
 }
@@ -89,7 +91,7 @@ func wsHandshakeValidator(allowedOrigins []string) func(*http.Request) bool {
 			origins.Add("http://" + strings.ToLower(hostname))
 		}
 	}
-	log.Debug(fmt.Sprintf("Allowed origin(s) for WS RPC interface %v", origins.ToSlice()))
+	rootLogger().Debug(fmt.Sprintf("Allowed origin(s) for WS RPC interface %v", origins.ToSlice()))
 
 	f := func(req *http.Request) bool {
 		// Skip origin verification if no Origin header is present. The origin check
@@ -104,7 +106,7 @@ func wsHandshakeValidator(allowedOrigins []string) func(*http.Request) bool {
 		if allowAllOrigins || origins.Contains(origin) {
 			return true
 		}
-		log.Warn("Rejected WebSocket connection", "origin", origin)
+		rootLogger().Warn("Rejected WebSocket connection", "origin", origin)
 		return false
 	}
 
@@ -116,6 +118,14 @@ type wsHandshakeError struct {
 	status string
 }
 
+// StatusCode returns the HTTP status code of the failed handshake, or 0 if the
+// server did not respond.
+func (e wsHandshakeError) StatusCode() int {
+	code, _, _ := strings.Cut(e.status, " ")
+	n, _ := strconv.Atoi(code)
+	return n
+}
+
 func (e wsHandshakeError) Error() string {
 	s := e.err.Error()
 	if e.status != "" {
@@ -124,23 +134,62 @@ func (e wsHandshakeError) Error() string {
 	return s
 }
 
-// DialWebsocket creates a new RPC client that communicates with a JSON-RPC server
-// that is listening on the given endpoint.
+// DialWebsocketWithDialer creates a new RPC client using WebSocket.
 //
 // The context is used for the initial connection establishment. It does not
 // affect subsequent interactions with the client.
-func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error) {
-	endpoint, header, err := wsClientHeaders(endpoint, origin)
+func DialWebsocketWithDialer(ctx context.Context, endpoint, origin string, dialer websocket.Dialer) (*Client, error) {
+	cfg := new(clientConfig)
+	cfg.wsDialer = &dialer
+	if origin != "" {
+		cfg.setHeader("origin", origin)
+	}
+	connect, err := newClientTransportWS(endpoint, cfg)
 	if err != nil {
 		return nil, err
 	}
+	return newClient(ctx, connect)
+}
+
+// DialWebsocket creates a new RPC client that communicates with a JSON-RPC server
+// that is listening on the given endpoint.
+//
+// The context is used for the initial connection establishment. It does not
+// affect subsequent interactions with the client.
+func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error) {
 	dialer := websocket.Dialer{
 		ReadBufferSize:  wsReadBuffer,
 		WriteBufferSize: wsWriteBuffer,
 		WriteBufferPool: wsBufferPool,
 	}
-	return newClient(ctx, func(ctx context.Context) (ServerCodec, error) {
-		conn, resp, err := dialer.DialContext(ctx, endpoint, header)
+	return DialWebsocketWithDialer(ctx, endpoint, origin, dialer)
+}
+
+func newClientTransportWS(endpoint string, cfg *clientConfig) (reconnectFunc, error) {
+	dialer := cfg.wsDialer
+	if dialer == nil {
+		dialer = &websocket.Dialer{
+			ReadBufferSize:  wsReadBuffer,
+			WriteBufferSize: wsWriteBuffer,
+			WriteBufferPool: wsBufferPool,
+		}
+	}
+
+	dialURL, header, err := wsClientHeaders(endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range cfg.httpHeaders {
+		header[key] = values
+	}
+
+	messageSizeLimit := int64(maxRequestContentLength)
+	if cfg.wsMessageSizeLimit != nil && *cfg.wsMessageSizeLimit >= 0 {
+		messageSizeLimit = *cfg.wsMessageSizeLimit
+	}
+
+	connect := func(ctx context.Context) (ServerCodec, error) {
+		conn, resp, err := dialer.DialContext(ctx, dialURL, header)
 		if err != nil {
 			hErr := wsHandshakeError{err: err}
 			if resp != nil {
@@ -148,8 +197,9 @@ func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error
 			}
 			return nil, hErr
 		}
-		return newWebsocketCodec(conn), nil
-	})
+		return newWebsocketCodec(conn, messageSizeLimit, cfg.wsPingInterval, cfg.wsPongTimeout), nil
+	}
+	return connect, nil
 }
 
 func wsClientHeaders(endpoint, origin string) (string, http.Header, error) {
@@ -169,7 +219,97 @@ func wsClientHeaders(endpoint, origin string) (string, http.Header, error) {
 	return endpointURL.String(), header, nil
 }
 
-func newWebsocketCodec(conn *websocket.Conn) ServerCodec {
-	conn.SetReadLimit(maxRequestContentLength)
-	return newCodec(conn, conn.WriteJSON, conn.ReadJSON)
+func newWebsocketCodec(conn *websocket.Conn, readLimit int64, pingInterval, pongTimeout time.Duration) ServerCodec {
+	conn.SetReadLimit(readLimit)
+	codec := newCodec(conn, conn.WriteJSON, conn.ReadJSON).(*jsonCodec)
+	if pingInterval <= 0 {
+		return codec
+	}
+
+	wc := &websocketCodec{
+		jsonCodec:    codec,
+		conn:         conn,
+		pingInterval: pingInterval,
+		pongTimeout:  pongTimeout,
+		pingReset:    make(chan struct{}, 1),
+		pongReceived: make(chan struct{}),
+	}
+	conn.SetPongHandler(func(appData string) error {
+		select {
+		case wc.pongReceived <- struct{}{}:
+		case <-wc.Closed():
+		}
+		return nil
+	})
+	wc.wg.Add(1)
+	go wc.pingLoop()
+	return wc
+}
+
+// websocketCodec is a websocket codec that sends keepalive pings, and closes the
+// connection when the peer does not answer them.
+type websocketCodec struct {
+	*jsonCodec
+	conn *websocket.Conn
+
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	wg           sync.WaitGroup
+	pingReset    chan struct{}
+	pongReceived chan struct{}
+}
+
+func (wc *websocketCodec) Close() {
+	wc.jsonCodec.Close()
+	wc.wg.Wait()
+}
+
+func (wc *websocketCodec) Write(ctx context.Context, v interface{}) error {
+	err := wc.jsonCodec.Write(ctx, v)
+	if err == nil {
+		// Notify pingLoop to delay the next idle ping.
+		select {
+		case wc.pingReset <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+// pingLoop sends periodic ping frames when the connection is idle.
+func (wc *websocketCodec) pingLoop() {
+	defer wc.wg.Done()
+
+	pingTimer := time.NewTimer(wc.pingInterval)
+	defer pingTimer.Stop()
+
+	// awaitingPong is set while a ping is unanswered, so that the read deadline is not
+	// pushed back by the following pings.
+	awaitingPong := false
+
+	for {
+		select {
+		case <-wc.Closed():
+			return
+		case <-wc.pingReset:
+			if !pingTimer.Stop() {
+				<-pingTimer.C
+			}
+			pingTimer.Reset(wc.pingInterval)
+		case <-pingTimer.C:
+			if !awaitingPong {
+				wc.jsonCodec.encMu.Lock()
+				wc.conn.SetWriteDeadline(time.Now().Add(wsPingWriteTimeout))
+				wc.conn.WriteMessage(websocket.PingMessage, nil)
+				wc.jsonCodec.encMu.Unlock()
+				// The read fails, which closes the connection, if the pong is not received in time.
+				wc.conn.SetReadDeadline(time.Now().Add(wc.pongTimeout))
+				awaitingPong = true
+			}
+			pingTimer.Reset(wc.pingInterval)
+		case <-wc.pongReceived:
+			awaitingPong = false
+			wc.conn.SetReadDeadline(time.Time{})
+		}
+	}
 }
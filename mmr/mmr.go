@@ -0,0 +1,135 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mmr implements the Merkle Mountain Range built by the MMR pallet, whose nodes are hashed with keccak-256,
+// and the verification of the proofs of its leaves.
+//
+// The nodes of the MMR are numbered by their position in the order they are appended, the leaves and their parents
+// being interleaved. The peaks are the roots of the perfect binary trees the MMR is made of, and the root of the MMR
+// is the hash of its peaks bagged from right to left.
+package mmr
+
+import (
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// HashLeaf returns the keccak-256 hash of the SCALE encoded leaf, such as a types.MMRLeaf.
+func HashLeaf(leaf interface{}) (types.H256, error) {
+	enc, err := codec.Encode(leaf)
+	if err != nil {
+		return types.H256{}, ErrEncoding.Wrap(err)
+	}
+
+	return types.NewH256(crypto.Keccak256(enc)), nil
+}
+
+// HashOpaqueLeaf returns the keccak-256 hash of the SCALE encoded leaf, such as the leaves of types.MMRLeavesProof.
+func HashOpaqueLeaf(leaf []byte) types.H256 {
+	return types.NewH256(crypto.Keccak256(leaf))
+}
+
+// merge returns the hash of a parent node from the hashes of its children.
+func merge(left, right types.H256) types.H256 {
+	return types.NewH256(crypto.Keccak256(left[:], right[:]))
+}
+
+// bagPeaks returns the root of the MMR from the hashes of its peaks, which are bagged from right to left.
+func bagPeaks(peaks []types.H256) (types.H256, bool) {
+	if len(peaks) == 0 {
+		return types.H256{}, false
+	}
+
+	for len(peaks) > 1 {
+		right, left := peaks[len(peaks)-1], peaks[len(peaks)-2]
+		peaks = append(peaks[:len(peaks)-2], merge(right, left))
+	}
+
+	return peaks[0], true
+}
+
+// Size returns the number of nodes of the MMR with leafCount leaves.
+func Size(leafCount uint64) uint64 {
+	return 2*leafCount - uint64(bits.OnesCount64(leafCount))
+}
+
+// LeafIndexToPos returns the position of the leaf with the index.
+func LeafIndexToPos(index uint64) uint64 {
+	return Size(index+1) - uint64(bits.TrailingZeros64(index+1)) - 1
+}
+
+// posHeight returns the height of the node at the position, the leaves being at height 0.
+func posHeight(pos uint64) uint64 {
+	pos++
+
+	for !allOnes(pos) {
+		pos = jumpLeft(pos)
+	}
+
+	return uint64(bits.Len64(pos)) - 1
+}
+
+// allOnes returns whether all the significant bits of n are set.
+func allOnes(n uint64) bool {
+	return n != 0 && bits.OnesCount64(n) == bits.Len64(n)
+}
+
+// jumpLeft returns the position, counted from 1, of the node at the same height in the leftmost tree.
+func jumpLeft(pos uint64) uint64 {
+	msb := uint64(1) << (bits.Len64(pos) - 1)
+
+	return pos - (msb - 1)
+}
+
+// siblingOffset returns the distance between a node at the height and its sibling.
+func siblingOffset(height uint64) uint64 {
+	return (2 << height) - 1
+}
+
+// parentOffset returns the distance between a left node at the height and its parent.
+func parentOffset(height uint64) uint64 {
+	return 2 << height
+}
+
+// Peaks returns the positions of the peaks of the MMR with size nodes, from left to right.
+func Peaks(size uint64) []uint64 {
+	if size == 0 {
+		return nil
+	}
+
+	var (
+		res      []uint64
+		pos      = size
+		peakSize = ^uint64(0) >> bits.LeadingZeros64(size)
+		sum      uint64
+	)
+
+	for peakSize > 0 {
+		if pos >= peakSize {
+			pos -= peakSize
+			res = append(res, sum+peakSize-1)
+			sum += peakSize
+		}
+
+		peakSize >>= 1
+	}
+
+	return res
+}
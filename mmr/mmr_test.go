@@ -0,0 +1,50 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMMRSize(t *testing.T) {
+	for leafCount, size := range map[uint64]uint64{0: 0, 1: 1, 2: 3, 3: 4, 4: 7, 5: 8, 6: 10, 7: 11, 8: 15, 11: 19} {
+		assert.Equal(t, size, Size(leafCount), "%d leaves", leafCount)
+	}
+}
+
+func TestLeafIndexToPos(t *testing.T) {
+	for index, pos := range []uint64{0, 1, 3, 4, 7, 8, 10, 11, 15, 16, 18} {
+		assert.Equal(t, pos, LeafIndexToPos(uint64(index)), "leaf %d", index)
+	}
+}
+
+func TestPosHeight(t *testing.T) {
+	for pos, height := range []uint64{0, 0, 1, 0, 0, 1, 2, 0, 0, 1, 0, 0, 1, 2, 3, 0} {
+		assert.Equal(t, height, posHeight(uint64(pos)), "position %d", pos)
+	}
+}
+
+func TestPeaks(t *testing.T) {
+	assert.Empty(t, Peaks(0))
+	assert.Equal(t, []uint64{0}, Peaks(1))
+	assert.Equal(t, []uint64{2}, Peaks(3))
+	assert.Equal(t, []uint64{2, 3}, Peaks(4))
+	assert.Equal(t, []uint64{6, 9}, Peaks(10))
+	assert.Equal(t, []uint64{14, 17, 18}, Peaks(19))
+}
@@ -0,0 +1,246 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// VerifyProof verifies that the MMR with the root contains the leaf with the hash at the index of the proof.
+func VerifyProof(root types.H256, leafHash types.H256, proof types.MMRProof) error {
+	return VerifyBatchProof(root, []types.H256{leafHash}, types.MMRBatchProof{
+		LeafIndices: []types.U64{proof.LeafIndex},
+		LeafCount:   proof.LeafCount,
+		Items:       proof.Items,
+	})
+}
+
+// VerifyLeavesProof verifies that the MMR with the root contains the leaves of the proof, returned by
+// mmr_generateProof.
+func VerifyLeavesProof(root types.H256, proof types.MMRLeavesProof) error {
+	leafHashes := make([]types.H256, 0, len(proof.Leaves))
+
+	for _, leaf := range proof.Leaves {
+		leafHashes = append(leafHashes, HashOpaqueLeaf(leaf))
+	}
+
+	return VerifyBatchProof(root, leafHashes, proof.Proof)
+}
+
+// VerifyBatchProof verifies that the MMR with the root contains the leaves with the hashes, at the indices of the
+// proof in the same order.
+func VerifyBatchProof(root types.H256, leafHashes []types.H256, proof types.MMRBatchProof) error {
+	if len(leafHashes) == 0 || len(leafHashes) != len(proof.LeafIndices) {
+		return ErrInvalidProof.WithMsg("%d leaves for %d leaf indices", len(leafHashes), len(proof.LeafIndices))
+	}
+
+	leaves := make([]node, 0, len(leafHashes))
+	positions := make(map[uint64]struct{}, len(leafHashes))
+
+	for i, index := range proof.LeafIndices {
+		if index >= proof.LeafCount {
+			return ErrInvalidProof.WithMsg("leaf index %d out of %d leaves", index, proof.LeafCount)
+		}
+
+		pos := LeafIndexToPos(uint64(index))
+
+		if _, ok := positions[pos]; ok {
+			return ErrInvalidProof.WithMsg("duplicate leaf index %d", index)
+		}
+
+		positions[pos] = struct{}{}
+		leaves = append(leaves, node{pos: pos, hash: leafHashes[i]})
+	}
+
+	computed, err := calculateRoot(leaves, Size(uint64(proof.LeafCount)), proof.Items)
+	if err != nil {
+		return err
+	}
+
+	if computed != root {
+		return ErrRootMismatch.WithMsg("expected %#x, got %#x", root, computed)
+	}
+
+	return nil
+}
+
+// node is a node of the MMR at its position.
+type node struct {
+	pos  uint64
+	hash types.H256
+}
+
+// proofItems iterates over the items of a proof.
+type proofItems struct {
+	items []types.H256
+}
+
+func (p *proofItems) next() (types.H256, bool) {
+	if len(p.items) == 0 {
+		return types.H256{}, false
+	}
+
+	item := p.items[0]
+	p.items = p.items[1:]
+
+	return item, true
+}
+
+// calculateRoot returns the root of the MMR with size nodes from the proven nodes and the items of their proof.
+func calculateRoot(nodes []node, size uint64, items []types.H256) (types.H256, error) {
+	peakHashes, err := calculatePeakHashes(nodes, size, &proofItems{items})
+	if err != nil {
+		return types.H256{}, err
+	}
+
+	root, ok := bagPeaks(peakHashes)
+	if !ok {
+		return types.H256{}, ErrInvalidProof.WithMsg("no peaks")
+	}
+
+	return root, nil
+}
+
+// calculatePeakHashes returns the hashes of the peaks of the MMR, the peaks without any proven node being taken
+// from the proof. The peaks at the right of the last proven node may be bagged in a single item of the proof.
+func calculatePeakHashes(nodes []node, size uint64, items *proofItems) ([]types.H256, error) {
+	nodes = append([]node{}, nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].pos < nodes[j].pos })
+
+	var peakHashes []types.H256
+
+	for _, peakPos := range Peaks(size) {
+		var peakNodes []node
+
+		for len(nodes) > 0 && nodes[0].pos <= peakPos {
+			peakNodes, nodes = append(peakNodes, nodes[0]), nodes[1:]
+		}
+
+		switch {
+		case len(peakNodes) == 1 && peakNodes[0].pos == peakPos:
+			peakHashes = append(peakHashes, peakNodes[0].hash)
+		case len(peakNodes) == 0:
+			item, ok := items.next()
+			if !ok {
+				// The remaining peaks are bagged, or the proof is incomplete, which the root does not match.
+				return peakHashes, checkRemaining(nodes, items)
+			}
+
+			peakHashes = append(peakHashes, item)
+		default:
+			peakHash, err := calculatePeakRoot(peakNodes, peakPos, items)
+			if err != nil {
+				return nil, err
+			}
+
+			peakHashes = append(peakHashes, peakHash)
+		}
+	}
+
+	// The peaks at the right of the proven nodes may be bagged in a single item.
+	if item, ok := items.next(); ok {
+		peakHashes = append(peakHashes, item)
+	}
+
+	return peakHashes, checkRemaining(nodes, items)
+}
+
+// checkRemaining returns an error if there are nodes that do not belong to any peak, or unused proof items.
+func checkRemaining(nodes []node, items *proofItems) error {
+	if len(nodes) > 0 {
+		return ErrInvalidProof.WithMsg("node at position %d is out of the MMR", nodes[0].pos)
+	}
+
+	if len(items.items) > 0 {
+		return ErrInvalidProof.WithMsg("%d unused proof items", len(items.items))
+	}
+
+	return nil
+}
+
+// calculatePeakRoot returns the hash of the peak from the proven nodes below it, merging each node with its
+// sibling, taken from the proven nodes or from the proof, up to the peak.
+func calculatePeakRoot(nodes []node, peakPos uint64, items *proofItems) (types.H256, error) {
+	type queued struct {
+		node
+		height uint64
+	}
+
+	queue := make([]queued, 0, len(nodes))
+	for _, n := range nodes {
+		queue = append(queue, queued{node: n})
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.pos == peakPos {
+			if len(queue) > 0 {
+				return types.H256{}, ErrInvalidProof.WithMsg("nodes above the peak at position %d", peakPos)
+			}
+
+			return current.hash, nil
+		}
+
+		// The sibling is taken from the queue if it is the next proven node, or from the proof.
+		sibling := func(pos uint64) (types.H256, error) {
+			if len(queue) > 0 && queue[0].pos == pos {
+				hash := queue[0].hash
+				queue = queue[1:]
+
+				return hash, nil
+			}
+
+			item, ok := items.next()
+			if !ok {
+				return types.H256{}, ErrInvalidProof.WithMsg("missing sibling of the node at position %d", current.pos)
+			}
+
+			return item, nil
+		}
+
+		var parent node
+
+		if posHeight(current.pos+1) > current.height {
+			// The node is a right child, whose parent follows it.
+			siblingHash, err := sibling(current.pos - siblingOffset(current.height))
+			if err != nil {
+				return types.H256{}, err
+			}
+
+			parent = node{pos: current.pos + 1, hash: merge(siblingHash, current.hash)}
+		} else {
+			siblingHash, err := sibling(current.pos + siblingOffset(current.height))
+			if err != nil {
+				return types.H256{}, err
+			}
+
+			parent = node{pos: current.pos + parentOffset(current.height), hash: merge(current.hash, siblingHash)}
+		}
+
+		if parent.pos > peakPos {
+			return types.H256{}, ErrInvalidProof.WithMsg("node at position %d is above the peak", parent.pos)
+		}
+
+		queue = append(queue, queued{node: parent, height: current.height + 1})
+	}
+
+	return types.H256{}, ErrInvalidProof.WithMsg("no nodes under the peak at position %d", peakPos)
+}
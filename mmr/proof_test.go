@@ -0,0 +1,211 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func TestVerifyProof(t *testing.T) {
+	for leafCount := uint64(1); leafCount <= 20; leafCount++ {
+		tree := newTestTree(leafCount)
+		root := testTreeRoot(t, tree)
+
+		for index := uint64(0); index < leafCount; index++ {
+			batchProof, err := tree.Proof(index)
+			require.NoError(t, err)
+
+			proof := types.MMRProof{LeafIndex: types.U64(index), LeafCount: types.U64(leafCount), Items: batchProof.Items}
+
+			assert.NoError(t, VerifyProof(root, testLeafHash(index), proof), "leaf %d of %d", index, leafCount)
+
+			err = VerifyProof(root, testLeafHash(index+1), proof)
+			assert.ErrorIs(t, err, ErrRootMismatch, "leaf %d of %d", index, leafCount)
+		}
+	}
+}
+
+func TestVerifyProof_Invalid(t *testing.T) {
+	tree := newTestTree(11)
+	root := testTreeRoot(t, tree)
+
+	batchProof, err := tree.Proof(4)
+	require.NoError(t, err)
+
+	proof := types.MMRProof{LeafIndex: 4, LeafCount: 11, Items: batchProof.Items}
+	require.NoError(t, VerifyProof(root, testLeafHash(4), proof))
+
+	invalid := proof
+	invalid.Items = append(invalid.Items, types.H256{})
+	assert.Error(t, VerifyProof(root, testLeafHash(4), invalid))
+
+	invalid = proof
+	invalid.Items = proof.Items[:1]
+	assert.Error(t, VerifyProof(root, testLeafHash(4), invalid))
+
+	invalid = proof
+	invalid.LeafIndex = 11
+	assert.ErrorIs(t, VerifyProof(root, testLeafHash(4), invalid), ErrInvalidProof)
+
+	invalid = proof
+	invalid.LeafCount = 5
+	assert.ErrorIs(t, VerifyProof(root, testLeafHash(4), invalid), ErrInvalidProof)
+}
+
+func TestVerifyProof_GenerateProofResponse(t *testing.T) {
+	// The leaf and the proof of leaf #2000 of 2265, returned by mmr_generateProof on a development chain.
+	var leaf types.MMREncodableOpaqueLeaf
+	err := codec.DecodeFromHex("0xc50100d007000024724d3186265a4c1da058d532f7e806cb7b6d0c5be6eecf753f35addc53b54601000000000000000300000042b63941ec636f52303b3c33f53349830d8a466e9456d25d22b28f4bb0ad03650000000000000000000000000000000000000000000000000000000000000000", &leaf) //nolint:lll
+	require.NoError(t, err)
+
+	var proof types.MMRProof
+	err = codec.DecodeFromHex("0xd007000000000000d90800000000000030ef3c78f3febfafa319c77aadb0801099da03a775898f486392106a15688c0354c71aed1edc46767357f1f6dd8701f7974dd2ae39e6f178f9de1f6b8402683ef78f2048bf973a2f8b852a4b4997d0b8637111641d88a5ae8670260417a1f4ccfc2a5a56bc7517b04d9931c82a24660c8fa86fd33611b6964c3201669537d7851a17f814e37d71841d69412816692a8c3b6615572b799674c9b6879e547b1f6511af7be87ea122cb70f6da8718cb017acf56c0dc8fb0f093ed300dbbef98d632329b93aa08f39a2fd0cf2e51c12f8b0370b9f3e50d3395a20017e3f24991c9b603951ed76df7fad6c19cade303795345a7a96729fa337ae82d74b03f2b039ee5e3b0c34f63d8f8e43a0db754f4c0f42dc84d98a26c51edea931971206398a80f5ebee8c4ab19a7358adc89d2c4258fb61d979517d363ee33086cc5a9e63c7eed27674f1ca1d3585eb77c1c281ff21057dfdf89227dbe9f6a522753d26cfcf2f271e27f93422d112ef763d502970da640b416c1d3a04c9373b10beef676068ceeec", &proof) //nolint:lll
+	require.NoError(t, err)
+	require.Len(t, proof.Items, 12)
+
+	leafHash := HashOpaqueLeaf(leaf)
+
+	// The response does not hold the root of the MMR. The first peak, of 2048 leaves, is computed from the leaf and
+	// the first eleven items, and the last item bags the peaks at its right.
+	peakHashes, err := calculatePeakHashes(
+		[]node{{pos: LeafIndexToPos(2000), hash: leafHash}},
+		Size(2265),
+		&proofItems{proof.Items},
+	)
+	require.NoError(t, err)
+	require.Len(t, peakHashes, 2)
+	assert.Equal(t, proof.Items[11], peakHashes[1])
+
+	root, ok := bagPeaks(peakHashes)
+	require.True(t, ok)
+
+	assert.NoError(t, VerifyProof(root, leafHash, proof))
+	assert.ErrorIs(t, VerifyProof(root, HashOpaqueLeaf(leaf[1:]), proof), ErrRootMismatch)
+
+	invalid := proof
+	invalid.Items = proof.Items[:11]
+	assert.Error(t, VerifyProof(root, leafHash, invalid))
+
+	invalid.Items = proof.Items[1:]
+	assert.Error(t, VerifyProof(root, leafHash, invalid))
+}
+
+func TestVerifyBatchProof(t *testing.T) {
+	for leafCount := uint64(1); leafCount <= 15; leafCount++ {
+		tree := newTestTree(leafCount)
+		root := testTreeRoot(t, tree)
+
+		for first := uint64(0); first < leafCount; first++ {
+			for second := first + 1; second < leafCount; second++ {
+				indices := []uint64{second, first}
+				if third := (first + second + 1) % leafCount; third != first && third != second {
+					indices = append(indices, third)
+				}
+
+				proof, err := tree.Proof(indices...)
+				require.NoError(t, err)
+
+				var leafHashes []types.H256
+				for _, index := range indices {
+					leafHashes = append(leafHashes, testLeafHash(index))
+				}
+
+				assert.NoError(t, VerifyBatchProof(root, leafHashes, proof), "leaves %v of %d", indices, leafCount)
+
+				leafHashes[0], leafHashes[1] = leafHashes[1], leafHashes[0]
+				assert.Error(t, VerifyBatchProof(root, leafHashes, proof), "leaves %v of %d", indices, leafCount)
+			}
+		}
+	}
+}
+
+func TestVerifyBatchProof_Invalid(t *testing.T) {
+	tree := newTestTree(7)
+	root := testTreeRoot(t, tree)
+
+	proof, err := tree.Proof(1, 5)
+	require.NoError(t, err)
+
+	leafHashes := []types.H256{testLeafHash(1), testLeafHash(5)}
+	require.NoError(t, VerifyBatchProof(root, leafHashes, proof))
+
+	assert.ErrorIs(t, VerifyBatchProof(root, leafHashes[:1], proof), ErrInvalidProof)
+	assert.ErrorIs(t, VerifyBatchProof(root, nil, types.MMRBatchProof{LeafCount: 7}), ErrInvalidProof)
+
+	duplicate := proof
+	duplicate.LeafIndices = []types.U64{1, 1}
+	assert.ErrorIs(t, VerifyBatchProof(root, leafHashes, duplicate), ErrInvalidProof)
+}
+
+func TestVerifyLeavesProof(t *testing.T) {
+	leaves := []types.MMREncodableOpaqueLeaf{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}}
+
+	tree := NewTree()
+	for _, leaf := range leaves {
+		tree.Append(HashOpaqueLeaf(leaf))
+	}
+
+	root := testTreeRoot(t, tree)
+
+	proof, err := tree.Proof(3, 0)
+	require.NoError(t, err)
+
+	leavesProof := types.MMRLeavesProof{Leaves: []types.MMREncodableOpaqueLeaf{leaves[3], leaves[0]}, Proof: proof}
+	assert.NoError(t, VerifyLeavesProof(root, leavesProof))
+
+	leavesProof.Leaves[1] = leaves[1]
+	assert.ErrorIs(t, VerifyLeavesProof(root, leavesProof), ErrRootMismatch)
+}
+
+func TestHashLeaf(t *testing.T) {
+	leaf := types.MMRLeaf{Version: 1}
+
+	hash, err := HashLeaf(leaf)
+	assert.NoError(t, err)
+
+	enc, err := codec.Encode(leaf)
+	require.NoError(t, err)
+	assert.Equal(t, HashOpaqueLeaf(enc), hash)
+}
+
+func testLeafHash(index uint64) types.H256 {
+	return HashOpaqueLeaf([]byte(fmt.Sprintf("leaf %d", index)))
+}
+
+func newTestTree(leafCount uint64) *Tree {
+	tree := NewTree()
+
+	for i := uint64(0); i < leafCount; i++ {
+		tree.Append(testLeafHash(i))
+	}
+
+	return tree
+}
+
+func testTreeRoot(t *testing.T, tree *Tree) types.H256 {
+	root, err := tree.Root()
+	require.NoError(t, err)
+
+	return root
+}
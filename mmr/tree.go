@@ -0,0 +1,177 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Tree is an in-memory MMR holding the hashes of all its nodes, which can generate the proofs of its leaves.
+type Tree struct {
+	nodes     []types.H256
+	leafCount uint64
+}
+
+// NewTree creates a new empty Tree.
+func NewTree() *Tree {
+	return &Tree{}
+}
+
+// Append appends the leaf with the hash, and the parents of the subtrees it completes. It returns the index of the
+// leaf.
+func (t *Tree) Append(leafHash types.H256) uint64 {
+	pos := uint64(len(t.nodes))
+	t.nodes = append(t.nodes, leafHash)
+
+	for height := uint64(0); posHeight(pos+1) > height; height++ {
+		pos++
+
+		left := pos - parentOffset(height)
+		right := left + siblingOffset(height)
+
+		t.nodes = append(t.nodes, merge(t.nodes[left], t.nodes[right]))
+	}
+
+	t.leafCount++
+
+	return t.leafCount - 1
+}
+
+// LeafCount returns the number of leaves of the MMR.
+func (t *Tree) LeafCount() uint64 {
+	return t.leafCount
+}
+
+// Root returns the root of the MMR, which is the hash of its peaks bagged from right to left.
+func (t *Tree) Root() (types.H256, error) {
+	var peakHashes []types.H256
+
+	for _, pos := range Peaks(uint64(len(t.nodes))) {
+		peakHashes = append(peakHashes, t.nodes[pos])
+	}
+
+	root, ok := bagPeaks(peakHashes)
+	if !ok {
+		return types.H256{}, ErrEmptyTree
+	}
+
+	return root, nil
+}
+
+// Proof returns the proof of the leaves with the indices, which can be verified with VerifyBatchProof.
+func (t *Tree) Proof(leafIndices ...uint64) (types.MMRBatchProof, error) {
+	proof := types.MMRBatchProof{LeafCount: types.U64(t.leafCount)}
+
+	if len(leafIndices) == 0 {
+		return proof, ErrInvalidLeaf.WithMsg("no leaves")
+	}
+
+	positions := make([]uint64, 0, len(leafIndices))
+
+	for _, index := range leafIndices {
+		if index >= t.leafCount {
+			return proof, ErrInvalidLeaf.WithMsg("leaf index %d out of %d leaves", index, t.leafCount)
+		}
+
+		positions = append(positions, LeafIndexToPos(index))
+		proof.LeafIndices = append(proof.LeafIndices, types.U64(index))
+	}
+
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+
+	for i := 1; i < len(positions); i++ {
+		if positions[i] == positions[i-1] {
+			return proof, ErrInvalidLeaf.WithMsg("duplicate leaf at position %d", positions[i])
+		}
+	}
+
+	// The number of peaks at the right of the last proven leaf, which are bagged in a single item.
+	var rightPeaks int
+
+	for _, peakPos := range Peaks(uint64(len(t.nodes))) {
+		var peakPositions []uint64
+
+		for len(positions) > 0 && positions[0] <= peakPos {
+			peakPositions, positions = append(peakPositions, positions[0]), positions[1:]
+		}
+
+		if len(peakPositions) == 0 {
+			rightPeaks++
+		} else {
+			rightPeaks = 0
+		}
+
+		proof.Items = t.appendPeakProof(proof.Items, peakPositions, peakPos)
+	}
+
+	if rightPeaks > 1 {
+		bagged, _ := bagPeaks(append([]types.H256{}, proof.Items[len(proof.Items)-rightPeaks:]...))
+		proof.Items = append(proof.Items[:len(proof.Items)-rightPeaks], bagged)
+	}
+
+	return proof, nil
+}
+
+// appendPeakProof appends the items proving the nodes at the positions under the peak, which are the siblings on
+// the paths from the nodes to the peak that cannot be computed from the nodes, or the peak itself if there are no
+// nodes under it.
+func (t *Tree) appendPeakProof(items []types.H256, positions []uint64, peakPos uint64) []types.H256 {
+	if len(positions) == 0 {
+		return append(items, t.nodes[peakPos])
+	}
+
+	type queued struct {
+		pos    uint64
+		height uint64
+	}
+
+	queue := make([]queued, 0, len(positions))
+	for _, pos := range positions {
+		queue = append(queue, queued{pos: pos})
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.pos == peakPos {
+			break
+		}
+
+		var siblingPos, parentPos uint64
+
+		if posHeight(current.pos+1) > current.height {
+			siblingPos, parentPos = current.pos-siblingOffset(current.height), current.pos+1
+		} else {
+			siblingPos, parentPos = current.pos+siblingOffset(current.height), current.pos+parentOffset(current.height)
+		}
+
+		if len(queue) > 0 && queue[0].pos == siblingPos {
+			queue = queue[1:]
+		} else {
+			items = append(items, t.nodes[siblingPos])
+		}
+
+		if parentPos <= peakPos {
+			queue = append(queue, queued{pos: parentPos, height: current.height + 1})
+		}
+	}
+
+	return items
+}
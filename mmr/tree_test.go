@@ -0,0 +1,67 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestTree_Root(t *testing.T) {
+	tree := NewTree()
+
+	_, err := tree.Root()
+	assert.ErrorIs(t, err, ErrEmptyTree)
+
+	h := []types.H256{testLeafHash(0), testLeafHash(1), testLeafHash(2)}
+
+	assert.Equal(t, uint64(0), tree.Append(h[0]))
+	assert.Equal(t, h[0], testTreeRoot(t, tree))
+
+	assert.Equal(t, uint64(1), tree.Append(h[1]))
+	assert.Equal(t, merge(h[0], h[1]), testTreeRoot(t, tree))
+
+	// The peaks are bagged from right to left.
+	assert.Equal(t, uint64(2), tree.Append(h[2]))
+	assert.Equal(t, merge(h[2], merge(h[0], h[1])), testTreeRoot(t, tree))
+	assert.Equal(t, uint64(3), tree.LeafCount())
+}
+
+func TestTree_Proof(t *testing.T) {
+	tree := newTestTree(11)
+
+	proof, err := tree.Proof(4)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.U64{4}, proof.LeafIndices)
+	assert.Equal(t, types.U64(11), proof.LeafCount)
+
+	// The siblings on the path to the first peak, followed by the bagged peaks at its right.
+	rightPeaks, _ := bagPeaks([]types.H256{tree.nodes[17], tree.nodes[18]})
+	assert.Equal(t, []types.H256{tree.nodes[8], tree.nodes[12], tree.nodes[6], rightPeaks}, proof.Items)
+
+	_, err = tree.Proof()
+	assert.ErrorIs(t, err, ErrInvalidLeaf)
+
+	_, err = tree.Proof(11)
+	assert.ErrorIs(t, err, ErrInvalidLeaf)
+
+	_, err = tree.Proof(2, 2)
+	assert.ErrorIs(t, err, ErrInvalidLeaf)
+}
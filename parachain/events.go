@@ -0,0 +1,169 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parachain
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	candidateBackedEventName   = "ParaInclusion.CandidateBacked"
+	candidateIncludedEventName = "ParaInclusion.CandidateIncluded"
+	candidateTimedOutEventName = "ParaInclusion.CandidateTimedOut"
+	disputeInitiatedEventName  = "ParasDisputes.DisputeInitiated"
+	disputeConcludedEventName  = "ParasDisputes.DisputeConcluded"
+	disputeTimedOutEventName   = "ParasDisputes.DisputeTimedOut"
+)
+
+// decodeEvent decodes the event parsed by the registry into T, the type of the event in the types package.
+//
+// The decoded fields of the event are encoded again following their types in the metadata, so the fields of T must
+// match the fields of the event, between the phase and the topics.
+func decodeEvent[T any](meta *types.Metadata, event *parser.Event) (T, error) {
+	var res T
+
+	phase := types.Phase{}
+
+	if event.Phase != nil {
+		phase = *event.Phase
+	}
+
+	b, err := codec.Encode(phase)
+	if err != nil {
+		return res, ErrEventsDecoding.Wrap(err)
+	}
+
+	for _, field := range event.Fields {
+		fieldBytes, err := encodeDecodedValue(meta, field.LookupIndex, field.Value)
+		if err != nil {
+			return res, ErrEventsDecoding.WithMsg("event '%s'", event.Name).Wrap(err)
+		}
+
+		b = append(b, fieldBytes...)
+	}
+
+	topics, err := codec.Encode(event.Topics)
+	if err != nil {
+		return res, ErrEventsDecoding.Wrap(err)
+	}
+
+	if err := codec.Decode(append(b, topics...), &res); err != nil {
+		return res, ErrEventsDecoding.WithMsg("event '%s'", event.Name).Wrap(err)
+	}
+
+	return res, nil
+}
+
+// encodeDecodedValue SCALE encodes a value decoded by the registry, using the type with the lookup index.
+//
+// The variants holding fields are not supported, since the registry does not keep their index.
+func encodeDecodedValue(meta *types.Metadata, lookupIndex int64, value any) ([]byte, error) {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[lookupIndex]
+	if !ok {
+		return nil, ErrEventFieldEncoding.WithMsg("type '%d' not found", lookupIndex)
+	}
+
+	def := typ.Def
+
+	switch {
+	case def.IsComposite, def.IsTuple:
+		if value == nil {
+			return nil, nil
+		}
+
+		fields, ok := value.(registry.DecodedFields)
+		if !ok {
+			return nil, ErrEventFieldEncoding.WithMsg("type '%d' is not a composite", lookupIndex)
+		}
+
+		var b []byte
+
+		for _, field := range fields {
+			fieldBytes, err := encodeDecodedValue(meta, field.LookupIndex, field.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			b = append(b, fieldBytes...)
+		}
+
+		return b, nil
+	case def.IsSequence, def.IsArray:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, ErrEventFieldEncoding.WithMsg("type '%d' is not a sequence", lookupIndex)
+		}
+
+		var (
+			b        []byte
+			itemType = def.Array.Type
+		)
+
+		if def.IsSequence {
+			itemType = def.Sequence.Type
+
+			lenBytes, err := codec.Encode(types.NewUCompactFromUInt(uint64(len(items))))
+			if err != nil {
+				return nil, ErrEventFieldEncoding.Wrap(err)
+			}
+
+			b = lenBytes
+		}
+
+		for _, item := range items {
+			itemBytes, err := encodeDecodedValue(meta, itemType.Int64(), item)
+			if err != nil {
+				return nil, err
+			}
+
+			b = append(b, itemBytes...)
+		}
+
+		return b, nil
+	case def.IsVariant:
+		index, ok := value.(byte)
+		if !ok {
+			return nil, ErrEventFieldEncoding.WithMsg("variant of type '%d' holds fields", lookupIndex)
+		}
+
+		return []byte{index}, nil
+	case def.IsPrimitive:
+		b, err := codec.Encode(value)
+		if err != nil {
+			return nil, ErrEventFieldEncoding.Wrap(err)
+		}
+
+		return b, nil
+	case def.IsCompact:
+		compact, ok := value.(types.UCompact)
+		if !ok {
+			return nil, ErrEventFieldEncoding.WithMsg("compact of type '%d' is not a number", lookupIndex)
+		}
+
+		b, err := codec.Encode(compact)
+		if err != nil {
+			return nil, ErrEventFieldEncoding.Wrap(err)
+		}
+
+		return b, nil
+	default:
+		return nil, ErrEventFieldEncoding.WithMsg("type '%d' is not supported", lookupIndex)
+	}
+}
@@ -0,0 +1,169 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parachain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+//go:generate mockery --name BlockFetcher --structname BlockFetcherMock --filename block_fetcher_mock.go --inpackage
+
+// BlockFetcher is the interface used for retrieving the relay chain blocks imported by an InclusionTracker.
+type BlockFetcher interface {
+	// FetchBlock returns the events of the block and the head of the parachain after the block.
+	FetchBlock(ctx context.Context, header types.Header, paraID types.ParachainID) (*RelayBlock, error)
+	// FetchHeader returns the header of the block.
+	FetchHeader(ctx context.Context, blockHash types.Hash) (*types.Header, error)
+}
+
+// blockFetcher implements the BlockFetcher interface. The events are retrieved with a retriever.EventRetriever, and
+// the metadata is cached by spec version.
+type blockFetcher struct {
+	chainRPC chain.Chain
+	stateRPC state.State
+
+	eventRetriever retriever.EventRetriever
+
+	specVersion types.U32
+	meta        *types.Metadata
+}
+
+// NewBlockFetcher creates a new BlockFetcher.
+func NewBlockFetcher(
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	eventRetriever retriever.EventRetriever,
+) BlockFetcher {
+	return &blockFetcher{chainRPC: chainRPC, stateRPC: stateRPC, eventRetriever: eventRetriever}
+}
+
+// NewDefaultBlockFetcher creates a new BlockFetcher that uses the default retriever.EventRetriever.
+func NewDefaultBlockFetcher(
+	ctx context.Context,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	fieldOverrides ...registry.FieldOverride,
+) (BlockFetcher, error) {
+	eventRetriever, err := retriever.NewDefaultEventRetriever(
+		ctx,
+		regState.NewEventProvider(stateRPC),
+		stateRPC,
+		fieldOverrides...,
+	)
+
+	if err != nil {
+		return nil, ErrEventRetrieverCreation.Wrap(err)
+	}
+
+	return NewBlockFetcher(chainRPC, stateRPC, eventRetriever), nil
+}
+
+const (
+	parasModule = "Paras"
+	headsMethod = "Heads"
+)
+
+func (f *blockFetcher) FetchHeader(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	header, err := f.chainRPC.GetHeader(ctx, blockHash)
+	if err != nil {
+		return nil, ErrHeaderRetrieval.Wrap(err)
+	}
+
+	return header, nil
+}
+
+func (f *blockFetcher) FetchBlock(
+	ctx context.Context,
+	header types.Header,
+	paraID types.ParachainID,
+) (*RelayBlock, error) {
+	blockHash, err := header.Hash()
+	if err != nil {
+		return nil, ErrHashing.Wrap(err)
+	}
+
+	meta, err := f.metadata(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := f.eventRetriever.GetEvents(ctx, blockHash)
+	if err != nil {
+		return nil, ErrEventsRetrieval.Wrap(err)
+	}
+
+	encodedParaID, err := codec.Encode(paraID)
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	headKey, err := types.CreateStorageKey(meta, parasModule, headsMethod, encodedParaID)
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	rawHead, err := f.stateRPC.GetStorageRaw(ctx, headKey, blockHash)
+	if err != nil {
+		return nil, ErrStorageRetrieval.Wrap(err)
+	}
+
+	var head *types.HeadData
+
+	if len(*rawHead) > 0 {
+		head = new(types.HeadData)
+
+		if err := codec.Decode(*rawHead, head); err != nil {
+			return nil, ErrHeadDecoding.Wrap(err)
+		}
+	}
+
+	block, err := NewRelayBlock(BlockRef{Hash: blockHash, Number: header.Number}, meta, events, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// metadata returns the metadata at the block, which is retrieved again when the spec version changes.
+func (f *blockFetcher) metadata(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	version, err := f.stateRPC.GetRuntimeVersion(ctx, blockHash)
+	if err != nil {
+		return nil, ErrRuntimeVersionRetrieval.Wrap(err)
+	}
+
+	if f.meta != nil && version.SpecVersion == f.specVersion {
+		return f.meta, nil
+	}
+
+	meta, err := f.stateRPC.GetMetadata(ctx, blockHash)
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	f.meta, f.specVersion = meta, version.SpecVersion
+
+	return meta, nil
+}
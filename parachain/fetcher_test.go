@@ -0,0 +1,226 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parachain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func findTestEventID(t *testing.T, meta *types.Metadata, module, event string) types.EventID {
+	for i := 0; i < 256; i++ {
+		for j := 0; j < 256; j++ {
+			id := types.EventID{byte(i), byte(j)}
+
+			m, e, err := meta.FindEventNamesForEventID(id)
+			if err == nil && string(m) == module && string(e) == event {
+				return id
+			}
+		}
+	}
+
+	require.FailNow(t, "event not found", "%s.%s", module, event)
+
+	return types.EventID{}
+}
+
+// encodeTestEvents encodes the events as stored in System.Events, applied during the first extrinsic.
+func encodeTestEvents(t *testing.T, events ...interface{}) types.StorageDataRaw {
+	enc, err := codec.Encode(types.NewUCompactFromUInt(uint64(len(events))))
+	require.NoError(t, err)
+
+	for _, event := range events {
+		b, err := codec.Encode(event)
+		require.NoError(t, err)
+
+		enc = append(enc, b...)
+	}
+
+	return enc
+}
+
+// parseTestEvents parses the events encoded by encodeTestEvents with the registry, as the retriever.EventRetriever
+// does.
+func parseTestEvents(t *testing.T, meta *types.Metadata, rawEvents types.StorageDataRaw) []*parser.Event {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	require.NoError(t, err)
+
+	events, err := parser.NewEventParser().ParseEvents(eventRegistry, &rawEvents)
+	require.NoError(t, err)
+
+	return events
+}
+
+type testEvent[T any] struct {
+	Phase   types.Phase
+	EventID types.EventID
+	Fields  T
+	Topics  []types.Hash
+}
+
+type testCandidateEventFields struct {
+	CandidateReceipt types.CandidateReceipt
+	HeadData         types.HeadData
+	CoreIndex        types.CoreIndex
+	GroupIndex       types.GroupIndex
+}
+
+func TestBlockFetcher(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+	stateRPCMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	fetcher := NewBlockFetcher(chainRPCMock, stateRPCMock, eventRetrieverMock)
+
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	header := types.Header{ParentHash: types.NewHash([]byte{0x01}), Number: 10}
+
+	blockHash, err := header.Hash()
+	require.NoError(t, err)
+
+	chainRPCMock.On("GetHeader", mock.Anything, blockHash).Return(&header, nil).Once()
+
+	res, err := fetcher.FetchHeader(context.Background(), blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &header, res)
+
+	chainRPCMock.On("GetHeader", mock.Anything, blockHash).Return(nil, errors.New("error")).Once()
+
+	_, err = fetcher.FetchHeader(context.Background(), blockHash)
+	assert.ErrorIs(t, err, ErrHeaderRetrieval)
+
+	receipt := testReceipt(testParaID, 0xa1)
+	candidateHash := testCandidateHash(t, receipt)
+	head := types.HeadData{0x01, 0x02}
+
+	rawEvents := encodeTestEvents(
+		t,
+		testEvent[testCandidateEventFields]{
+			Phase:   types.Phase{IsApplyExtrinsic: true},
+			EventID: findTestEventID(t, &meta, "ParaInclusion", "CandidateBacked"),
+			Fields:  testCandidateEventFields{CandidateReceipt: receipt, HeadData: head, CoreIndex: 1, GroupIndex: 2},
+		},
+		testEvent[types.Hash]{
+			Phase:   types.Phase{IsApplyExtrinsic: true},
+			EventID: findTestEventID(t, &meta, "ParasDisputes", "DisputeTimedOut"),
+			Fields:  candidateHash,
+		},
+	)
+
+	encodedParaID, err := codec.Encode(testParaID)
+	require.NoError(t, err)
+
+	headKey, err := types.CreateStorageKey(&meta, parasModule, headsMethod, encodedParaID)
+	require.NoError(t, err)
+
+	encodedHead, err := codec.Encode(head)
+	require.NoError(t, err)
+
+	rawHead := types.StorageDataRaw(encodedHead)
+
+	stateRPCMock.On("GetRuntimeVersion", mock.Anything, blockHash).
+		Return(&types.RuntimeVersion{SpecVersion: 9180}, nil).
+		Twice()
+	// The metadata is retrieved once for the spec version.
+	stateRPCMock.On("GetMetadata", mock.Anything, blockHash).Return(&meta, nil).Once()
+	eventRetrieverMock.On("GetEvents", mock.Anything, blockHash).Return(parseTestEvents(t, &meta, rawEvents), nil).Twice()
+	stateRPCMock.On("GetStorageRaw", mock.Anything, headKey, blockHash).Return(&rawHead, nil).Once()
+	stateRPCMock.On("GetStorageRaw", mock.Anything, headKey, blockHash).Return(&types.StorageDataRaw{}, nil).Once()
+
+	block, err := fetcher.FetchBlock(context.Background(), header, testParaID)
+	assert.NoError(t, err)
+	assert.Equal(t, BlockRef{Hash: blockHash, Number: 10}, block.BlockRef)
+	require.Len(t, block.CandidateBacked, 1)
+	assert.Equal(t, receipt, block.CandidateBacked[0].CandidateReceipt)
+	assert.Equal(t, head, block.CandidateBacked[0].HeadData)
+	require.Len(t, block.DisputeTimedOut, 1)
+	assert.Equal(t, candidateHash, block.DisputeTimedOut[0].CandidateHash)
+	assert.Equal(t, &head, block.Head)
+
+	block, err = fetcher.FetchBlock(context.Background(), header, testParaID)
+	assert.NoError(t, err)
+	assert.Nil(t, block.Head)
+}
+
+func TestBlockFetcher_Errors(t *testing.T) {
+	stateRPCMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	fetcher := NewBlockFetcher(chainMocks.NewChain(t), stateRPCMock, eventRetrieverMock)
+
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	header := types.Header{Number: 10}
+
+	blockHash, err := header.Hash()
+	require.NoError(t, err)
+
+	stateRPCMock.On("GetRuntimeVersion", mock.Anything, blockHash).Return(nil, errors.New("error")).Once()
+
+	_, err = fetcher.FetchBlock(context.Background(), header, testParaID)
+	assert.ErrorIs(t, err, ErrRuntimeVersionRetrieval)
+
+	stateRPCMock.On("GetRuntimeVersion", mock.Anything, blockHash).Return(&types.RuntimeVersion{SpecVersion: 1}, nil)
+	stateRPCMock.On("GetMetadata", mock.Anything, blockHash).Return(nil, errors.New("error")).Once()
+
+	_, err = fetcher.FetchBlock(context.Background(), header, testParaID)
+	assert.ErrorIs(t, err, ErrMetadataRetrieval)
+
+	stateRPCMock.On("GetMetadata", mock.Anything, blockHash).Return(&meta, nil).Once()
+
+	eventRetrieverMock.On("GetEvents", mock.Anything, blockHash).Return(nil, errors.New("error")).Once()
+
+	_, err = fetcher.FetchBlock(context.Background(), header, testParaID)
+	assert.ErrorIs(t, err, ErrEventsRetrieval)
+
+	encodedParaID, err := codec.Encode(testParaID)
+	require.NoError(t, err)
+
+	headKey, err := types.CreateStorageKey(&meta, parasModule, headsMethod, encodedParaID)
+	require.NoError(t, err)
+
+	// The candidate hash is decoded as a sequence instead of a composite.
+	eventRetrieverMock.On("GetEvents", mock.Anything, blockHash).Return([]*parser.Event{
+		{
+			Name:   disputeTimedOutEventName,
+			Fields: registry.DecodedFields{{Value: []any{types.U8(1)}, LookupIndex: 114}},
+		},
+	}, nil).Once()
+	stateRPCMock.On("GetStorageRaw", mock.Anything, headKey, blockHash).Return(&types.StorageDataRaw{}, nil).Once()
+
+	_, err = fetcher.FetchBlock(context.Background(), header, testParaID)
+	assert.ErrorIs(t, err, ErrEventsDecoding)
+	assert.ErrorIs(t, err, ErrEventFieldEncoding)
+}
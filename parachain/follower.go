@@ -0,0 +1,97 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parachain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Follower imports the finalized relay chain blocks into an InclusionTracker.
+//
+// A Follower is not safe for concurrent use.
+type Follower struct {
+	chainRPC chain.Chain
+	fetcher  BlockFetcher
+	tracker  *InclusionTracker
+}
+
+// NewFollower creates a new Follower.
+func NewFollower(chainRPC chain.Chain, fetcher BlockFetcher, tracker *InclusionTracker) *Follower {
+	return &Follower{chainRPC: chainRPC, fetcher: fetcher, tracker: tracker}
+}
+
+// Run imports the finalized blocks until the context is done or an error occurs.
+func (f *Follower) Run(ctx context.Context) error {
+	sub, err := f.chainRPC.SubscribeFinalizedHeads(ctx)
+	if err != nil {
+		return ErrSubscription.Wrap(err)
+	}
+
+	defer sub.Unsubscribe()
+
+	for {
+		header, err := sub.Next(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := f.ImportFinalized(ctx, header); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportFinalized imports the finalized block, after the blocks finalized since the last imported block, which the
+// finalized heads notifications may skip. The headers of the skipped blocks are retrieved through the parent hashes.
+// The blocks that are not after the last imported block are ignored.
+func (f *Follower) ImportFinalized(ctx context.Context, header types.Header) error {
+	last, ok := f.tracker.LastBlock()
+	if ok && header.Number <= last.Number {
+		return nil
+	}
+
+	headers := []types.Header{header}
+
+	for ok && headers[0].Number > last.Number+1 {
+		parent, err := f.fetcher.FetchHeader(ctx, headers[0].ParentHash)
+		if err != nil {
+			return err
+		}
+
+		headers = append([]types.Header{*parent}, headers...)
+	}
+
+	if ok && headers[0].ParentHash != last.Hash {
+		return ErrBlockOrder.WithMsg("block #%d is not a descendant of block %s", header.Number, last.Hash.Hex())
+	}
+
+	for _, h := range headers {
+		block, err := f.fetcher.FetchBlock(ctx, h, f.tracker.ParaID())
+		if err != nil {
+			return err
+		}
+
+		if err := f.tracker.ImportBlock(*block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parachain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHeaders returns a chain of headers from the block 1.
+func newTestHeaders(t *testing.T, n int) []types.Header {
+	headers := make([]types.Header, n)
+
+	for i := range headers {
+		headers[i].Number = types.BlockNumber(i + 1)
+
+		if i > 0 {
+			parentHash, err := headers[i-1].Hash()
+			require.NoError(t, err)
+
+			headers[i].ParentHash = parentHash
+		}
+	}
+
+	return headers
+}
+
+func mockFetchBlock(t *testing.T, fetcherMock *BlockFetcherMock, header types.Header) {
+	blockHash, err := header.Hash()
+	require.NoError(t, err)
+
+	fetcherMock.On("FetchBlock", mock.Anything, header, testParaID).
+		Return(&RelayBlock{BlockRef: BlockRef{Hash: blockHash, Number: header.Number}}, nil).
+		Once()
+}
+
+func TestFollower_ImportFinalized(t *testing.T) {
+	fetcherMock := NewBlockFetcherMock(t)
+	tracker := NewInclusionTracker(testParaID)
+
+	follower := NewFollower(chainMocks.NewChain(t), fetcherMock, tracker)
+
+	headers := newTestHeaders(t, 4)
+
+	mockFetchBlock(t, fetcherMock, headers[0])
+
+	err := follower.ImportFinalized(context.Background(), headers[0])
+	assert.NoError(t, err)
+
+	// The blocks 2 and 3 are skipped by the notifications.
+	for _, header := range headers[1:3] {
+		hash, err := header.Hash()
+		require.NoError(t, err)
+
+		header := header
+
+		fetcherMock.On("FetchHeader", mock.Anything, hash).Return(&header, nil).Once()
+	}
+
+	for _, header := range headers[1:] {
+		mockFetchBlock(t, fetcherMock, header)
+	}
+
+	err = follower.ImportFinalized(context.Background(), headers[3])
+	assert.NoError(t, err)
+
+	last, ok := tracker.LastBlock()
+	assert.True(t, ok)
+	assert.Equal(t, types.BlockNumber(4), last.Number)
+
+	// The blocks that are already imported are ignored.
+	err = follower.ImportFinalized(context.Background(), headers[2])
+	assert.NoError(t, err)
+}
+
+func TestFollower_ImportFinalized_Errors(t *testing.T) {
+	fetcherMock := NewBlockFetcherMock(t)
+	tracker := NewInclusionTracker(testParaID)
+
+	follower := NewFollower(chainMocks.NewChain(t), fetcherMock, tracker)
+
+	headers := newTestHeaders(t, 3)
+
+	fetcherMock.On("FetchBlock", mock.Anything, headers[0], testParaID).Return(nil, errors.New("error")).Once()
+
+	err := follower.ImportFinalized(context.Background(), headers[0])
+	assert.EqualError(t, err, "error")
+
+	mockFetchBlock(t, fetcherMock, headers[0])
+
+	err = follower.ImportFinalized(context.Background(), headers[0])
+	require.NoError(t, err)
+
+	parentHash, err := headers[1].Hash()
+	require.NoError(t, err)
+
+	fetcherMock.On("FetchHeader", mock.Anything, parentHash).Return(nil, ErrHeaderRetrieval).Once()
+
+	err = follower.ImportFinalized(context.Background(), headers[2])
+	assert.ErrorIs(t, err, ErrHeaderRetrieval)
+
+	// The block 2 is not the child of the imported block 1.
+	fork := headers[1]
+	fork.ParentHash = types.NewHash([]byte{0xff})
+
+	err = follower.ImportFinalized(context.Background(), fork)
+	assert.ErrorIs(t, err, ErrBlockOrder)
+}
+
+func TestFollower_Run(t *testing.T) {
+	chainRPCMock := chainMocks.NewChain(t)
+
+	follower := NewFollower(chainRPCMock, NewBlockFetcherMock(t), NewInclusionTracker(testParaID))
+
+	chainRPCMock.On("SubscribeFinalizedHeads", mock.Anything).Return(nil, errors.New("error")).Once()
+
+	err := follower.Run(context.Background())
+	assert.ErrorIs(t, err, ErrSubscription)
+}
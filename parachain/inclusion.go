@@ -0,0 +1,445 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parachain follows the inclusion of the blocks of a parachain in the relay chain, from the events and the
+// storage of the relay chain blocks.
+package parachain
+
+import (
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// BlockRef is a relay chain block.
+type BlockRef struct {
+	Hash   types.Hash
+	Number types.BlockNumber
+}
+
+// RelayBlock holds the events of a relay chain block concerning the parachains, and the head of the tracked
+// parachain in the Paras.Heads storage after the block.
+type RelayBlock struct {
+	BlockRef
+
+	CandidateBacked   []types.EventParaInclusionCandidateBacked
+	CandidateIncluded []types.EventParaInclusionCandidateIncluded
+	CandidateTimedOut []types.EventParaInclusionCandidateTimedOut
+	DisputeInitiated  []types.EventParasDisputesDisputeInitiated
+	DisputeConcluded  []types.EventParasDisputesDisputeConcluded
+	DisputeTimedOut   []types.EventParasDisputesDisputeTimedOut
+
+	// Head is nil if the parachain has no head.
+	Head *types.HeadData
+}
+
+// NewRelayBlock creates a new RelayBlock from the events of the block, which are picked by name and decoded with the
+// metadata of the block.
+func NewRelayBlock(
+	ref BlockRef,
+	meta *types.Metadata,
+	events []*parser.Event,
+	head *types.HeadData,
+) (RelayBlock, error) {
+	block := RelayBlock{BlockRef: ref, Head: head}
+
+	for _, event := range events {
+		var err error
+
+		switch event.Name {
+		case candidateBackedEventName:
+			block.CandidateBacked, err = appendEvent(block.CandidateBacked, meta, event)
+		case candidateIncludedEventName:
+			block.CandidateIncluded, err = appendEvent(block.CandidateIncluded, meta, event)
+		case candidateTimedOutEventName:
+			block.CandidateTimedOut, err = appendEvent(block.CandidateTimedOut, meta, event)
+		case disputeInitiatedEventName:
+			block.DisputeInitiated, err = appendEvent(block.DisputeInitiated, meta, event)
+		case disputeConcludedEventName:
+			block.DisputeConcluded, err = appendEvent(block.DisputeConcluded, meta, event)
+		case disputeTimedOutEventName:
+			block.DisputeTimedOut, err = appendEvent(block.DisputeTimedOut, meta, event)
+		}
+
+		if err != nil {
+			return RelayBlock{}, err
+		}
+	}
+
+	return block, nil
+}
+
+func appendEvent[T any](events []T, meta *types.Metadata, event *parser.Event) ([]T, error) {
+	decoded, err := decodeEvent[T](meta, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(events, decoded), nil
+}
+
+// Candidate is a parachain block candidate, with the relay chain blocks where it was backed and included.
+type Candidate struct {
+	// ParaBlockHash is the hash of the parachain block, which is the hash of its head data.
+	ParaBlockHash types.Hash
+	// CandidateHash is the hash of the candidate receipt, which identifies the candidate in the disputes.
+	CandidateHash types.Hash
+	Receipt       types.CandidateReceipt
+
+	// Backed, Included and TimedOut are nil until the candidate is backed, included or timed out.
+	Backed   *BlockRef
+	Included *BlockRef
+	TimedOut *BlockRef
+}
+
+// InclusionLatency returns the number of relay chain blocks between the backing and the inclusion of the candidate,
+// and false if it was not backed and included yet.
+func (c Candidate) InclusionLatency() (uint32, bool) {
+	if c.Backed == nil || c.Included == nil {
+		return 0, false
+	}
+
+	return uint32(c.Included.Number - c.Backed.Number), true
+}
+
+// Dispute is a dispute concerning a candidate of the parachain.
+type Dispute struct {
+	CandidateHash types.Hash
+	ParaBlockHash types.Hash
+	Location      types.DisputeLocation
+
+	Initiated BlockRef
+	// Concluded and TimedOut are nil until the dispute concludes or times out.
+	Concluded *BlockRef
+	Result    *types.DisputeResult
+	TimedOut  *BlockRef
+}
+
+// InclusionTracker maps the blocks of a parachain to the relay chain blocks where they were backed and included,
+// and records the disputes concerning them.
+//
+// The relay chain blocks must be imported in order. The disputes are attributed to the parachain from the
+// candidates backed in the imported blocks, so the disputes of the candidates backed before the first imported block
+// are not recorded.
+//
+// The recorded candidates, disputes and heads are kept until they are pruned with Prune.
+//
+// An InclusionTracker is not safe for concurrent use.
+type InclusionTracker struct {
+	paraID types.ParachainID
+
+	last *BlockRef
+
+	candidates      map[types.Hash]*Candidate
+	candidateHashes map[types.Hash]types.Hash
+	disputes        map[types.Hash]*Dispute
+	heads           map[types.Hash]paraHead
+}
+
+// paraHead is the head of the parachain after a relay chain block.
+type paraHead struct {
+	relayBlockNumber types.BlockNumber
+	paraBlockHash    types.Hash
+}
+
+// NewInclusionTracker creates a new InclusionTracker for the parachain.
+func NewInclusionTracker(paraID types.ParachainID) *InclusionTracker {
+	return &InclusionTracker{
+		paraID:          paraID,
+		candidates:      make(map[types.Hash]*Candidate),
+		candidateHashes: make(map[types.Hash]types.Hash),
+		disputes:        make(map[types.Hash]*Dispute),
+		heads:           make(map[types.Hash]paraHead),
+	}
+}
+
+// ParaID returns the ID of the tracked parachain.
+func (t *InclusionTracker) ParaID() types.ParachainID {
+	return t.paraID
+}
+
+// LastBlock returns the last imported relay chain block, and false if no block was imported.
+func (t *InclusionTracker) LastBlock() (BlockRef, bool) {
+	if t.last == nil {
+		return BlockRef{}, false
+	}
+
+	return *t.last, true
+}
+
+// ImportBlock records the events of the relay chain block concerning the parachain. The block number must be higher
+// than the one of the previously imported block.
+func (t *InclusionTracker) ImportBlock(block RelayBlock) error {
+	if t.last != nil && block.Number <= t.last.Number {
+		return ErrBlockOrder.WithMsg("block #%d imported after block #%d", block.Number, t.last.Number)
+	}
+
+	ref := block.BlockRef
+
+	for _, event := range block.CandidateBacked {
+		candidate, err := t.candidate(event.CandidateReceipt)
+		if err != nil {
+			return err
+		}
+
+		if candidate != nil {
+			candidate.Backed = &ref
+		}
+	}
+
+	for _, event := range block.CandidateIncluded {
+		candidate, err := t.candidate(event.CandidateReceipt)
+		if err != nil {
+			return err
+		}
+
+		if candidate != nil {
+			candidate.Included = &ref
+		}
+	}
+
+	for _, event := range block.CandidateTimedOut {
+		candidate, err := t.candidate(event.CandidateReceipt)
+		if err != nil {
+			return err
+		}
+
+		if candidate != nil {
+			candidate.TimedOut = &ref
+		}
+	}
+
+	t.importDisputes(block)
+
+	if block.Head != nil {
+		paraBlockHash, err := ParaBlockHash(*block.Head)
+		if err != nil {
+			return err
+		}
+
+		t.heads[block.Hash] = paraHead{relayBlockNumber: block.Number, paraBlockHash: paraBlockHash}
+	}
+
+	t.last = &ref
+
+	return nil
+}
+
+// candidate returns the candidate of the receipt, which is created if it is not known yet, and nil if the receipt is
+// for another parachain.
+func (t *InclusionTracker) candidate(receipt types.CandidateReceipt) (*Candidate, error) {
+	if receipt.Descriptor.ParachainID != t.paraID {
+		return nil, nil
+	}
+
+	candidateHash, err := CandidateHash(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate, ok := t.candidates[candidateHash]; ok {
+		return candidate, nil
+	}
+
+	candidate := &Candidate{
+		ParaBlockHash: receipt.Descriptor.ParaHead,
+		CandidateHash: candidateHash,
+		Receipt:       receipt,
+	}
+
+	t.candidates[candidateHash] = candidate
+	t.candidateHashes[candidate.ParaBlockHash] = candidateHash
+
+	return candidate, nil
+}
+
+func (t *InclusionTracker) importDisputes(block RelayBlock) {
+	ref := block.BlockRef
+
+	for _, event := range block.DisputeInitiated {
+		candidate, ok := t.candidates[event.CandidateHash]
+		if !ok {
+			continue
+		}
+
+		t.disputes[event.CandidateHash] = &Dispute{
+			CandidateHash: event.CandidateHash,
+			ParaBlockHash: candidate.ParaBlockHash,
+			Location:      event.DisputeLocation,
+			Initiated:     ref,
+		}
+	}
+
+	for _, event := range block.DisputeConcluded {
+		if dispute, ok := t.disputes[event.CandidateHash]; ok {
+			result := event.DisputeLocation
+
+			dispute.Concluded = &ref
+			dispute.Result = &result
+		}
+	}
+
+	for _, event := range block.DisputeTimedOut {
+		if dispute, ok := t.disputes[event.CandidateHash]; ok {
+			dispute.TimedOut = &ref
+		}
+	}
+}
+
+// Candidate returns the candidate of the parachain block, and false if it is unknown.
+func (t *InclusionTracker) Candidate(paraBlockHash types.Hash) (Candidate, bool) {
+	candidateHash, ok := t.candidateHashes[paraBlockHash]
+	if !ok {
+		return Candidate{}, false
+	}
+
+	return *t.candidates[candidateHash], true
+}
+
+// Candidates returns the known candidates, ordered by the relay chain block where they were first seen.
+func (t *InclusionTracker) Candidates() []Candidate {
+	candidates := make([]Candidate, 0, len(t.candidates))
+
+	for _, candidate := range t.candidates {
+		candidates = append(candidates, *candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return firstSeen(candidates[i]) < firstSeen(candidates[j])
+	})
+
+	return candidates
+}
+
+func firstSeen(c Candidate) types.BlockNumber {
+	for _, ref := range []*BlockRef{c.Backed, c.Included, c.TimedOut} {
+		if ref != nil {
+			return ref.Number
+		}
+	}
+
+	return 0
+}
+
+func lastSeen(c Candidate) types.BlockNumber {
+	var last types.BlockNumber
+
+	for _, ref := range []*BlockRef{c.Backed, c.Included, c.TimedOut} {
+		if ref != nil && ref.Number > last {
+			last = ref.Number
+		}
+	}
+
+	return last
+}
+
+// Disputes returns the disputes concerning the candidates of the parachain, ordered by the relay chain block where
+// they were initiated.
+func (t *InclusionTracker) Disputes() []Dispute {
+	disputes := make([]Dispute, 0, len(t.disputes))
+
+	for _, dispute := range t.disputes {
+		disputes = append(disputes, *dispute)
+	}
+
+	sort.Slice(disputes, func(i, j int) bool {
+		return disputes[i].Initiated.Number < disputes[j].Initiated.Number
+	})
+
+	return disputes
+}
+
+// Head returns the hash of the parachain block that was the head of the parachain after the relay chain block, and
+// false if it is unknown.
+func (t *InclusionTracker) Head(relayBlockHash types.Hash) (types.Hash, bool) {
+	head, ok := t.heads[relayBlockHash]
+
+	return head.paraBlockHash, ok
+}
+
+// Prune removes the candidates and the disputes last seen in a relay chain block below the block number, and the
+// heads of the parachain after these blocks.
+//
+// The disputes initiated after the pruning for the removed candidates are not recorded.
+func (t *InclusionTracker) Prune(belowNumber types.BlockNumber) {
+	for candidateHash, candidate := range t.candidates {
+		if lastSeen(*candidate) >= belowNumber {
+			continue
+		}
+
+		delete(t.candidates, candidateHash)
+
+		if t.candidateHashes[candidate.ParaBlockHash] == candidateHash {
+			delete(t.candidateHashes, candidate.ParaBlockHash)
+		}
+	}
+
+	for candidateHash, dispute := range t.disputes {
+		last := dispute.Initiated.Number
+
+		for _, ref := range []*BlockRef{dispute.Concluded, dispute.TimedOut} {
+			if ref != nil && ref.Number > last {
+				last = ref.Number
+			}
+		}
+
+		if last < belowNumber {
+			delete(t.disputes, candidateHash)
+		}
+	}
+
+	for relayBlockHash, head := range t.heads {
+		if head.relayBlockNumber < belowNumber {
+			delete(t.heads, relayBlockHash)
+		}
+	}
+}
+
+// ParaBlockHash returns the hash of the parachain block with the provided head data, which is the SCALE encoded
+// header of the block.
+func ParaBlockHash(head types.HeadData) (types.Hash, error) {
+	b := make([]byte, len(head))
+
+	for i, v := range head {
+		b[i] = byte(v)
+	}
+
+	return blake2b256(b)
+}
+
+// CandidateHash returns the hash of the candidate receipt.
+func CandidateHash(receipt types.CandidateReceipt) (types.Hash, error) {
+	b, err := codec.Encode(receipt)
+	if err != nil {
+		return types.Hash{}, ErrHashing.Wrap(err)
+	}
+
+	return blake2b256(b)
+}
+
+func blake2b256(b []byte) (types.Hash, error) {
+	h, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, ErrHashing.Wrap(err)
+	}
+
+	h.Write(b)
+
+	return types.NewHash(h.Sum(nil)), nil
+}
@@ -0,0 +1,380 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parachain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+const testParaID types.ParachainID = 2000
+
+func testReceipt(paraID types.ParachainID, paraHead byte) types.CandidateReceipt {
+	return types.CandidateReceipt{
+		Descriptor: types.CandidateDescriptor{ParachainID: paraID, ParaHead: types.NewHash([]byte{paraHead})},
+	}
+}
+
+func testBlockRef(number types.BlockNumber) BlockRef {
+	return BlockRef{Hash: types.NewHash([]byte{byte(number)}), Number: number}
+}
+
+func testCandidateHash(t *testing.T, receipt types.CandidateReceipt) types.Hash {
+	enc, err := codec.Encode(receipt)
+	require.NoError(t, err)
+
+	return blake2b.Sum256(enc)
+}
+
+func TestInclusionTracker(t *testing.T) {
+	tracker := NewInclusionTracker(testParaID)
+	assert.Equal(t, testParaID, tracker.ParaID())
+
+	_, ok := tracker.LastBlock()
+	assert.False(t, ok)
+
+	first := testReceipt(testParaID, 0xa1)
+	second := testReceipt(testParaID, 0xa2)
+	other := testReceipt(testParaID+1, 0xb1)
+
+	head := types.HeadData{0x01, 0x02}
+
+	err := tracker.ImportBlock(RelayBlock{
+		BlockRef: testBlockRef(10),
+		CandidateBacked: []types.EventParaInclusionCandidateBacked{
+			{CandidateReceipt: other},
+			{CandidateReceipt: first},
+		},
+		Head: &head,
+	})
+	require.NoError(t, err)
+
+	err = tracker.ImportBlock(RelayBlock{
+		BlockRef:        testBlockRef(11),
+		CandidateBacked: []types.EventParaInclusionCandidateBacked{{CandidateReceipt: second}},
+	})
+	require.NoError(t, err)
+
+	err = tracker.ImportBlock(RelayBlock{
+		BlockRef:          testBlockRef(12),
+		CandidateIncluded: []types.EventParaInclusionCandidateIncluded{{CandidateReceipt: first}},
+		CandidateTimedOut: []types.EventParaInclusionCandidateTimedOut{{CandidateReceipt: second}},
+	})
+	require.NoError(t, err)
+
+	last, ok := tracker.LastBlock()
+	assert.True(t, ok)
+	assert.Equal(t, testBlockRef(12), last)
+
+	candidate, ok := tracker.Candidate(first.Descriptor.ParaHead)
+	assert.True(t, ok)
+
+	backed, included := testBlockRef(10), testBlockRef(12)
+
+	assert.Equal(t, Candidate{
+		ParaBlockHash: first.Descriptor.ParaHead,
+		CandidateHash: testCandidateHash(t, first),
+		Receipt:       first,
+		Backed:        &backed,
+		Included:      &included,
+	}, candidate)
+
+	latency, ok := candidate.InclusionLatency()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), latency)
+
+	candidate, ok = tracker.Candidate(second.Descriptor.ParaHead)
+	assert.True(t, ok)
+	assert.Equal(t, &included, candidate.TimedOut)
+
+	_, ok = candidate.InclusionLatency()
+	assert.False(t, ok)
+
+	_, ok = tracker.Candidate(other.Descriptor.ParaHead)
+	assert.False(t, ok)
+
+	candidates := tracker.Candidates()
+	require.Len(t, candidates, 2)
+	assert.Equal(t, first.Descriptor.ParaHead, candidates[0].ParaBlockHash)
+	assert.Equal(t, second.Descriptor.ParaHead, candidates[1].ParaBlockHash)
+
+	paraBlockHash, ok := tracker.Head(testBlockRef(10).Hash)
+	assert.True(t, ok)
+	assert.Equal(t, types.Hash(blake2b.Sum256([]byte{0x01, 0x02})), paraBlockHash)
+
+	_, ok = tracker.Head(testBlockRef(11).Hash)
+	assert.False(t, ok)
+
+	err = tracker.ImportBlock(RelayBlock{BlockRef: testBlockRef(12)})
+	assert.ErrorIs(t, err, ErrBlockOrder)
+}
+
+func TestInclusionTracker_Disputes(t *testing.T) {
+	tracker := NewInclusionTracker(testParaID)
+
+	first := testReceipt(testParaID, 0xa1)
+	second := testReceipt(testParaID, 0xa2)
+
+	firstHash := testCandidateHash(t, first)
+	secondHash := testCandidateHash(t, second)
+	unknownHash := types.NewHash([]byte{0xff})
+
+	err := tracker.ImportBlock(RelayBlock{
+		BlockRef: testBlockRef(10),
+		CandidateBacked: []types.EventParaInclusionCandidateBacked{
+			{CandidateReceipt: first},
+			{CandidateReceipt: second},
+		},
+	})
+	require.NoError(t, err)
+
+	err = tracker.ImportBlock(RelayBlock{
+		BlockRef: testBlockRef(11),
+		DisputeInitiated: []types.EventParasDisputesDisputeInitiated{
+			{CandidateHash: secondHash, DisputeLocation: types.DisputeLocation{IsRemote: true}},
+			{CandidateHash: unknownHash, DisputeLocation: types.DisputeLocation{IsLocal: true}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = tracker.ImportBlock(RelayBlock{
+		BlockRef: testBlockRef(12),
+		DisputeInitiated: []types.EventParasDisputesDisputeInitiated{
+			{CandidateHash: firstHash, DisputeLocation: types.DisputeLocation{IsLocal: true}},
+		},
+		DisputeConcluded: []types.EventParasDisputesDisputeConcluded{
+			{CandidateHash: secondHash, DisputeLocation: types.DisputeResult{IsInvalid: true}},
+			{CandidateHash: unknownHash, DisputeLocation: types.DisputeResult{IsValid: true}},
+		},
+		DisputeTimedOut: []types.EventParasDisputesDisputeTimedOut{{CandidateHash: unknownHash}},
+	})
+	require.NoError(t, err)
+
+	err = tracker.ImportBlock(RelayBlock{
+		BlockRef:        testBlockRef(13),
+		DisputeTimedOut: []types.EventParasDisputesDisputeTimedOut{{CandidateHash: firstHash}},
+	})
+	require.NoError(t, err)
+
+	concluded, timedOut := testBlockRef(12), testBlockRef(13)
+
+	assert.Equal(t, []Dispute{
+		{
+			CandidateHash: secondHash,
+			ParaBlockHash: second.Descriptor.ParaHead,
+			Location:      types.DisputeLocation{IsRemote: true},
+			Initiated:     testBlockRef(11),
+			Concluded:     &concluded,
+			Result:        &types.DisputeResult{IsInvalid: true},
+		},
+		{
+			CandidateHash: firstHash,
+			ParaBlockHash: first.Descriptor.ParaHead,
+			Location:      types.DisputeLocation{IsLocal: true},
+			Initiated:     testBlockRef(12),
+			TimedOut:      &timedOut,
+		},
+	}, tracker.Disputes())
+}
+
+func TestInclusionTracker_Prune(t *testing.T) {
+	tracker := NewInclusionTracker(testParaID)
+
+	first := testReceipt(testParaID, 0xa1)
+	second := testReceipt(testParaID, 0xa2)
+	firstHash, secondHash := testCandidateHash(t, first), testCandidateHash(t, second)
+
+	head := types.HeadData{0x01}
+
+	blocks := []RelayBlock{
+		{
+			BlockRef:        testBlockRef(10),
+			CandidateBacked: []types.EventParaInclusionCandidateBacked{{CandidateReceipt: first}},
+			Head:            &head,
+		},
+		{
+			BlockRef:        testBlockRef(11),
+			CandidateBacked: []types.EventParaInclusionCandidateBacked{{CandidateReceipt: second}},
+			Head:            &head,
+		},
+		{
+			BlockRef:          testBlockRef(12),
+			CandidateIncluded: []types.EventParaInclusionCandidateIncluded{{CandidateReceipt: second}},
+			DisputeInitiated:  []types.EventParasDisputesDisputeInitiated{{CandidateHash: firstHash}},
+			Head:              &head,
+		},
+	}
+
+	for _, block := range blocks {
+		require.NoError(t, tracker.ImportBlock(block))
+	}
+
+	tracker.Prune(12)
+
+	_, ok := tracker.Candidate(first.Descriptor.ParaHead)
+	assert.False(t, ok)
+
+	_, ok = tracker.Candidate(second.Descriptor.ParaHead)
+	assert.True(t, ok)
+
+	// The dispute was initiated in the last block, after the candidate was last seen.
+	disputes := tracker.Disputes()
+	require.Len(t, disputes, 1)
+	assert.Equal(t, firstHash, disputes[0].CandidateHash)
+
+	for _, block := range blocks {
+		_, ok := tracker.Head(block.Hash)
+		assert.Equal(t, block.Number >= 12, ok)
+	}
+
+	tracker.Prune(13)
+
+	assert.Empty(t, tracker.Candidates())
+	assert.Empty(t, tracker.Disputes())
+
+	_, ok = tracker.Head(testBlockRef(12).Hash)
+	assert.False(t, ok)
+
+	// The disputes of the pruned candidates are not recorded.
+	err := tracker.ImportBlock(RelayBlock{
+		BlockRef:         testBlockRef(13),
+		DisputeInitiated: []types.EventParasDisputesDisputeInitiated{{CandidateHash: secondHash}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, tracker.Disputes())
+
+	last, ok := tracker.LastBlock()
+	assert.True(t, ok)
+	assert.Equal(t, testBlockRef(13), last)
+}
+
+func TestNewRelayBlock(t *testing.T) {
+	var meta types.Metadata
+
+	require.NoError(t, codec.DecodeFromHex(test.PolkadotMetadataHex, &meta))
+
+	receipt := testReceipt(testParaID, 0xa1)
+	head := types.HeadData{0x01}
+	phase := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}
+	topics := []types.Hash{types.NewHash([]byte{0x03})}
+
+	candidateFields := testCandidateEventFields{CandidateReceipt: receipt, HeadData: head, CoreIndex: 1, GroupIndex: 2}
+
+	type timedOutFields struct {
+		CandidateReceipt types.CandidateReceipt
+		HeadData         types.HeadData
+		CoreIndex        types.CoreIndex
+	}
+
+	type disputeFields struct {
+		CandidateHash types.Hash
+		Location      types.DisputeLocation
+	}
+
+	type concludedFields struct {
+		CandidateHash types.Hash
+		Result        types.DisputeResult
+	}
+
+	rawEvents := encodeTestEvents(
+		t,
+		testEvent[testCandidateEventFields]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "ParaInclusion", "CandidateBacked"),
+			Fields:  candidateFields,
+			Topics:  topics,
+		},
+		// The events of the other pallets are skipped.
+		testEvent[types.ParachainID]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "Paras", "CurrentHeadUpdated"),
+			Fields:  testParaID,
+		},
+		testEvent[testCandidateEventFields]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "ParaInclusion", "CandidateIncluded"),
+			Fields:  candidateFields,
+		},
+		testEvent[timedOutFields]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "ParaInclusion", "CandidateTimedOut"),
+			Fields:  timedOutFields{CandidateReceipt: receipt, HeadData: head, CoreIndex: 1},
+		},
+		testEvent[disputeFields]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "ParasDisputes", "DisputeInitiated"),
+			Fields:  disputeFields{CandidateHash: receipt.CommitmentsHash, Location: types.DisputeLocation{IsRemote: true}},
+		},
+		testEvent[concludedFields]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "ParasDisputes", "DisputeConcluded"),
+			Fields:  concludedFields{CandidateHash: receipt.CommitmentsHash, Result: types.DisputeResult{IsInvalid: true}},
+		},
+		testEvent[types.Hash]{
+			Phase:   phase,
+			EventID: findTestEventID(t, &meta, "ParasDisputes", "DisputeTimedOut"),
+			Fields:  receipt.CommitmentsHash,
+		},
+	)
+
+	block, err := NewRelayBlock(testBlockRef(1), &meta, parseTestEvents(t, &meta, rawEvents), &head)
+	require.NoError(t, err)
+
+	assert.Equal(t, RelayBlock{
+		BlockRef: testBlockRef(1),
+		CandidateBacked: []types.EventParaInclusionCandidateBacked{
+			{
+				Phase:            phase,
+				CandidateReceipt: receipt,
+				HeadData:         head,
+				CoreIndex:        1,
+				GroupIndex:       2,
+				Topics:           topics,
+			},
+		},
+		CandidateIncluded: []types.EventParaInclusionCandidateIncluded{
+			{Phase: phase, CandidateReceipt: receipt, HeadData: head, CoreIndex: 1, GroupIndex: 2},
+		},
+		CandidateTimedOut: []types.EventParaInclusionCandidateTimedOut{
+			{Phase: phase, CandidateReceipt: receipt, HeadData: head, CoreIndex: 1},
+		},
+		DisputeInitiated: []types.EventParasDisputesDisputeInitiated{
+			{
+				Phase:           phase,
+				CandidateHash:   receipt.CommitmentsHash,
+				DisputeLocation: types.DisputeLocation{IsRemote: true},
+			},
+		},
+		DisputeConcluded: []types.EventParasDisputesDisputeConcluded{
+			{
+				Phase:           phase,
+				CandidateHash:   receipt.CommitmentsHash,
+				DisputeLocation: types.DisputeResult{IsInvalid: true},
+			},
+		},
+		DisputeTimedOut: []types.EventParasDisputesDisputeTimedOut{
+			{Phase: phase, CandidateHash: receipt.CommitmentsHash},
+		},
+		Head: &head,
+	}, block)
+}
@@ -0,0 +1,308 @@
+package batch
+
+import (
+	"context"
+	"math"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+//go:generate mockery --name Composer --structname ComposerMock --filename composer_mock.go --inpackage
+
+// Composer is the interface used for composing utility batches that fit in a block.
+type Composer interface {
+	Compose(ctx context.Context, calls []types.Call, opts ...OptsFn) ([]*Chunk, error)
+}
+
+// Chunk is a utility batch call that wraps a contiguous part of the composed calls.
+type Chunk struct {
+	// Call is the utility batch call, ready to be used in an extrinsic.
+	Call types.Call
+	// CallCount is the number of calls wrapped by Call.
+	CallCount int
+	// Weight is the weight of Call, as estimated by the TransactionPaymentCallApi.
+	Weight types.Weight
+	// Length is the length of the encoded Call.
+	Length uint32
+}
+
+const (
+	systemModuleName          = "System"
+	blockWeightsConstantName  = "BlockWeights"
+	blockLengthConstantName   = "BlockLength"
+	encodedCallIndexLength    = 2
+	maxEncodedCompactU32Bytes = 5
+)
+
+// composer implements the Composer interface.
+type composer struct {
+	stateRPC state.State
+}
+
+// NewComposer creates a new Composer.
+func NewComposer(stateRPC state.State) Composer {
+	return &composer{
+		stateRPC: stateRPC,
+	}
+}
+
+// Compose splits the provided calls into utility batch calls that fit under the weight and length limits of a single
+// normal extrinsic, as found in the System.BlockWeights and System.BlockLength constants.
+//
+// The chunks are built greedily, in the order of the provided calls, first by their encoded length and then by their
+// weight, which is estimated for every chunk via the TransactionPaymentCallApi. The returned chunks keep the order
+// of the calls and are meant to be signed and submitted with consecutive nonces.
+func (c *composer) Compose(ctx context.Context, calls []types.Call, opts ...OptsFn) ([]*Chunk, error) {
+	composeOpts := NewDefaultOpts()
+
+	for _, opt := range opts {
+		opt(composeOpts)
+	}
+
+	if len(calls) == 0 {
+		return nil, ErrNoCalls
+	}
+
+	if composeOpts.limitRatio <= 0 || composeOpts.limitRatio > 1 {
+		return nil, ErrInvalidLimitRatio.WithMsg("ratio %f", composeOpts.limitRatio)
+	}
+
+	meta, err := c.getMetadata(ctx, composeOpts.blockHash)
+
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	limits, err := getLimits(meta, composeOpts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	callLengths, err := getEncodedLengths(calls)
+
+	if err != nil {
+		return nil, ErrCallEncoding.Wrap(err)
+	}
+
+	var chunks []*Chunk
+
+	for start := 0; start < len(calls); {
+		count := getMaxCountByLength(callLengths[start:], limits.length)
+
+		if count == 0 {
+			return nil, ErrCallExceedsLengthLimit.WithMsg("call #%d", start)
+		}
+
+		chunk, err := c.createChunk(ctx, meta, calls[start:start+count], limits, composeOpts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		chunks = append(chunks, chunk)
+
+		start += chunk.CallCount
+	}
+
+	return chunks, nil
+}
+
+// createChunk creates a chunk that holds as many of the provided calls as possible while staying under the weight
+// limit. The number of calls is reduced proportionally to the amount by which the estimated weight exceeds the limit.
+func (c *composer) createChunk(
+	ctx context.Context,
+	meta *types.Metadata,
+	calls []types.Call,
+	limits *limits,
+	opts *Opts,
+) (*Chunk, error) {
+	count := len(calls)
+
+	for {
+		batchCall, err := types.NewCall(meta, opts.mode.CallName(), calls[:count])
+
+		if err != nil {
+			return nil, ErrBatchCallCreation.Wrap(err)
+		}
+
+		encodedBatchCall, err := codec.Encode(batchCall)
+
+		if err != nil {
+			return nil, ErrCallEncoding.Wrap(err)
+		}
+
+		length := uint32(len(encodedBatchCall))
+
+		info, err := state.QueryCallInfo(
+			ctx,
+			c.stateRPC,
+			encodedBatchCall,
+			length+opts.extrinsicLengthOverhead,
+			opts.blockHash,
+		)
+
+		if err != nil {
+			return nil, ErrCallInfoQuery.Wrap(err)
+		}
+
+		weight := info.Weight
+
+		ratio := limits.getWeightRatio(weight)
+
+		if ratio >= 1 {
+			return &Chunk{
+				Call:      batchCall,
+				CallCount: count,
+				Weight:    weight,
+				Length:    length,
+			}, nil
+		}
+
+		if count == 1 {
+			return nil, ErrCallExceedsWeightLimit.WithMsg(
+				"ref time %d, proof size %d",
+				ucompactToUint64(weight.RefTime),
+				ucompactToUint64(weight.ProofSize),
+			)
+		}
+
+		count = int(math.Floor(float64(count) * ratio))
+
+		if count < 1 {
+			count = 1
+		}
+	}
+}
+
+func (c *composer) getMetadata(ctx context.Context, blockHash *types.Hash) (*types.Metadata, error) {
+	if blockHash == nil {
+		return c.stateRPC.GetMetadataLatest(ctx)
+	}
+
+	return c.stateRPC.GetMetadata(ctx, *blockHash)
+}
+
+// limits holds the maximum weight and length of a chunk.
+type limits struct {
+	refTime   uint64
+	proofSize uint64
+	length    uint64
+}
+
+// getWeightRatio returns the ratio between the limit and the provided weight for the most constrained weight
+// component. A ratio of at least 1 means that the weight is within the limits.
+func (l *limits) getWeightRatio(weight types.Weight) float64 {
+	ratio := math.Inf(1)
+
+	if refTime := ucompactToUint64(weight.RefTime); refTime > 0 {
+		ratio = math.Min(ratio, float64(l.refTime)/float64(refTime))
+	}
+
+	if proofSize := ucompactToUint64(weight.ProofSize); proofSize > 0 {
+		ratio = math.Min(ratio, float64(l.proofSize)/float64(proofSize))
+	}
+
+	return ratio
+}
+
+// getLimits retrieves the weight and length limits of a normal extrinsic from the metadata constants and scales
+// them according to the provided Opts.
+func getLimits(meta *types.Metadata, opts *Opts) (*limits, error) {
+	encodedBlockWeights, err := meta.FindConstantValue(systemModuleName, blockWeightsConstantName)
+
+	if err != nil {
+		return nil, ErrBlockWeightsRetrieval.Wrap(err)
+	}
+
+	var blockWeights types.BlockWeights
+
+	if err := codec.Decode(encodedBlockWeights, &blockWeights); err != nil {
+		return nil, ErrBlockWeightsRetrieval.Wrap(err)
+	}
+
+	encodedBlockLength, err := meta.FindConstantValue(systemModuleName, blockLengthConstantName)
+
+	if err != nil {
+		return nil, ErrBlockLengthRetrieval.Wrap(err)
+	}
+
+	var blockLength types.BlockLength
+
+	if err := codec.Decode(encodedBlockLength, &blockLength); err != nil {
+		return nil, ErrBlockLengthRetrieval.Wrap(err)
+	}
+
+	normalClass := types.DispatchClass{IsNormal: true}
+
+	maxWeight := getMaxExtrinsicWeight(blockWeights, normalClass)
+
+	maxLength := uint64(float64(blockLength.Max.Get(normalClass)) * opts.limitRatio)
+
+	if maxLength <= uint64(opts.extrinsicLengthOverhead) {
+		return nil, ErrLengthOverheadTooLarge.WithMsg("overhead %d, limit %d", opts.extrinsicLengthOverhead, maxLength)
+	}
+
+	return &limits{
+		refTime:   uint64(float64(ucompactToUint64(maxWeight.RefTime)) * opts.limitRatio),
+		proofSize: uint64(float64(ucompactToUint64(maxWeight.ProofSize)) * opts.limitRatio),
+		length:    maxLength - uint64(opts.extrinsicLengthOverhead),
+	}, nil
+}
+
+// getMaxExtrinsicWeight returns the maximum weight of a single extrinsic of the dispatch class, falling back
+// to the maximum total weight of the class and then to the maximum weight of the block.
+func getMaxExtrinsicWeight(blockWeights types.BlockWeights, class types.DispatchClass) types.Weight {
+	classWeights := blockWeights.PerClass.Get(class)
+
+	if ok, maxExtrinsic := classWeights.MaxExtrinsic.Unwrap(); ok {
+		return maxExtrinsic
+	}
+
+	if ok, maxTotal := classWeights.MaxTotal.Unwrap(); ok {
+		return maxTotal
+	}
+
+	return blockWeights.MaxBlock
+}
+
+// getMaxCountByLength returns the number of leading calls whose batch call does not exceed the length limit.
+func getMaxCountByLength(callLengths []uint64, maxLength uint64) int {
+	// The call index and the largest compact encoded length prefix of the calls vector.
+	length := uint64(encodedCallIndexLength + maxEncodedCompactU32Bytes)
+
+	for i, callLength := range callLengths {
+		length += callLength
+
+		if length > maxLength {
+			return i
+		}
+	}
+
+	return len(callLengths)
+}
+
+func getEncodedLengths(calls []types.Call) ([]uint64, error) {
+	lengths := make([]uint64, 0, len(calls))
+
+	for _, call := range calls {
+		encodedCall, err := codec.Encode(call)
+
+		if err != nil {
+			return nil, err
+		}
+
+		lengths = append(lengths, uint64(len(encodedCall)))
+	}
+
+	return lengths, nil
+}
+
+func ucompactToUint64(u types.UCompact) uint64 {
+	b := big.Int(u)
+
+	return b.Uint64()
+}
@@ -0,0 +1,257 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	queryCallInfoRuntimeAPI = "TransactionPaymentCallApi_query_call_info"
+
+	// Max normal extrinsic ref time of the test metadata, 1479914788000, scaled by the default limit ratio.
+	testMaxRefTime = 1109936091000
+	// Max normal block length of the test metadata, 3932160, scaled by the default limit ratio.
+	testMaxLength = 2949120
+)
+
+func TestComposer_Compose(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	testCases := []struct {
+		Name                string
+		Calls               []types.Call
+		CallRefTime         uint64
+		Opts                []OptsFn
+		ExpectedCallName    string
+		ExpectedChunkCounts []int
+	}{
+		{
+			Name:                "weight limit",
+			Calls:               newTestCalls(25, 32),
+			CallRefTime:         testMaxRefTime / 11,
+			ExpectedCallName:    "Utility.batch_all",
+			ExpectedChunkCounts: []int{11, 11, 3},
+		},
+		{
+			Name:                "length limit",
+			Calls:               newTestCalls(5, 1_000_000),
+			CallRefTime:         1,
+			ExpectedCallName:    "Utility.batch_all",
+			ExpectedChunkCounts: []int{2, 2, 1},
+		},
+		{
+			Name:                "single chunk",
+			Calls:               newTestCalls(3, 32),
+			CallRefTime:         1,
+			Opts:                []OptsFn{WithMode(ModeForceBatch)},
+			ExpectedCallName:    "Utility.force_batch",
+			ExpectedChunkCounts: []int{3},
+		},
+		{
+			Name:                "limit ratio",
+			Calls:               newTestCalls(10, 32),
+			CallRefTime:         testMaxRefTime / 11,
+			Opts:                []OptsFn{WithMode(ModeBatch), WithLimitRatio(0.375)},
+			ExpectedCallName:    "Utility.batch",
+			ExpectedChunkCounts: []int{5, 5},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			stateRPCMock := stateMocks.NewState(t)
+
+			composer := NewComposer(stateRPCMock)
+
+			stateRPCMock.On("GetMetadataLatest", mock.Anything).
+				Return(meta, nil).
+				Once()
+
+			stateRPCMock.On("CallRawLatest", mock.Anything, queryCallInfoRuntimeAPI, mock.Anything).
+				Return(newTestCallRawLatestFn(t, testCase.CallRefTime))
+
+			chunks, err := composer.Compose(context.Background(), testCase.Calls, testCase.Opts...)
+			assert.NoError(t, err)
+
+			expectedCallIndex, err := meta.FindCallIndex(testCase.ExpectedCallName)
+			assert.NoError(t, err)
+
+			var chunkCounts []int
+
+			start := 0
+
+			for _, chunk := range chunks {
+				chunkCounts = append(chunkCounts, chunk.CallCount)
+
+				expectedCall, err := types.NewCall(meta, testCase.ExpectedCallName, testCase.Calls[start:start+chunk.CallCount])
+				assert.NoError(t, err)
+
+				assert.Equal(t, expectedCallIndex, chunk.Call.CallIndex)
+				assert.Equal(t, expectedCall, chunk.Call)
+				assert.Equal(t, testCase.CallRefTime*uint64(chunk.CallCount), ucompactToUint64(chunk.Weight.RefTime))
+
+				encodedCall, err := codec.Encode(chunk.Call)
+				assert.NoError(t, err)
+				assert.Equal(t, uint32(len(encodedCall)), chunk.Length)
+
+				start += chunk.CallCount
+			}
+
+			assert.Equal(t, testCase.ExpectedChunkCounts, chunkCounts)
+		})
+	}
+}
+
+func TestComposer_Compose_WithBlockHash(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	composer := NewComposer(stateRPCMock)
+
+	blockHash := types.Hash{1, 2, 3}
+
+	stateRPCMock.On("GetMetadata", mock.Anything, blockHash).
+		Return(meta, nil).
+		Once()
+
+	stateRPCMock.On("CallRaw", mock.Anything, queryCallInfoRuntimeAPI, mock.Anything, blockHash).
+		Return(func(_ context.Context, _ string, args []byte, _ types.Hash) (types.Bytes, error) {
+			return queryTestCallInfo(t, args, 1)
+		}).
+		Once()
+
+	chunks, err := composer.Compose(context.Background(), newTestCalls(2, 32), WithBlockHash(blockHash))
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 1)
+	assert.Equal(t, 2, chunks[0].CallCount)
+}
+
+func TestComposer_Compose_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	composer := NewComposer(stateRPCMock)
+
+	ctx := context.Background()
+
+	res, err := composer.Compose(ctx, nil)
+	assert.ErrorIs(t, err, ErrNoCalls)
+	assert.Nil(t, res)
+
+	res, err = composer.Compose(ctx, newTestCalls(1, 32), WithLimitRatio(1.5))
+	assert.ErrorIs(t, err, ErrInvalidLimitRatio)
+	assert.Nil(t, res)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = composer.Compose(ctx, newTestCalls(1, 32))
+	assert.ErrorIs(t, err, ErrMetadataRetrieval)
+	assert.Nil(t, res)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(meta, nil)
+
+	res, err = composer.Compose(ctx, newTestCalls(1, 32), WithExtrinsicLengthOverhead(testMaxLength))
+	assert.ErrorIs(t, err, ErrLengthOverheadTooLarge)
+	assert.Nil(t, res)
+
+	res, err = composer.Compose(ctx, newTestCalls(1, testMaxLength))
+	assert.ErrorIs(t, err, ErrCallExceedsLengthLimit)
+	assert.Nil(t, res)
+
+	stateRPCMock.On("CallRawLatest", mock.Anything, queryCallInfoRuntimeAPI, mock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = composer.Compose(ctx, newTestCalls(1, 32))
+	assert.ErrorIs(t, err, ErrCallInfoQuery)
+	assert.Nil(t, res)
+
+	stateRPCMock.On("CallRawLatest", mock.Anything, queryCallInfoRuntimeAPI, mock.Anything).
+		Return(types.Bytes{}, nil).
+		Once()
+
+	res, err = composer.Compose(ctx, newTestCalls(1, 32))
+	assert.ErrorIs(t, err, ErrCallInfoQuery)
+	assert.Nil(t, res)
+
+	stateRPCMock.On("CallRawLatest", mock.Anything, queryCallInfoRuntimeAPI, mock.Anything).
+		Return(newTestCallRawLatestFn(t, testMaxRefTime+1)).
+		Once()
+
+	res, err = composer.Compose(ctx, newTestCalls(1, 32))
+	assert.ErrorIs(t, err, ErrCallExceedsWeightLimit)
+	assert.Nil(t, res)
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func newTestCalls(count int, argsLength int) []types.Call {
+	calls := make([]types.Call, 0, count)
+
+	for i := 0; i < count; i++ {
+		args := make([]byte, argsLength)
+		args[0] = byte(i)
+
+		calls = append(calls, types.Call{
+			CallIndex: types.CallIndex{SectionIndex: 4, MethodIndex: byte(i)},
+			Args:      args,
+		})
+	}
+
+	return calls
+}
+
+// newTestCallRawLatestFn returns a function that mocks State.CallRawLatest when querying the call info of a batch call.
+func newTestCallRawLatestFn(
+	t *testing.T,
+	callRefTime uint64,
+) func(ctx context.Context, method string, args []byte) (types.Bytes, error) {
+	return func(_ context.Context, _ string, args []byte) (types.Bytes, error) {
+		return queryTestCallInfo(t, args, callRefTime)
+	}
+}
+
+// queryTestCallInfo returns the encoded call info of the batch call found in the provided args,
+// by reporting the provided ref time for each batched call.
+func queryTestCallInfo(t *testing.T, args []byte, callRefTime uint64) (types.Bytes, error) {
+	decoder := scale.NewDecoder(bytes.NewReader(args))
+
+	var callIndex types.CallIndex
+
+	err := decoder.Decode(&callIndex)
+	assert.NoError(t, err)
+
+	callCount, err := decoder.DecodeUintCompact()
+	assert.NoError(t, err)
+
+	refTime := new(big.Int).Mul(callCount, new(big.Int).SetUint64(callRefTime))
+
+	return codec.Encode(types.RuntimeDispatchInfo{
+		Weight:     types.NewWeight(types.NewUCompact(refTime), types.NewUCompactFromUInt(1)),
+		Class:      types.DispatchClass{IsNormal: true},
+		PartialFee: types.NewU128(*big.NewInt(1)),
+	})
+}
@@ -0,0 +1,17 @@
+package batch
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrNoCalls                = libErr.Error("no calls provided")
+	ErrMetadataRetrieval      = libErr.Error("metadata retrieval")
+	ErrBlockWeightsRetrieval  = libErr.Error("block weights retrieval")
+	ErrBlockLengthRetrieval   = libErr.Error("block length retrieval")
+	ErrCallEncoding           = libErr.Error("call encoding")
+	ErrBatchCallCreation      = libErr.Error("batch call creation")
+	ErrCallInfoQuery          = libErr.Error("call info query")
+	ErrCallExceedsLengthLimit = libErr.Error("call exceeds the length limit")
+	ErrCallExceedsWeightLimit = libErr.Error("call exceeds the weight limit")
+	ErrInvalidLimitRatio      = libErr.Error("invalid limit ratio")
+	ErrLengthOverheadTooLarge = libErr.Error("extrinsic length overhead exceeds the length limit")
+)
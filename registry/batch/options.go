@@ -0,0 +1,90 @@
+package batch
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+// Mode specifies the utility call used for batching the calls of a chunk.
+type Mode uint8
+
+const (
+	// ModeBatchAll uses Utility.batch_all, which reverts all the calls of a chunk if one of them fails.
+	ModeBatchAll Mode = iota
+	// ModeBatch uses Utility.batch, which stops at the first failed call of a chunk.
+	ModeBatch
+	// ModeForceBatch uses Utility.force_batch, which dispatches all the calls of a chunk regardless of failures.
+	ModeForceBatch
+)
+
+// CallName returns the name of the utility call used for the Mode.
+func (m Mode) CallName() string {
+	switch m {
+	case ModeBatch:
+		return "Utility.batch"
+	case ModeForceBatch:
+		return "Utility.force_batch"
+	default:
+		return "Utility.batch_all"
+	}
+}
+
+const (
+	defaultLimitRatio              = 0.75
+	defaultExtrinsicLengthOverhead = 256
+)
+
+// Opts holds the configurable options for a Composer.
+type Opts struct {
+	// mode specifies the utility call used for every chunk.
+	mode Mode
+
+	// blockHash is the hash of the block used for retrieving the limits and estimating weights.
+	// The latest block is used if not set.
+	blockHash *types.Hash
+
+	// limitRatio is the fraction of the extrinsic weight and length limits that a chunk may use.
+	limitRatio float64
+
+	// extrinsicLengthOverhead is the number of bytes added to the encoded call when signing the extrinsic,
+	// e.g. for the signer, signature and signed extensions.
+	extrinsicLengthOverhead uint32
+}
+
+// NewDefaultOpts creates the default Opts.
+func NewDefaultOpts() *Opts {
+	return &Opts{
+		mode:                    ModeBatchAll,
+		limitRatio:              defaultLimitRatio,
+		extrinsicLengthOverhead: defaultExtrinsicLengthOverhead,
+	}
+}
+
+// OptsFn is function that operate on Opts.
+type OptsFn func(opts *Opts)
+
+// WithMode sets the utility call used for every chunk.
+func WithMode(mode Mode) OptsFn {
+	return func(opts *Opts) {
+		opts.mode = mode
+	}
+}
+
+// WithBlockHash sets the block used for retrieving the limits and estimating weights.
+func WithBlockHash(blockHash types.Hash) OptsFn {
+	return func(opts *Opts) {
+		opts.blockHash = &blockHash
+	}
+}
+
+// WithLimitRatio sets the fraction, in (0, 1], of the extrinsic weight and length limits that a chunk may use.
+// Keeping a margin below the limits allows a chunk to be included in a block alongside other extrinsics.
+func WithLimitRatio(limitRatio float64) OptsFn {
+	return func(opts *Opts) {
+		opts.limitRatio = limitRatio
+	}
+}
+
+// WithExtrinsicLengthOverhead sets the number of bytes that signing adds to the encoded call of a chunk.
+func WithExtrinsicLengthOverhead(extrinsicLengthOverhead uint32) OptsFn {
+	return func(opts *Opts) {
+		opts.extrinsicLengthOverhead = extrinsicLengthOverhead
+	}
+}
@@ -0,0 +1,115 @@
+package multisig
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// accountIDPrefix is the prefix used by pallet-multisig when deriving the account ID of a multisig.
+var accountIDPrefix = []byte("modlpy/utilisuba")
+
+// Account holds the account ID of a multisig together with the signatories and threshold it was derived from.
+type Account struct {
+	// ID is the derived account ID of the multisig.
+	ID types.AccountID
+	// Signatories holds the sorted account IDs of all the signatories.
+	Signatories []types.AccountID
+	// Threshold is the number of approvals required for dispatching a call.
+	Threshold uint16
+}
+
+// NewAccount creates a new Account for the provided signatories and threshold.
+//
+// The signatories are sorted, as expected by pallet-multisig, and must not contain duplicates.
+func NewAccount(signatories []types.AccountID, threshold uint16) (*Account, error) {
+	if len(signatories) < 2 {
+		return nil, ErrNoOtherSignatories
+	}
+
+	if threshold == 0 || int(threshold) > len(signatories) {
+		return nil, ErrInvalidThreshold.WithMsg("threshold %d, signatories %d", threshold, len(signatories))
+	}
+
+	sortedSignatories := sortSignatories(signatories)
+
+	for i := 1; i < len(sortedSignatories); i++ {
+		if sortedSignatories[i] == sortedSignatories[i-1] {
+			return nil, ErrDuplicateSignatory.WithMsg("signatory '%s'", sortedSignatories[i].ToHexString())
+		}
+	}
+
+	accountID, err := DeriveAccountID(sortedSignatories, threshold)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		ID:          accountID,
+		Signatories: sortedSignatories,
+		Threshold:   threshold,
+	}, nil
+}
+
+// SS58Address returns the SS58 address of the multisig for the provided network format.
+func (a *Account) SS58Address(format uint16) string {
+	return subkey.SS58Encode(a.ID[:], format)
+}
+
+// OtherSignatories returns the sorted signatories, excluding the provided one.
+func (a *Account) OtherSignatories(signatory types.AccountID) ([]types.AccountID, error) {
+	otherSignatories := make([]types.AccountID, 0, len(a.Signatories)-1)
+
+	for _, s := range a.Signatories {
+		if s != signatory {
+			otherSignatories = append(otherSignatories, s)
+		}
+	}
+
+	if len(otherSignatories) == len(a.Signatories) {
+		return nil, ErrSignatoryNotFound.WithMsg("signatory '%s'", signatory.ToHexString())
+	}
+
+	return otherSignatories, nil
+}
+
+// DeriveAccountID derives the account ID of a multisig, as done by pallet-multisig, by hashing the multisig prefix,
+// the sorted signatories and the threshold with blake2-256.
+func DeriveAccountID(signatories []types.AccountID, threshold uint16) (types.AccountID, error) {
+	encodedSignatories, err := codec.Encode(sortSignatories(signatories))
+
+	if err != nil {
+		return types.AccountID{}, ErrAccountIDDerivation.Wrap(err)
+	}
+
+	encodedThreshold, err := codec.Encode(types.NewU16(threshold))
+
+	if err != nil {
+		return types.AccountID{}, ErrAccountIDDerivation.Wrap(err)
+	}
+
+	var b []byte
+
+	b = append(b, accountIDPrefix...)
+	b = append(b, encodedSignatories...)
+	b = append(b, encodedThreshold...)
+
+	return blake2b.Sum256(b), nil
+}
+
+func sortSignatories(signatories []types.AccountID) []types.AccountID {
+	sortedSignatories := make([]types.AccountID, len(signatories))
+
+	copy(sortedSignatories, signatories)
+
+	sort.Slice(sortedSignatories, func(i, j int) bool {
+		return bytes.Compare(sortedSignatories[i][:], sortedSignatories[j][:]) < 0
+	})
+
+	return sortedSignatories
+}
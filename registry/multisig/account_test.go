@@ -0,0 +1,76 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+var (
+	testAlice   = mustDecodeSS58AccountID("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	testBob     = mustDecodeSS58AccountID("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	testCharlie = mustDecodeSS58AccountID("5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y")
+)
+
+func TestNewAccount(t *testing.T) {
+	account, err := NewAccount([]types.AccountID{testCharlie, testAlice, testBob}, 2)
+	assert.NoError(t, err)
+
+	// The multisig address of Alice, Bob and Charlie with threshold 2, as derived by polkadot-js.
+	assert.Equal(t, "5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7", account.SS58Address(42))
+	assert.Equal(t, []types.AccountID{testBob, testCharlie, testAlice}, account.Signatories)
+	assert.Equal(t, uint16(2), account.Threshold)
+
+	accountID, err := DeriveAccountID([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, account.ID, accountID)
+}
+
+func TestNewAccount_Errors(t *testing.T) {
+	account, err := NewAccount([]types.AccountID{testAlice}, 1)
+	assert.ErrorIs(t, err, ErrNoOtherSignatories)
+	assert.Nil(t, account)
+
+	account, err = NewAccount([]types.AccountID{testAlice, testBob}, 0)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+	assert.Nil(t, account)
+
+	account, err = NewAccount([]types.AccountID{testAlice, testBob}, 3)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+	assert.Nil(t, account)
+
+	account, err = NewAccount([]types.AccountID{testAlice, testBob, testAlice}, 2)
+	assert.ErrorIs(t, err, ErrDuplicateSignatory)
+	assert.Nil(t, account)
+}
+
+func TestAccount_OtherSignatories(t *testing.T) {
+	account, err := NewAccount([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+
+	otherSignatories, err := account.OtherSignatories(testAlice)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.AccountID{testBob, testCharlie}, otherSignatories)
+
+	otherSignatories, err = account.OtherSignatories(types.AccountID{1})
+	assert.ErrorIs(t, err, ErrSignatoryNotFound)
+	assert.Nil(t, otherSignatories)
+}
+
+func mustDecodeSS58AccountID(address string) types.AccountID {
+	_, b, err := subkey.SS58Decode(address)
+
+	if err != nil {
+		panic(err)
+	}
+
+	accountID, err := types.NewAccountID(b)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return *accountID
+}
@@ -0,0 +1,355 @@
+package multisig
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+//go:generate mockery --name CallBuilder --structname CallBuilderMock --filename call_builder_mock.go --inpackage
+
+// CallBuilder is the interface used for retrieving the pending operations of a multisig and for building
+// the pallet-multisig calls of its signatories.
+type CallBuilder interface {
+	GetOperations(ctx context.Context, account *Account) ([]*Operation, error)
+	GetOperation(ctx context.Context, account *Account, callHash types.Hash) (*Operation, error)
+
+	NextCall(
+		ctx context.Context,
+		account *Account,
+		signatory types.AccountID,
+		call types.Call,
+		opts ...OptsFn,
+	) (types.Call, error)
+	CancelCall(
+		ctx context.Context,
+		account *Account,
+		signatory types.AccountID,
+		callHash types.Hash,
+	) (types.Call, error)
+}
+
+// Operation is a pending multisig operation, as found in the Multisig.Multisigs storage.
+type Operation struct {
+	// CallHash is the blake2-256 hash of the encoded call of the operation.
+	CallHash types.Hash
+	// When is the timepoint of the first approval of the operation.
+	When types.TimePoint
+	// Deposit is the amount reserved from the depositor.
+	Deposit types.U128
+	// Depositor is the signatory that made the first approval and holds the deposit.
+	Depositor types.AccountID
+	// Approvals holds the signatories that approved the operation.
+	Approvals []types.AccountID
+}
+
+// IsApprovedBy returns true if the provided signatory approved the operation.
+func (o *Operation) IsApprovedBy(signatory types.AccountID) bool {
+	for _, approval := range o.Approvals {
+		if approval == signatory {
+			return true
+		}
+	}
+
+	return false
+}
+
+// multisigInfo is the storage representation of a pending operation.
+type multisigInfo struct {
+	When      types.TimePoint
+	Deposit   types.U128
+	Depositor types.AccountID
+	Approvals []types.AccountID
+}
+
+const (
+	multisigModuleName    = "Multisig"
+	multisigsStorageName  = "Multisigs"
+	asMultiThreshold1Call = "Multisig.as_multi_threshold_1"
+	asMultiCall           = "Multisig.as_multi"
+	approveAsMultiCall    = "Multisig.approve_as_multi"
+	cancelAsMultiCall     = "Multisig.cancel_as_multi"
+
+	// storagePrefixLength is the length of the hashed pallet and storage names.
+	storagePrefixLength = 32
+	// twox64ConcatOverhead is the length of the hash that precedes a Twox64Concat key.
+	twox64ConcatOverhead = 8
+)
+
+// callBuilder implements the CallBuilder interface.
+type callBuilder struct {
+	stateRPC state.State
+}
+
+// NewCallBuilder creates a new CallBuilder.
+func NewCallBuilder(stateRPC state.State) CallBuilder {
+	return &callBuilder{
+		stateRPC: stateRPC,
+	}
+}
+
+// GetOperations retrieves all the pending operations of the multisig account.
+func (c *callBuilder) GetOperations(ctx context.Context, account *Account) ([]*Operation, error) {
+	meta, err := c.stateRPC.GetMetadataLatest(ctx)
+
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	// The full key of an entry is used for retrieving the prefix that is shared by all the operations of the account,
+	// which consists of the hashed pallet and storage names and the Twox64Concat hashed account ID.
+	entryKey, err := types.CreateStorageKey(
+		meta,
+		multisigModuleName,
+		multisigsStorageName,
+		account.ID[:],
+		make([]byte, len(types.Hash{})),
+	)
+
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	prefix := entryKey[:storagePrefixLength+twox64ConcatOverhead+types.AccountIDLen]
+
+	keys, err := c.stateRPC.GetKeysLatest(ctx, prefix)
+
+	if err != nil {
+		return nil, ErrStorageKeysRetrieval.Wrap(err)
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	changeSets, err := c.stateRPC.QueryStorageAtLatest(ctx, keys)
+
+	if err != nil {
+		return nil, ErrStorageRetrieval.Wrap(err)
+	}
+
+	var operations []*Operation
+
+	for _, changeSet := range changeSets {
+		for _, change := range changeSet.Changes {
+			if !change.HasStorageData {
+				continue
+			}
+
+			// The call hash is stored at the end of the key, after its Blake2_128Concat hash.
+			callHash := types.NewHash(change.StorageKey[len(change.StorageKey)-len(types.Hash{}):])
+
+			operation, err := decodeOperation(callHash, change.StorageData)
+
+			if err != nil {
+				return nil, err
+			}
+
+			operations = append(operations, operation)
+		}
+	}
+
+	return operations, nil
+}
+
+// GetOperation retrieves the pending operation of the multisig account for the provided call hash.
+func (c *callBuilder) GetOperation(ctx context.Context, account *Account, callHash types.Hash) (*Operation, error) {
+	meta, err := c.stateRPC.GetMetadataLatest(ctx)
+
+	if err != nil {
+		return nil, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	return c.getOperation(ctx, meta, account, callHash)
+}
+
+// NextCall builds the call that the signatory has to submit in order to approve the provided call:
+//
+//   - Multisig.as_multi_threshold_1 if the threshold is 1.
+//   - Multisig.approve_as_multi if the call requires more approvals, with the timepoint of the pending operation,
+//     if any.
+//   - Multisig.as_multi if this is the final approval, with the timepoint of the pending operation and a max weight
+//     that is either estimated via the TransactionPaymentCallApi or provided in the options.
+func (c *callBuilder) NextCall(
+	ctx context.Context,
+	account *Account,
+	signatory types.AccountID,
+	call types.Call,
+	opts ...OptsFn,
+) (types.Call, error) {
+	callOpts := NewDefaultOpts()
+
+	for _, opt := range opts {
+		opt(callOpts)
+	}
+
+	otherSignatories, err := account.OtherSignatories(signatory)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	meta, err := c.stateRPC.GetMetadataLatest(ctx)
+
+	if err != nil {
+		return types.Call{}, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	if account.Threshold == 1 {
+		return newCall(meta, asMultiThreshold1Call, otherSignatories, call)
+	}
+
+	encodedCall, err := codec.Encode(call)
+
+	if err != nil {
+		return types.Call{}, ErrCallEncoding.Wrap(err)
+	}
+
+	callHash := types.NewHash(hashCall(encodedCall))
+
+	maybeTimepoint := types.NewEmptyOption[types.TimePoint]()
+	approvals := 0
+
+	operation, err := c.getOperation(ctx, meta, account, callHash)
+
+	switch {
+	case err == nil:
+		if operation.IsApprovedBy(signatory) {
+			return types.Call{}, ErrAlreadyApproved.WithMsg("signatory '%s'", signatory.ToHexString())
+		}
+
+		maybeTimepoint = types.NewOption(operation.When)
+		approvals = len(operation.Approvals)
+	case !errors.Is(err, ErrOperationNotFound):
+		return types.Call{}, err
+	}
+
+	threshold := types.NewU16(account.Threshold)
+
+	if approvals+1 < int(account.Threshold) {
+		return newCall(
+			meta,
+			approveAsMultiCall,
+			threshold,
+			otherSignatories,
+			maybeTimepoint,
+			callHash,
+			types.NewWeight(types.NewUCompactFromUInt(0), types.NewUCompactFromUInt(0)),
+		)
+	}
+
+	maxWeight := callOpts.maxWeight
+
+	if maxWeight == nil {
+		info, err := state.QueryCallInfo(ctx, c.stateRPC, encodedCall, uint32(len(encodedCall)), nil)
+
+		if err != nil {
+			return types.Call{}, ErrCallInfoQuery.Wrap(err)
+		}
+
+		maxWeight = &info.Weight
+	}
+
+	return newCall(meta, asMultiCall, threshold, otherSignatories, maybeTimepoint, call, *maxWeight)
+}
+
+// CancelCall builds the Multisig.cancel_as_multi call for the pending operation with the provided call hash.
+// Only the depositor of the operation can cancel it.
+func (c *callBuilder) CancelCall(
+	ctx context.Context,
+	account *Account,
+	signatory types.AccountID,
+	callHash types.Hash,
+) (types.Call, error) {
+	otherSignatories, err := account.OtherSignatories(signatory)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	meta, err := c.stateRPC.GetMetadataLatest(ctx)
+
+	if err != nil {
+		return types.Call{}, ErrMetadataRetrieval.Wrap(err)
+	}
+
+	operation, err := c.getOperation(ctx, meta, account, callHash)
+
+	if err != nil {
+		return types.Call{}, err
+	}
+
+	if operation.Depositor != signatory {
+		return types.Call{}, ErrNotDepositor.WithMsg("signatory '%s'", signatory.ToHexString())
+	}
+
+	return newCall(
+		meta,
+		cancelAsMultiCall,
+		types.NewU16(account.Threshold),
+		otherSignatories,
+		operation.When,
+		callHash,
+	)
+}
+
+func (c *callBuilder) getOperation(
+	ctx context.Context,
+	meta *types.Metadata,
+	account *Account,
+	callHash types.Hash,
+) (*Operation, error) {
+	key, err := types.CreateStorageKey(meta, multisigModuleName, multisigsStorageName, account.ID[:], callHash[:])
+
+	if err != nil {
+		return nil, ErrStorageKeyCreation.Wrap(err)
+	}
+
+	raw, err := c.stateRPC.GetStorageRawLatest(ctx, key)
+
+	if err != nil {
+		return nil, ErrStorageRetrieval.Wrap(err)
+	}
+
+	if raw == nil || len(*raw) == 0 {
+		return nil, ErrOperationNotFound.WithMsg("call hash '%s'", callHash.Hex())
+	}
+
+	return decodeOperation(callHash, *raw)
+}
+
+func decodeOperation(callHash types.Hash, storageData types.StorageDataRaw) (*Operation, error) {
+	var info multisigInfo
+
+	if err := codec.Decode(storageData, &info); err != nil {
+		return nil, ErrOperationDecoding.Wrap(err)
+	}
+
+	return &Operation{
+		CallHash:  callHash,
+		When:      info.When,
+		Deposit:   info.Deposit,
+		Depositor: info.Depositor,
+		Approvals: info.Approvals,
+	}, nil
+}
+
+func newCall(meta *types.Metadata, name string, args ...interface{}) (types.Call, error) {
+	call, err := types.NewCall(meta, name, args...)
+
+	if err != nil {
+		return types.Call{}, ErrCallCreation.WithMsg("call '%s'", name).Wrap(err)
+	}
+
+	return call, nil
+}
+
+func hashCall(encodedCall []byte) []byte {
+	h := blake2b.Sum256(encodedCall)
+
+	return h[:]
+}
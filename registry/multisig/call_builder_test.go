@@ -0,0 +1,428 @@
+package multisig
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const queryCallInfoRuntimeAPI = "TransactionPaymentCallApi_query_call_info"
+
+func TestCallBuilder_NextCall(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	account, err := NewAccount([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 0, MethodIndex: 7}, Args: []byte{1, 2, 3}}
+	callHash := getTestCallHash(t, call)
+	timepoint := types.TimePoint{Height: 10, Index: 1}
+	callWeight := types.NewWeight(types.NewUCompactFromUInt(100), types.NewUCompactFromUInt(200))
+
+	otherSignatories, err := account.OtherSignatories(testBob)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		Name         string
+		Operation    *Operation
+		Opts         []OptsFn
+		QueryWeight  bool
+		ExpectedCall func() (types.Call, error)
+	}{
+		{
+			Name: "first approval",
+			ExpectedCall: func() (types.Call, error) {
+				return types.NewCall(
+					meta,
+					approveAsMultiCall,
+					types.NewU16(2),
+					otherSignatories,
+					types.NewEmptyOption[types.TimePoint](),
+					callHash,
+					types.NewWeight(types.NewUCompactFromUInt(0), types.NewUCompactFromUInt(0)),
+				)
+			},
+		},
+		{
+			Name: "final approval",
+			Operation: &Operation{
+				When:      timepoint,
+				Depositor: testAlice,
+				Approvals: []types.AccountID{testAlice},
+			},
+			QueryWeight: true,
+			ExpectedCall: func() (types.Call, error) {
+				return types.NewCall(
+					meta,
+					asMultiCall,
+					types.NewU16(2),
+					otherSignatories,
+					types.NewOption(timepoint),
+					call,
+					callWeight,
+				)
+			},
+		},
+		{
+			Name: "final approval with max weight",
+			Operation: &Operation{
+				When:      timepoint,
+				Depositor: testAlice,
+				Approvals: []types.AccountID{testAlice},
+			},
+			Opts: []OptsFn{WithMaxWeight(types.NewWeight(types.NewUCompactFromUInt(1), types.NewUCompactFromUInt(2)))},
+			ExpectedCall: func() (types.Call, error) {
+				return types.NewCall(
+					meta,
+					asMultiCall,
+					types.NewU16(2),
+					otherSignatories,
+					types.NewOption(timepoint),
+					call,
+					types.NewWeight(types.NewUCompactFromUInt(1), types.NewUCompactFromUInt(2)),
+				)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			stateRPCMock := stateMocks.NewState(t)
+
+			callBuilder := NewCallBuilder(stateRPCMock)
+
+			stateRPCMock.On("GetMetadataLatest", mock.Anything).
+				Return(meta, nil).
+				Once()
+
+			stateRPCMock.On("GetStorageRawLatest", mock.Anything, getTestStorageKey(t, meta, account, callHash)).
+				Return(encodeTestOperation(t, testCase.Operation), nil).
+				Once()
+
+			if testCase.QueryWeight {
+				encodedCall, err := codec.Encode(call)
+				assert.NoError(t, err)
+
+				encodedLength, err := codec.Encode(types.NewU32(uint32(len(encodedCall))))
+				assert.NoError(t, err)
+
+				stateRPCMock.On(
+					"CallRawLatest",
+					mock.Anything,
+					queryCallInfoRuntimeAPI,
+					append(encodedCall, encodedLength...),
+				).Return(encodeTestDispatchInfo(t, callWeight), nil).Once()
+			}
+
+			res, err := callBuilder.NextCall(context.Background(), account, testBob, call, testCase.Opts...)
+			assert.NoError(t, err)
+
+			expectedCall, err := testCase.ExpectedCall()
+			assert.NoError(t, err)
+			assert.Equal(t, expectedCall, res)
+		})
+	}
+}
+
+func TestCallBuilder_NextCall_ThresholdOne(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	account, err := NewAccount([]types.AccountID{testAlice, testBob}, 1)
+	assert.NoError(t, err)
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	callBuilder := NewCallBuilder(stateRPCMock)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(meta, nil).
+		Once()
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 0, MethodIndex: 7}, Args: []byte{1}}
+
+	res, err := callBuilder.NextCall(context.Background(), account, testAlice, call)
+	assert.NoError(t, err)
+
+	expectedCall, err := types.NewCall(meta, asMultiThreshold1Call, []types.AccountID{testBob}, call)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCall, res)
+}
+
+func TestCallBuilder_NextCall_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	account, err := NewAccount([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 0, MethodIndex: 7}, Args: []byte{1}}
+	callHash := getTestCallHash(t, call)
+	storageKey := getTestStorageKey(t, meta, account, callHash)
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	callBuilder := NewCallBuilder(stateRPCMock)
+
+	ctx := context.Background()
+
+	res, err := callBuilder.NextCall(ctx, account, types.AccountID{1}, call)
+	assert.ErrorIs(t, err, ErrSignatoryNotFound)
+	assert.Equal(t, types.Call{}, res)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = callBuilder.NextCall(ctx, account, testBob, call)
+	assert.ErrorIs(t, err, ErrMetadataRetrieval)
+	assert.Equal(t, types.Call{}, res)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(meta, nil)
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, storageKey).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = callBuilder.NextCall(ctx, account, testBob, call)
+	assert.ErrorIs(t, err, ErrStorageRetrieval)
+	assert.Equal(t, types.Call{}, res)
+
+	operation := &Operation{Depositor: testBob, Approvals: []types.AccountID{testBob}}
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, storageKey).
+		Return(encodeTestOperation(t, operation), nil).
+		Once()
+
+	res, err = callBuilder.NextCall(ctx, account, testBob, call)
+	assert.ErrorIs(t, err, ErrAlreadyApproved)
+	assert.Equal(t, types.Call{}, res)
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, storageKey).
+		Return(encodeTestOperation(t, operation), nil).
+		Once()
+
+	stateRPCMock.On("CallRawLatest", mock.Anything, queryCallInfoRuntimeAPI, mock.Anything).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = callBuilder.NextCall(ctx, account, testAlice, call)
+	assert.ErrorIs(t, err, ErrCallInfoQuery)
+	assert.Equal(t, types.Call{}, res)
+}
+
+func TestCallBuilder_CancelCall(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	account, err := NewAccount([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+
+	callHash := types.Hash{1, 2, 3}
+	storageKey := getTestStorageKey(t, meta, account, callHash)
+	timepoint := types.TimePoint{Height: 5, Index: 2}
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	callBuilder := NewCallBuilder(stateRPCMock)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(meta, nil)
+
+	operation := &Operation{When: timepoint, Depositor: testCharlie, Approvals: []types.AccountID{testCharlie}}
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, storageKey).
+		Return(encodeTestOperation(t, operation), nil)
+
+	res, err := callBuilder.CancelCall(context.Background(), account, testCharlie, callHash)
+	assert.NoError(t, err)
+
+	otherSignatories, err := account.OtherSignatories(testCharlie)
+	assert.NoError(t, err)
+
+	expectedCall, err := types.NewCall(meta, cancelAsMultiCall, types.NewU16(2), otherSignatories, timepoint, callHash)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCall, res)
+
+	res, err = callBuilder.CancelCall(context.Background(), account, testAlice, callHash)
+	assert.ErrorIs(t, err, ErrNotDepositor)
+	assert.Equal(t, types.Call{}, res)
+
+	res, err = callBuilder.CancelCall(context.Background(), account, types.AccountID{1}, callHash)
+	assert.ErrorIs(t, err, ErrSignatoryNotFound)
+	assert.Equal(t, types.Call{}, res)
+}
+
+func TestCallBuilder_GetOperation(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	account, err := NewAccount([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+
+	callHash := types.Hash{1, 2, 3}
+	storageKey := getTestStorageKey(t, meta, account, callHash)
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	callBuilder := NewCallBuilder(stateRPCMock)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(meta, nil)
+
+	operation := &Operation{
+		CallHash:  callHash,
+		When:      types.TimePoint{Height: 5, Index: 2},
+		Deposit:   types.NewU128(*big.NewInt(1000)),
+		Depositor: testCharlie,
+		Approvals: []types.AccountID{testAlice, testCharlie},
+	}
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, storageKey).
+		Return(encodeTestOperation(t, operation), nil).
+		Once()
+
+	res, err := callBuilder.GetOperation(context.Background(), account, callHash)
+	assert.NoError(t, err)
+	assert.Equal(t, operation, res)
+	assert.True(t, res.IsApprovedBy(testAlice))
+	assert.False(t, res.IsApprovedBy(testBob))
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, storageKey).
+		Return(encodeTestOperation(t, nil), nil).
+		Once()
+
+	res, err = callBuilder.GetOperation(context.Background(), account, callHash)
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+	assert.Nil(t, res)
+}
+
+func TestCallBuilder_GetOperations(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	account, err := NewAccount([]types.AccountID{testAlice, testBob, testCharlie}, 2)
+	assert.NoError(t, err)
+
+	firstOperation := &Operation{
+		CallHash:  types.Hash{1},
+		When:      types.TimePoint{Height: 5, Index: 2},
+		Deposit:   types.NewU128(*big.NewInt(1000)),
+		Depositor: testCharlie,
+		Approvals: []types.AccountID{testCharlie},
+	}
+
+	secondOperation := &Operation{
+		CallHash:  types.Hash{2},
+		When:      types.TimePoint{Height: 6, Index: 1},
+		Deposit:   types.NewU128(*big.NewInt(2000)),
+		Depositor: testAlice,
+		Approvals: []types.AccountID{testAlice},
+	}
+
+	firstKey := getTestStorageKey(t, meta, account, firstOperation.CallHash)
+	secondKey := getTestStorageKey(t, meta, account, secondOperation.CallHash)
+
+	stateRPCMock := stateMocks.NewState(t)
+
+	callBuilder := NewCallBuilder(stateRPCMock)
+
+	stateRPCMock.On("GetMetadataLatest", mock.Anything).
+		Return(meta, nil)
+
+	prefix := firstKey[:storagePrefixLength+twox64ConcatOverhead+types.AccountIDLen]
+
+	// All the keys of the account share the prefix.
+	assert.Equal(t, prefix, secondKey[:len(prefix)])
+
+	stateRPCMock.On("GetKeysLatest", mock.Anything, prefix).
+		Return([]types.StorageKey{firstKey, secondKey}, nil).
+		Once()
+
+	stateRPCMock.On("QueryStorageAtLatest", mock.Anything, []types.StorageKey{firstKey, secondKey}).
+		Return([]types.StorageChangeSet{
+			{
+				Changes: []types.KeyValueOption{
+					{
+						StorageKey:     firstKey,
+						HasStorageData: true,
+						StorageData:    *encodeTestOperation(t, firstOperation),
+					},
+					{
+						StorageKey:     secondKey,
+						HasStorageData: true,
+						StorageData:    *encodeTestOperation(t, secondOperation),
+					},
+				},
+			},
+		}, nil).
+		Once()
+
+	res, err := callBuilder.GetOperations(context.Background(), account)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Operation{firstOperation, secondOperation}, res)
+
+	stateRPCMock.On("GetKeysLatest", mock.Anything, prefix).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err = callBuilder.GetOperations(context.Background(), account)
+	assert.ErrorIs(t, err, ErrStorageKeysRetrieval)
+	assert.Nil(t, res)
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+func getTestCallHash(t *testing.T, call types.Call) types.Hash {
+	encodedCall, err := codec.Encode(call)
+	assert.NoError(t, err)
+
+	return types.NewHash(hashCall(encodedCall))
+}
+
+func getTestStorageKey(t *testing.T, meta *types.Metadata, account *Account, callHash types.Hash) types.StorageKey {
+	key, err := types.CreateStorageKey(meta, multisigModuleName, multisigsStorageName, account.ID[:], callHash[:])
+	assert.NoError(t, err)
+
+	return key
+}
+
+func encodeTestOperation(t *testing.T, operation *Operation) *types.StorageDataRaw {
+	if operation == nil {
+		return &types.StorageDataRaw{}
+	}
+
+	b, err := codec.Encode(multisigInfo{
+		When:      operation.When,
+		Deposit:   operation.Deposit,
+		Depositor: operation.Depositor,
+		Approvals: operation.Approvals,
+	})
+	assert.NoError(t, err)
+
+	data := types.NewStorageDataRaw(b)
+
+	return &data
+}
+
+func encodeTestDispatchInfo(t *testing.T, weight types.Weight) types.Bytes {
+	b, err := codec.Encode(types.RuntimeDispatchInfo{
+		Weight:     weight,
+		Class:      types.DispatchClass{IsNormal: true},
+		PartialFee: types.NewU128(*big.NewInt(1)),
+	})
+	assert.NoError(t, err)
+
+	return b
+}
@@ -0,0 +1,22 @@
+package multisig
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrNoOtherSignatories   = libErr.Error("no other signatories")
+	ErrInvalidThreshold     = libErr.Error("invalid threshold")
+	ErrDuplicateSignatory   = libErr.Error("duplicate signatory")
+	ErrSignatoryNotFound    = libErr.Error("signatory not found")
+	ErrAccountIDDerivation  = libErr.Error("account ID derivation")
+	ErrMetadataRetrieval    = libErr.Error("metadata retrieval")
+	ErrStorageKeyCreation   = libErr.Error("storage key creation")
+	ErrStorageKeysRetrieval = libErr.Error("storage keys retrieval")
+	ErrStorageRetrieval     = libErr.Error("storage retrieval")
+	ErrOperationDecoding    = libErr.Error("operation decoding")
+	ErrOperationNotFound    = libErr.Error("operation not found")
+	ErrAlreadyApproved      = libErr.Error("operation already approved by signatory")
+	ErrNotDepositor         = libErr.Error("signatory is not the depositor of the operation")
+	ErrCallEncoding         = libErr.Error("call encoding")
+	ErrCallCreation         = libErr.Error("call creation")
+	ErrCallInfoQuery        = libErr.Error("call info query")
+)
@@ -0,0 +1,25 @@
+package multisig
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+// Opts holds the configurable options for building the next call of a multisig operation.
+type Opts struct {
+	// maxWeight is the max weight used for the final approval. It is estimated via the TransactionPaymentCallApi
+	// if not set.
+	maxWeight *types.Weight
+}
+
+// NewDefaultOpts creates the default Opts.
+func NewDefaultOpts() *Opts {
+	return &Opts{}
+}
+
+// OptsFn is function that operate on Opts.
+type OptsFn func(opts *Opts)
+
+// WithMaxWeight sets the max weight used for the final approval, instead of estimating it.
+func WithMaxWeight(maxWeight types.Weight) OptsFn {
+	return func(opts *Opts) {
+		opts.maxWeight = &maxWeight
+	}
+}
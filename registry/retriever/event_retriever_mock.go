@@ -3,6 +3,8 @@
 package retriever
 
 import (
+	context "context"
+
 	parser "github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type EventRetrieverMock struct {
 	mock.Mock
 }
 
-// GetEvents provides a mock function with given fields: blockHash
-func (_m *EventRetrieverMock) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
-	ret := _m.Called(blockHash)
+// GetEvents provides a mock function with given fields: ctx, blockHash
+func (_m *EventRetrieverMock) GetEvents(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error) {
+	ret := _m.Called(ctx, blockHash)
 
 	var r0 []*parser.Event
-	if rf, ok := ret.Get(0).(func(types.Hash) []*parser.Event); ok {
-		r0 = rf(blockHash)
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) []*parser.Event); ok {
+		r0 = rf(ctx, blockHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*parser.Event)
@@ -27,8 +29,8 @@ func (_m *EventRetrieverMock) GetEvents(blockHash types.Hash) ([]*parser.Event,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
-		r1 = rf(blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
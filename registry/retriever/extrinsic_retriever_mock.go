@@ -3,6 +3,8 @@
 package retriever
 
 import (
+	context "context"
+
 	registry "github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type ExtrinsicRetrieverMock struct {
 	mock.Mock
 }
 
-// GetExtrinsics provides a mock function with given fields: blockHash
-func (_m *ExtrinsicRetrieverMock) GetExtrinsics(blockHash types.Hash) ([]*registry.DecodedExtrinsic, error) {
-	ret := _m.Called(blockHash)
+// GetExtrinsics provides a mock function with given fields: ctx, blockHash
+func (_m *ExtrinsicRetrieverMock) GetExtrinsics(ctx context.Context, blockHash types.Hash) ([]*registry.DecodedExtrinsic, error) {
+	ret := _m.Called(ctx, blockHash)
 
 	var r0 []*registry.DecodedExtrinsic
-	if rf, ok := ret.Get(0).(func(types.Hash) []*registry.DecodedExtrinsic); ok {
-		r0 = rf(blockHash)
+	if rf, ok := ret.Get(0).(func(context.Context, types.Hash) []*registry.DecodedExtrinsic); ok {
+		r0 = rf(ctx, blockHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*registry.DecodedExtrinsic)
@@ -27,8 +29,8 @@ func (_m *ExtrinsicRetrieverMock) GetExtrinsics(blockHash types.Hash) ([]*regist
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.Hash) error); ok {
-		r1 = rf(blockHash)
+	if rf, ok := ret.Get(1).(func(context.Context, types.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,225 @@
+package submitter
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DispatchError holds the resolved information of a sp_runtime::DispatchError.
+type DispatchError struct {
+	// Name is the name of the DispatchError variant, e.g. "BadOrigin", "Token" or "Module".
+	Name string
+	// Details holds the name of the nested error, if any, e.g. "FundsUnavailable" for a token error
+	// or "Balances.InsufficientBalance" for a module error.
+	Details string
+	// Docs holds the documentation of the module error, if any.
+	Docs string
+	// ModuleError is set for module errors.
+	ModuleError *types.ModuleError
+}
+
+// Error implements the standard error interface.
+func (d *DispatchError) Error() string {
+	if d.Details == "" {
+		return d.Name
+	}
+
+	return fmt.Sprintf("%s: %s", d.Name, d.Details)
+}
+
+const (
+	dispatchErrorTypeName     = "DispatchError"
+	moduleErrorIndexFieldName = "index"
+	moduleErrorErrorFieldName = "error"
+)
+
+// ResolveDispatchError resolves the DispatchError found in the decoded fields of a System.ExtrinsicFailed event,
+// using the type information and pallet errors from the provided metadata.
+func ResolveDispatchError(meta *types.Metadata, fields registry.DecodedFields) (*DispatchError, error) {
+	field, err := getDispatchErrorField(meta, fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dispatchErrorType, ok := meta.AsMetadataV14.EfficientLookup[field.LookupIndex]
+
+	if !ok {
+		return nil, ErrDispatchErrorTypeNotFound.WithMsg("lookup index %d", field.LookupIndex)
+	}
+
+	if !dispatchErrorType.Def.IsVariant {
+		return nil, ErrDispatchErrorTypeNotVariant.WithMsg("lookup index %d", field.LookupIndex)
+	}
+
+	switch value := field.Value.(type) {
+	case byte:
+		// Variants without fields are decoded as the variant byte.
+		variant, ok := findVariantByIndex(dispatchErrorType.Def.Variant, value)
+
+		if !ok {
+			return nil, ErrDispatchErrorVariantNotFound.WithMsg("variant index %d", value)
+		}
+
+		return &DispatchError{Name: string(variant.Name)}, nil
+	case registry.DecodedFields:
+		// Variants with fields are decoded as composites, the variant is identified by the type of its field.
+		if len(value) == 0 {
+			return nil, ErrDispatchErrorValueNotSupported.WithMsg("empty variant fields")
+		}
+
+		innerField := value[0]
+
+		variant, ok := findVariantByFieldType(dispatchErrorType.Def.Variant, innerField.LookupIndex)
+
+		if !ok {
+			return nil, ErrDispatchErrorVariantNotFound.WithMsg("field lookup index %d", innerField.LookupIndex)
+		}
+
+		dispatchError := &DispatchError{Name: string(variant.Name)}
+
+		if err := resolveInnerError(meta, innerField, dispatchError); err != nil {
+			return nil, err
+		}
+
+		return dispatchError, nil
+	default:
+		return nil, ErrDispatchErrorValueNotSupported.WithMsg("value type %T", field.Value)
+	}
+}
+
+// getDispatchErrorField returns the field whose type is a DispatchError, or the first field if no such field
+// is found.
+func getDispatchErrorField(meta *types.Metadata, fields registry.DecodedFields) (*registry.DecodedField, error) {
+	if len(fields) == 0 {
+		return nil, ErrDispatchErrorFieldNotFound
+	}
+
+	for _, field := range fields {
+		fieldType, ok := meta.AsMetadataV14.EfficientLookup[field.LookupIndex]
+
+		if !ok {
+			continue
+		}
+
+		if pathLen := len(fieldType.Path); pathLen > 0 && fieldType.Path[pathLen-1] == dispatchErrorTypeName {
+			return field, nil
+		}
+	}
+
+	return fields[0], nil
+}
+
+// resolveInnerError resolves the error nested in a DispatchError variant, such as a ModuleError or a TokenError.
+func resolveInnerError(meta *types.Metadata, innerField *registry.DecodedField, dispatchError *DispatchError) error {
+	switch innerValue := innerField.Value.(type) {
+	case byte:
+		innerType, ok := meta.AsMetadataV14.EfficientLookup[innerField.LookupIndex]
+
+		if !ok || !innerType.Def.IsVariant {
+			return nil
+		}
+
+		if variant, ok := findVariantByIndex(innerType.Def.Variant, innerValue); ok {
+			dispatchError.Details = string(variant.Name)
+		}
+
+		return nil
+	case registry.DecodedFields:
+		moduleError, err := getModuleError(innerValue)
+
+		if err != nil {
+			return ErrModuleErrorFieldsDecoding.Wrap(err)
+		}
+
+		metaError, err := meta.FindError(moduleError.Index, moduleError.Error)
+
+		if err != nil {
+			return ErrModuleErrorRetrieval.Wrap(err)
+		}
+
+		dispatchError.ModuleError = moduleError
+		dispatchError.Details = metaError.Name
+		dispatchError.Docs = metaError.Value
+
+		for _, pallet := range meta.AsMetadataV14.Pallets {
+			if pallet.Index == moduleError.Index {
+				dispatchError.Details = fmt.Sprintf("%s.%s", pallet.Name, metaError.Name)
+				break
+			}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+// getModuleError creates a types.ModuleError from its decoded fields. Both the current [u8; 4] error
+// and the legacy u8 error are supported.
+func getModuleError(fields registry.DecodedFields) (*types.ModuleError, error) {
+	index, err := registry.GetDecodedFieldAsType[types.U8](
+		fields,
+		func(_ int, field *registry.DecodedField) bool {
+			return field.Name == moduleErrorIndexFieldName
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	moduleError := &types.ModuleError{Index: index}
+
+	errorBytes, err := registry.GetDecodedFieldAsSliceOfType[types.U8](
+		fields,
+		func(_ int, field *registry.DecodedField) bool {
+			return field.Name == moduleErrorErrorFieldName
+		},
+	)
+
+	if err == nil {
+		copy(moduleError.Error[:], errorBytes)
+
+		return moduleError, nil
+	}
+
+	errorByte, err := registry.GetDecodedFieldAsType[types.U8](
+		fields,
+		func(_ int, field *registry.DecodedField) bool {
+			return field.Name == moduleErrorErrorFieldName
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	moduleError.Error[0] = errorByte
+
+	return moduleError, nil
+}
+
+func findVariantByIndex(variantDef types.Si1TypeDefVariant, index byte) (types.Si1Variant, bool) {
+	for _, variant := range variantDef.Variants {
+		if byte(variant.Index) == index {
+			return variant, true
+		}
+	}
+
+	return types.Si1Variant{}, false
+}
+
+func findVariantByFieldType(variantDef types.Si1TypeDefVariant, lookupIndex int64) (types.Si1Variant, bool) {
+	for _, variant := range variantDef.Variants {
+		for _, field := range variant.Fields {
+			if field.Type.Int64() == lookupIndex {
+				return variant, true
+			}
+		}
+	}
+
+	return types.Si1Variant{}, false
+}
@@ -0,0 +1,125 @@
+package submitter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/test"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveDispatchError(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	testCases := []struct {
+		Name                  string
+		EncodedDispatchError  []byte
+		ExpectedDispatchError *DispatchError
+	}{
+		{
+			Name:                  "unit variant",
+			EncodedDispatchError:  []byte{2},
+			ExpectedDispatchError: &DispatchError{Name: "BadOrigin"},
+		},
+		{
+			Name:                  "nested variant",
+			EncodedDispatchError:  []byte{7, 0},
+			ExpectedDispatchError: &DispatchError{Name: "Token", Details: "NoFunds"},
+		},
+		{
+			Name:                 "module error",
+			EncodedDispatchError: []byte{3, 5, 2, 0, 0, 0},
+			ExpectedDispatchError: &DispatchError{
+				Name:    "Module",
+				Details: "Balances.InsufficientBalance",
+				Docs:    "Balance too low to send value.",
+				ModuleError: &types.ModuleError{
+					Index: 5,
+					Error: [4]types.U8{2, 0, 0, 0},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			fields := decodeTestDispatchErrorFields(t, meta, testCase.EncodedDispatchError)
+
+			res, err := ResolveDispatchError(meta, fields)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedDispatchError, res)
+		})
+	}
+}
+
+func TestResolveDispatchError_Errors(t *testing.T) {
+	meta := getTestMetadata(t)
+
+	res, err := ResolveDispatchError(meta, nil)
+	assert.ErrorIs(t, err, ErrDispatchErrorFieldNotFound)
+	assert.Nil(t, res)
+
+	fields := decodeTestDispatchErrorFields(t, meta, []byte{2})
+
+	fields[0].Value = "unsupported"
+
+	res, err = ResolveDispatchError(meta, fields)
+	assert.ErrorIs(t, err, ErrDispatchErrorValueNotSupported)
+	assert.Nil(t, res)
+
+	fields[0].Value = byte(255)
+
+	res, err = ResolveDispatchError(meta, fields)
+	assert.ErrorIs(t, err, ErrDispatchErrorVariantNotFound)
+	assert.Nil(t, res)
+
+	fields[0].LookupIndex = -1
+
+	res, err = ResolveDispatchError(meta, fields)
+	assert.ErrorIs(t, err, ErrDispatchErrorTypeNotFound)
+	assert.Nil(t, res)
+}
+
+func TestDispatchError_Error(t *testing.T) {
+	assert.Equal(t, "BadOrigin", (&DispatchError{Name: "BadOrigin"}).Error())
+	assert.Equal(
+		t,
+		"Module: Balances.InsufficientBalance",
+		(&DispatchError{Name: "Module", Details: "Balances.InsufficientBalance"}).Error(),
+	)
+}
+
+func getTestMetadata(t *testing.T) *types.Metadata {
+	var meta types.Metadata
+
+	err := codec.DecodeFromHex(test.PolkadotMetadataHex, &meta)
+	assert.NoError(t, err)
+
+	return &meta
+}
+
+// decodeTestDispatchErrorFields decodes the provided dispatch error bytes using the field decoder
+// of the System.ExtrinsicFailed event.
+func decodeTestDispatchErrorFields(t *testing.T, meta *types.Metadata, encodedDispatchError []byte) registry.DecodedFields {
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	assert.NoError(t, err)
+
+	for _, eventDecoder := range eventRegistry {
+		if eventDecoder.Name != systemExtrinsicFailedEventName {
+			continue
+		}
+
+		decodedField, err := eventDecoder.Fields[0].Decode(scale.NewDecoder(bytes.NewReader(encodedDispatchError)))
+		assert.NoError(t, err)
+
+		return registry.DecodedFields{decodedField}
+	}
+
+	t.Fatalf("event %s not found", systemExtrinsicFailedEventName)
+
+	return nil
+}
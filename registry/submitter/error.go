@@ -0,0 +1,30 @@
+package submitter
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrEventRetrieverCreation         = libErr.Error("event retriever creation")
+	ErrExtrinsicEncoding              = libErr.Error("extrinsic encoding")
+	ErrExtrinsicHashing               = libErr.Error("extrinsic hashing")
+	ErrExtrinsicSubmission            = libErr.Error("extrinsic submission")
+	ErrSubscription                   = libErr.Error("extrinsic status subscription")
+	ErrSubscriptionClosed             = libErr.Error("extrinsic status subscription closed")
+	ErrWaitInterrupted                = libErr.Error("wait interrupted")
+	ErrFinalityTimeout                = libErr.Error("finality timeout")
+	ErrExtrinsicUsurped               = libErr.Error("extrinsic usurped")
+	ErrExtrinsicDropped               = libErr.Error("extrinsic dropped")
+	ErrExtrinsicInvalid               = libErr.Error("extrinsic invalid")
+	ErrBlockRetrieval                 = libErr.Error("block retrieval")
+	ErrExtrinsicNotFound              = libErr.Error("extrinsic not found in block")
+	ErrEventRetrieval                 = libErr.Error("event retrieval")
+	ErrExtrinsicResultNotFound        = libErr.Error("extrinsic result event not found")
+	ErrMetadataRetrieval              = libErr.Error("metadata retrieval")
+	ErrDispatchErrorResolving         = libErr.Error("dispatch error resolving")
+	ErrDispatchErrorFieldNotFound     = libErr.Error("dispatch error field not found")
+	ErrDispatchErrorTypeNotFound      = libErr.Error("dispatch error type not found")
+	ErrDispatchErrorTypeNotVariant    = libErr.Error("dispatch error type not variant")
+	ErrDispatchErrorVariantNotFound   = libErr.Error("dispatch error variant not found")
+	ErrDispatchErrorValueNotSupported = libErr.Error("dispatch error value not supported")
+	ErrModuleErrorFieldsDecoding      = libErr.Error("module error fields decoding")
+	ErrModuleErrorRetrieval           = libErr.Error("module error retrieval")
+)
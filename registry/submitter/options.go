@@ -0,0 +1,50 @@
+package submitter
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+// WaitFor specifies the extrinsic status that ends the wait of a Submitter.
+type WaitFor uint8
+
+const (
+	// WaitForInBlock ends the wait once the extrinsic is included in a block.
+	WaitForInBlock WaitFor = iota
+	// WaitForFinalized ends the wait once the block that includes the extrinsic is finalized.
+	WaitForFinalized
+)
+
+// StatusHandlerFn is called for every extrinsic status update received while waiting.
+type StatusHandlerFn func(status types.ExtrinsicStatus)
+
+// Opts holds the configurable options for a Submitter.
+type Opts struct {
+	// waitFor specifies the status that ends the wait.
+	waitFor WaitFor
+
+	// statusHandler is called for each extrinsic status update, if set.
+	statusHandler StatusHandlerFn
+}
+
+// NewDefaultOpts creates the default Opts.
+func NewDefaultOpts() *Opts {
+	return &Opts{
+		waitFor: WaitForInBlock,
+	}
+}
+
+// OptsFn is function that operate on Opts.
+type OptsFn func(opts *Opts)
+
+// WithWaitFor sets the extrinsic status that ends the wait.
+func WithWaitFor(waitFor WaitFor) OptsFn {
+	return func(opts *Opts) {
+		opts.waitFor = waitFor
+	}
+}
+
+// WithStatusHandler sets the function that is called for each extrinsic status update,
+// including Retracted and FinalityTimeout transitions.
+func WithStatusHandler(statusHandler StatusHandlerFn) OptsFn {
+	return func(opts *Opts) {
+		opts.statusHandler = statusHandler
+	}
+}
@@ -0,0 +1,311 @@
+package submitter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	regState "github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"golang.org/x/crypto/blake2b"
+)
+
+//go:generate mockery --name Submitter --structname SubmitterMock --filename submitter_mock.go --inpackage
+
+// Submitter is the interface used for submitting an extrinsic and waiting for its inclusion result.
+type Submitter interface {
+	SubmitAndWait(ctx context.Context, xt extrinsic.Extrinsic, opts ...OptsFn) (*InclusionResult, error)
+}
+
+// InclusionResult holds the outcome of an extrinsic that was included in a block.
+type InclusionResult struct {
+	// BlockHash is the hash of the block that includes the extrinsic.
+	BlockHash types.Hash
+	// IsFinalized is true if BlockHash was reported as finalized.
+	IsFinalized bool
+	// ExtrinsicHash is the blake2-256 hash of the encoded extrinsic.
+	ExtrinsicHash types.Hash
+	// ExtrinsicIndex is the index of the extrinsic in the block.
+	ExtrinsicIndex uint32
+	// Events holds all the events emitted while applying the extrinsic.
+	Events []*parser.Event
+	// IsSuccess is true if System.ExtrinsicSuccess was emitted for the extrinsic.
+	IsSuccess bool
+	// DispatchError holds the resolved error if System.ExtrinsicFailed was emitted for the extrinsic.
+	DispatchError *DispatchError
+	// FeePaid holds the actual fee paid, as reported by TransactionPayment.TransactionFeePaid, if emitted.
+	FeePaid *types.U128
+	// Retracted holds the hashes of the blocks that included the extrinsic but were retracted
+	// while waiting for finality.
+	Retracted []types.Hash
+}
+
+// statusSubscription is the subset of author.ExtrinsicStatusSubscription used when waiting for a block.
+type statusSubscription interface {
+	Chan() <-chan types.ExtrinsicStatus
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// submitter implements the Submitter interface.
+type submitter struct {
+	authorRPC author.Author
+	chainRPC  chain.Chain
+	stateRPC  state.State
+
+	eventRetriever retriever.EventRetriever
+}
+
+// NewSubmitter creates a new Submitter.
+func NewSubmitter(
+	authorRPC author.Author,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	eventRetriever retriever.EventRetriever,
+) Submitter {
+	return &submitter{
+		authorRPC:      authorRPC,
+		chainRPC:       chainRPC,
+		stateRPC:       stateRPC,
+		eventRetriever: eventRetriever,
+	}
+}
+
+// NewDefaultSubmitter creates a new Submitter that uses the default retriever.EventRetriever.
+func NewDefaultSubmitter(
+	ctx context.Context,
+	authorRPC author.Author,
+	chainRPC chain.Chain,
+	stateRPC state.State,
+	fieldOverrides ...registry.FieldOverride,
+) (Submitter, error) {
+	eventRetriever, err := retriever.NewDefaultEventRetriever(
+		ctx,
+		regState.NewEventProvider(stateRPC),
+		stateRPC,
+		fieldOverrides...,
+	)
+
+	if err != nil {
+		return nil, ErrEventRetrieverCreation.Wrap(err)
+	}
+
+	return NewSubmitter(authorRPC, chainRPC, stateRPC, eventRetriever), nil
+}
+
+// SubmitAndWait submits the extrinsic, waits until it is included in a block - or until that block is finalized,
+// depending on the provided options - and returns the result of the extrinsic inclusion.
+//
+// The wait is interrupted when the provided context is done. A FinalityTimeout, Usurped, Dropped or Invalid
+// status ends the wait with an error, while a Retracted status is recorded in the result.
+//
+// If an error occurs after the extrinsic was included in a block, e.g. the dispatch error cannot be resolved,
+// the partially filled result is returned together with the error.
+func (s *submitter) SubmitAndWait(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+	opts ...OptsFn,
+) (*InclusionResult, error) {
+	submitOpts := NewDefaultOpts()
+
+	for _, opt := range opts {
+		opt(submitOpts)
+	}
+
+	encodedExtrinsic, err := codec.EncodeToHex(xt)
+
+	if err != nil {
+		return nil, ErrExtrinsicEncoding.Wrap(err)
+	}
+
+	extrinsicHash, err := getExtrinsicHash(encodedExtrinsic)
+
+	if err != nil {
+		return nil, ErrExtrinsicHashing.Wrap(err)
+	}
+
+	sub, err := s.authorRPC.SubmitAndWatchExtrinsicContext(ctx, xt)
+
+	if err != nil {
+		return nil, ErrExtrinsicSubmission.Wrap(err)
+	}
+
+	defer sub.Unsubscribe()
+
+	res, err := waitForBlock(ctx, sub, submitOpts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.ExtrinsicHash = extrinsicHash
+
+	if err := s.fillInclusionResult(ctx, res, encodedExtrinsic); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// waitForBlock processes the extrinsic status updates until the extrinsic is in a block or its block is finalized,
+// as specified in the provided Opts.
+func waitForBlock(ctx context.Context, sub statusSubscription, opts *Opts) (*InclusionResult, error) {
+	res := &InclusionResult{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ErrWaitInterrupted.Wrap(ctx.Err())
+		case err := <-sub.Err():
+			if err == nil {
+				return nil, ErrSubscriptionClosed
+			}
+
+			return nil, ErrSubscription.Wrap(err)
+		case status, ok := <-sub.Chan():
+			if !ok {
+				return nil, ErrSubscriptionClosed
+			}
+
+			if opts.statusHandler != nil {
+				opts.statusHandler(status)
+			}
+
+			switch {
+			case status.IsInBlock:
+				if opts.waitFor == WaitForInBlock {
+					res.BlockHash = status.AsInBlock
+
+					return res, nil
+				}
+			case status.IsRetracted:
+				res.Retracted = append(res.Retracted, status.AsRetracted)
+			case status.IsFinalized:
+				res.BlockHash = status.AsFinalized
+				res.IsFinalized = true
+
+				return res, nil
+			case status.IsFinalityTimeout:
+				return nil, ErrFinalityTimeout.WithMsg("block '%s'", status.AsFinalityTimeout.Hex())
+			case status.IsUsurped:
+				return nil, ErrExtrinsicUsurped.WithMsg("usurped by '%s'", status.AsUsurped.Hex())
+			case status.IsDropped:
+				return nil, ErrExtrinsicDropped
+			case status.IsInvalid:
+				return nil, ErrExtrinsicInvalid
+			}
+		}
+	}
+}
+
+const (
+	systemExtrinsicSuccessEventName             = "System.ExtrinsicSuccess"
+	systemExtrinsicFailedEventName              = "System.ExtrinsicFailed"
+	transactionPaymentFeePaidEventName          = "TransactionPayment.TransactionFeePaid"
+	transactionPaymentFeePaidActualFeeFieldName = "actual_fee"
+)
+
+// fillInclusionResult locates the extrinsic in the block found in the provided result and
+// fills in its index, events, dispatch outcome and paid fee.
+func (s *submitter) fillInclusionResult(ctx context.Context, res *InclusionResult, encodedExtrinsic string) error {
+	block, err := s.chainRPC.GetBlock(ctx, res.BlockHash)
+
+	if err != nil {
+		return ErrBlockRetrieval.Wrap(err)
+	}
+
+	extrinsicIndex, ok := findExtrinsicIndex(block.Block.Extrinsics, encodedExtrinsic)
+
+	if !ok {
+		return ErrExtrinsicNotFound.WithMsg("block '%s'", res.BlockHash.Hex())
+	}
+
+	res.ExtrinsicIndex = extrinsicIndex
+
+	events, err := s.eventRetriever.GetEvents(ctx, res.BlockHash)
+
+	if err != nil {
+		return ErrEventRetrieval.Wrap(err)
+	}
+
+	var failedEvent *parser.Event
+
+	for _, event := range events {
+		if event.Phase == nil || !event.Phase.IsApplyExtrinsic || event.Phase.AsApplyExtrinsic != extrinsicIndex {
+			continue
+		}
+
+		res.Events = append(res.Events, event)
+
+		switch event.Name {
+		case systemExtrinsicSuccessEventName:
+			res.IsSuccess = true
+		case systemExtrinsicFailedEventName:
+			failedEvent = event
+		case transactionPaymentFeePaidEventName:
+			fee, err := registry.GetDecodedFieldAsType[types.U128](
+				event.Fields,
+				func(_ int, field *registry.DecodedField) bool {
+					return field.Name == transactionPaymentFeePaidActualFeeFieldName
+				},
+			)
+
+			if err == nil {
+				res.FeePaid = &fee
+			}
+		}
+	}
+
+	if res.IsSuccess {
+		return nil
+	}
+
+	if failedEvent == nil {
+		return ErrExtrinsicResultNotFound.WithMsg("extrinsic #%d, block '%s'", extrinsicIndex, res.BlockHash.Hex())
+	}
+
+	meta, err := s.stateRPC.GetMetadata(ctx, res.BlockHash)
+
+	if err != nil {
+		return ErrMetadataRetrieval.Wrap(err)
+	}
+
+	dispatchError, err := ResolveDispatchError(meta, failedEvent.Fields)
+
+	if err != nil {
+		return ErrDispatchErrorResolving.Wrap(err)
+	}
+
+	res.DispatchError = dispatchError
+
+	return nil
+}
+
+func findExtrinsicIndex(blockExtrinsics []string, encodedExtrinsic string) (uint32, bool) {
+	for i, blockExtrinsic := range blockExtrinsics {
+		if strings.EqualFold(blockExtrinsic, encodedExtrinsic) {
+			return uint32(i), true
+		}
+	}
+
+	return 0, false
+}
+
+func getExtrinsicHash(encodedExtrinsic string) (types.Hash, error) {
+	b, err := codec.HexDecodeString(encodedExtrinsic)
+
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	h := blake2b.Sum256(b)
+
+	return types.NewHash(h[:]), nil
+}
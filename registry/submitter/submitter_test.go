@@ -0,0 +1,374 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	authorMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author/mocks"
+	chainMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain/mocks"
+	stateMocks "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/block"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubmitter_SubmitAndWait_EncodingError(t *testing.T) {
+	authorMock := authorMocks.NewAuthor(t)
+	chainMock := chainMocks.NewChain(t)
+	stateMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := NewSubmitter(authorMock, chainMock, stateMock, eventRetrieverMock)
+
+	res, err := submitter.SubmitAndWait(context.Background(), extrinsic.Extrinsic{Version: extrinsic.Version1})
+	assert.ErrorIs(t, err, ErrExtrinsicEncoding)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_SubmitAndWait_SubmissionError(t *testing.T) {
+	authorMock := authorMocks.NewAuthor(t)
+	chainMock := chainMocks.NewChain(t)
+	stateMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	submitter := NewSubmitter(authorMock, chainMock, stateMock, eventRetrieverMock)
+
+	xt := extrinsic.NewExtrinsic(types.Call{})
+
+	authorMock.On("SubmitAndWatchExtrinsicContext", mock.Anything, xt).
+		Return(nil, errors.New("error")).
+		Once()
+
+	res, err := submitter.SubmitAndWait(context.Background(), xt)
+	assert.ErrorIs(t, err, ErrExtrinsicSubmission)
+	assert.Nil(t, res)
+}
+
+func TestWaitForBlock(t *testing.T) {
+	inBlockHash := types.Hash{1}
+	retractedHash := types.Hash{2}
+	finalizedHash := types.Hash{3}
+
+	testCases := []struct {
+		Name           string
+		WaitFor        WaitFor
+		Statuses       []types.ExtrinsicStatus
+		ExpectedResult *InclusionResult
+		ExpectedErr    error
+	}{
+		{
+			Name:    "in block",
+			WaitFor: WaitForInBlock,
+			Statuses: []types.ExtrinsicStatus{
+				{IsReady: true},
+				{IsInBlock: true, AsInBlock: inBlockHash},
+			},
+			ExpectedResult: &InclusionResult{BlockHash: inBlockHash},
+		},
+		{
+			Name:    "finalized after retraction",
+			WaitFor: WaitForFinalized,
+			Statuses: []types.ExtrinsicStatus{
+				{IsReady: true},
+				{IsInBlock: true, AsInBlock: inBlockHash},
+				{IsRetracted: true, AsRetracted: retractedHash},
+				{IsInBlock: true, AsInBlock: finalizedHash},
+				{IsFinalized: true, AsFinalized: finalizedHash},
+			},
+			ExpectedResult: &InclusionResult{
+				BlockHash:   finalizedHash,
+				IsFinalized: true,
+				Retracted:   []types.Hash{retractedHash},
+			},
+		},
+		{
+			Name:    "finality timeout",
+			WaitFor: WaitForFinalized,
+			Statuses: []types.ExtrinsicStatus{
+				{IsInBlock: true, AsInBlock: inBlockHash},
+				{IsFinalityTimeout: true, AsFinalityTimeout: inBlockHash},
+			},
+			ExpectedErr: ErrFinalityTimeout,
+		},
+		{
+			Name:        "usurped",
+			WaitFor:     WaitForInBlock,
+			Statuses:    []types.ExtrinsicStatus{{IsUsurped: true}},
+			ExpectedErr: ErrExtrinsicUsurped,
+		},
+		{
+			Name:        "dropped",
+			WaitFor:     WaitForInBlock,
+			Statuses:    []types.ExtrinsicStatus{{IsDropped: true}},
+			ExpectedErr: ErrExtrinsicDropped,
+		},
+		{
+			Name:        "invalid",
+			WaitFor:     WaitForInBlock,
+			Statuses:    []types.ExtrinsicStatus{{IsInvalid: true}},
+			ExpectedErr: ErrExtrinsicInvalid,
+		},
+		{
+			Name:        "subscription closed",
+			WaitFor:     WaitForInBlock,
+			Statuses:    []types.ExtrinsicStatus{{IsReady: true}},
+			ExpectedErr: ErrSubscriptionClosed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			sub := newTestStatusSubscription(testCase.Statuses...)
+
+			var handledStatuses []types.ExtrinsicStatus
+
+			opts := NewDefaultOpts()
+
+			WithWaitFor(testCase.WaitFor)(opts)
+			WithStatusHandler(func(status types.ExtrinsicStatus) {
+				handledStatuses = append(handledStatuses, status)
+			})(opts)
+
+			res, err := waitForBlock(context.Background(), sub, opts)
+
+			if testCase.ExpectedErr != nil {
+				assert.ErrorIs(t, err, testCase.ExpectedErr)
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedResult, res)
+			assert.Equal(t, testCase.Statuses, handledStatuses)
+		})
+	}
+}
+
+func TestWaitForBlock_SubscriptionError(t *testing.T) {
+	sub := &testStatusSubscription{
+		ch:    make(chan types.ExtrinsicStatus),
+		errCh: make(chan error, 1),
+	}
+
+	sub.errCh <- errors.New("error")
+
+	res, err := waitForBlock(context.Background(), sub, NewDefaultOpts())
+	assert.ErrorIs(t, err, ErrSubscription)
+	assert.Nil(t, res)
+}
+
+func TestWaitForBlock_ContextDeadline(t *testing.T) {
+	sub := &testStatusSubscription{
+		ch:    make(chan types.ExtrinsicStatus),
+		errCh: make(chan error),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	res, err := waitForBlock(ctx, sub, NewDefaultOpts())
+	assert.ErrorIs(t, err, ErrWaitInterrupted)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, res)
+}
+
+func TestSubmitter_fillInclusionResult(t *testing.T) {
+	authorMock := authorMocks.NewAuthor(t)
+	chainMock := chainMocks.NewChain(t)
+	stateMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	s := &submitter{
+		authorRPC:      authorMock,
+		chainRPC:       chainMock,
+		stateRPC:       stateMock,
+		eventRetriever: eventRetrieverMock,
+	}
+
+	ctx := context.Background()
+	blockHash := types.Hash{1}
+	encodedExtrinsic := "0x0102"
+
+	chainMock.On("GetBlock", ctx, blockHash).
+		Return(newTestBlock("0x00", "0X0102"), nil).
+		Once()
+
+	fee := types.NewU128(*big.NewInt(1234))
+
+	events := []*parser.Event{
+		newTestEvent("System.ExtrinsicSuccess", 0, nil),
+		newTestEvent("Balances.Withdraw", 1, nil),
+		newTestEvent(
+			transactionPaymentFeePaidEventName,
+			1,
+			registry.DecodedFields{{Name: transactionPaymentFeePaidActualFeeFieldName, Value: fee}},
+		),
+		newTestEvent(systemExtrinsicSuccessEventName, 1, nil),
+	}
+
+	eventRetrieverMock.On("GetEvents", ctx, blockHash).
+		Return(events, nil).
+		Once()
+
+	res := &InclusionResult{BlockHash: blockHash}
+
+	err := s.fillInclusionResult(ctx, res, encodedExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), res.ExtrinsicIndex)
+	assert.Equal(t, events[1:], res.Events)
+	assert.True(t, res.IsSuccess)
+	assert.Nil(t, res.DispatchError)
+	assert.Equal(t, &fee, res.FeePaid)
+}
+
+func TestSubmitter_fillInclusionResult_ExtrinsicFailed(t *testing.T) {
+	authorMock := authorMocks.NewAuthor(t)
+	chainMock := chainMocks.NewChain(t)
+	stateMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	s := &submitter{
+		authorRPC:      authorMock,
+		chainRPC:       chainMock,
+		stateRPC:       stateMock,
+		eventRetriever: eventRetrieverMock,
+	}
+
+	ctx := context.Background()
+	blockHash := types.Hash{1}
+	encodedExtrinsic := "0x0102"
+	meta := getTestMetadata(t)
+
+	chainMock.On("GetBlock", ctx, blockHash).
+		Return(newTestBlock(encodedExtrinsic), nil).
+		Once()
+
+	events := []*parser.Event{
+		newTestEvent(systemExtrinsicFailedEventName, 0, decodeTestDispatchErrorFields(t, meta, []byte{2})),
+	}
+
+	eventRetrieverMock.On("GetEvents", ctx, blockHash).
+		Return(events, nil).
+		Once()
+
+	stateMock.On("GetMetadata", ctx, blockHash).
+		Return(meta, nil).
+		Once()
+
+	res := &InclusionResult{BlockHash: blockHash}
+
+	err := s.fillInclusionResult(ctx, res, encodedExtrinsic)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.ExtrinsicIndex)
+	assert.False(t, res.IsSuccess)
+	assert.Equal(t, &DispatchError{Name: "BadOrigin"}, res.DispatchError)
+	assert.Nil(t, res.FeePaid)
+}
+
+func TestSubmitter_fillInclusionResult_Errors(t *testing.T) {
+	authorMock := authorMocks.NewAuthor(t)
+	chainMock := chainMocks.NewChain(t)
+	stateMock := stateMocks.NewState(t)
+	eventRetrieverMock := retriever.NewEventRetrieverMock(t)
+
+	s := &submitter{
+		authorRPC:      authorMock,
+		chainRPC:       chainMock,
+		stateRPC:       stateMock,
+		eventRetriever: eventRetrieverMock,
+	}
+
+	ctx := context.Background()
+	blockHash := types.Hash{1}
+	encodedExtrinsic := "0x0102"
+
+	chainMock.On("GetBlock", ctx, blockHash).
+		Return(nil, errors.New("error")).
+		Once()
+
+	err := s.fillInclusionResult(ctx, &InclusionResult{BlockHash: blockHash}, encodedExtrinsic)
+	assert.ErrorIs(t, err, ErrBlockRetrieval)
+
+	chainMock.On("GetBlock", ctx, blockHash).
+		Return(newTestBlock("0x00"), nil).
+		Once()
+
+	err = s.fillInclusionResult(ctx, &InclusionResult{BlockHash: blockHash}, encodedExtrinsic)
+	assert.ErrorIs(t, err, ErrExtrinsicNotFound)
+
+	chainMock.On("GetBlock", ctx, blockHash).
+		Return(newTestBlock(encodedExtrinsic), nil).
+		Twice()
+
+	eventRetrieverMock.On("GetEvents", ctx, blockHash).
+		Return(nil, errors.New("error")).
+		Once()
+
+	err = s.fillInclusionResult(ctx, &InclusionResult{BlockHash: blockHash}, encodedExtrinsic)
+	assert.ErrorIs(t, err, ErrEventRetrieval)
+
+	eventRetrieverMock.On("GetEvents", ctx, blockHash).
+		Return([]*parser.Event{newTestEvent(systemExtrinsicSuccessEventName, 1, nil)}, nil).
+		Once()
+
+	err = s.fillInclusionResult(ctx, &InclusionResult{BlockHash: blockHash}, encodedExtrinsic)
+	assert.ErrorIs(t, err, ErrExtrinsicResultNotFound)
+}
+
+func newTestEvent(name string, extrinsicIndex uint32, fields registry.DecodedFields) *parser.Event {
+	return &parser.Event{
+		Name:   name,
+		Fields: fields,
+		Phase: &types.Phase{
+			IsApplyExtrinsic: true,
+			AsApplyExtrinsic: extrinsicIndex,
+		},
+	}
+}
+
+func newTestBlock(extrinsics ...string) *block.SignedBlock {
+	return &block.SignedBlock{
+		Block: block.Block{
+			Extrinsics: extrinsics,
+		},
+	}
+}
+
+type testStatusSubscription struct {
+	ch    chan types.ExtrinsicStatus
+	errCh chan error
+}
+
+// newTestStatusSubscription creates a subscription that delivers the provided statuses and is closed afterwards.
+func newTestStatusSubscription(statuses ...types.ExtrinsicStatus) *testStatusSubscription {
+	sub := &testStatusSubscription{
+		ch:    make(chan types.ExtrinsicStatus, len(statuses)),
+		errCh: make(chan error),
+	}
+
+	for _, status := range statuses {
+		sub.ch <- status
+	}
+
+	close(sub.ch)
+
+	return sub
+}
+
+func (s *testStatusSubscription) Chan() <-chan types.ExtrinsicStatus {
+	return s.ch
+}
+
+func (s *testStatusSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *testStatusSubscription) Unsubscribe() {}
@@ -0,0 +1,100 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Archive --filename archive.go
+
+package archive
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	// ErrBlockNotFound is returned when the node does not know the queried block.
+	ErrBlockNotFound = errors.New("block not found")
+	// ErrCallFailed is returned when a runtime call failed.
+	ErrCallFailed = errors.New("runtime call failed")
+	// ErrStorageFailed is returned when the node failed to query the storage.
+	ErrStorageFailed = errors.New("storage query failed")
+)
+
+// Archive exposes the archive_v1 methods of the Substrate JSON-RPC spec, which query any block of an archive node,
+// including the blocks that are not finalized yet.
+type Archive interface {
+	// Body returns the SCALE encoded extrinsics of the block.
+	Body(ctx context.Context, blockHash types.Hash) ([]types.Bytes, error)
+	// Header returns the header of the block.
+	Header(ctx context.Context, blockHash types.Hash) (*types.Header, error)
+	// FinalizedHeight returns the height of the last finalized block.
+	FinalizedHeight(ctx context.Context) (uint64, error)
+	// HashByHeight returns the hashes of the blocks at the height, several blocks are returned for the heights
+	// that are not finalized yet.
+	HashByHeight(ctx context.Context, height uint64) ([]types.Hash, error)
+	// GenesisHash returns the hash of the genesis block.
+	GenesisHash(ctx context.Context) (types.Hash, error)
+	// Call calls a runtime function with the SCALE encoded parameters at the block, and returns its SCALE encoded
+	// output.
+	Call(ctx context.Context, blockHash types.Hash, function string, params []byte) (types.Bytes, error)
+	// Storage queries the storage of the block, in the child trie if childTrie is not nil.
+	Storage(
+		ctx context.Context,
+		blockHash types.Hash,
+		items []StorageQueryItem,
+		childTrie *types.StorageKey,
+	) ([]StorageResult, error)
+	// StoragePages queries the storage of the block like Storage, but calls fn with pages of at most pageSize
+	// results as soon as they are received instead of collecting them.
+	StoragePages(
+		ctx context.Context,
+		blockHash types.Hash,
+		items []StorageQueryItem,
+		childTrie *types.StorageKey,
+		pageSize int,
+		fn func([]StorageResult) error,
+	) error
+	// StorageDiff returns the differences of the storage of the block with the storage of previousBlockHash, or
+	// with the storage of its parent if previousBlockHash is nil.
+	StorageDiff(
+		ctx context.Context,
+		blockHash types.Hash,
+		items []StorageDiffItem,
+		previousBlockHash *types.Hash,
+	) ([]StorageDiffResult, error)
+	// StorageDiffPages returns the differences of the storage like StorageDiff, but calls fn with pages of at most
+	// pageSize results as soon as they are received instead of collecting them.
+	StorageDiffPages(
+		ctx context.Context,
+		blockHash types.Hash,
+		items []StorageDiffItem,
+		previousBlockHash *types.Hash,
+		pageSize int,
+		fn func([]StorageDiffResult) error,
+	) error
+}
+
+// archive exposes methods for querying the blocks of an archive node
+type archive struct {
+	client client.Client
+}
+
+// NewArchive creates a new archive struct
+func NewArchive(cl client.Client) Archive {
+	return &archive{cl}
+}
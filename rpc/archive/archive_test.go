@@ -0,0 +1,262 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var testArchive Archive
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("archive", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.RegisterSubscription("archive_v1_storage", "")
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.RegisterSubscription("archive_v1_storageDiff", "")
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testArchive = NewArchive(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	blockHash       types.Hash
+	parentBlockHash types.Hash
+	unknownHash     types.Hash
+	finalizedHeight uint64
+	genesisHash     types.Hash
+	body            []string
+	storage         map[string]string
+	parentStorage   map[string]string
+}
+
+var mockSrv = MockSrv{
+	blockHash:       types.NewHash([]byte{0x02}),
+	parentBlockHash: types.NewHash([]byte{0x01}),
+	unknownHash:     types.NewHash([]byte{0xff}),
+	finalizedHeight: 2,
+	genesisHash:     types.NewHash([]byte{0x00}),
+	body:            []string{"0x0102", "0x0304"},
+	storage: map[string]string{
+		"0x01":   "0xaa",
+		"0x0201": "0xbb",
+		"0x0202": "0xcc",
+		"0x0203": "0xdd",
+		"0x03":   "0xee",
+	},
+	parentStorage: map[string]string{
+		"0x01":   "0xaa",
+		"0x0201": "0x00",
+		"0x0204": "0xff",
+		"0x03":   "0xee",
+	},
+}
+
+type storageQueryItem struct {
+	Key        string `json:"key"`
+	Type       string `json:"type"`
+	ReturnType string `json:"returnType"`
+}
+
+func (s *MockSrv) V1_body(hash types.Hash) *[]string {
+	if hash != mockSrv.blockHash {
+		return nil
+	}
+
+	return &mockSrv.body
+}
+
+func (s *MockSrv) V1_header(hash types.Hash) (*string, error) {
+	if hash != mockSrv.blockHash {
+		return nil, nil
+	}
+
+	header, err := codec.EncodeToHex(types.Header{ParentHash: mockSrv.parentBlockHash, Number: 2})
+	if err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+func (s *MockSrv) V1_finalizedHeight() uint64 {
+	return mockSrv.finalizedHeight
+}
+
+func (s *MockSrv) V1_hashByHeight(height uint64) []types.Hash {
+	if height != 2 {
+		return []types.Hash{}
+	}
+
+	return []types.Hash{mockSrv.blockHash, mockSrv.unknownHash}
+}
+
+func (s *MockSrv) V1_genesisHash() types.Hash {
+	return mockSrv.genesisHash
+}
+
+func (s *MockSrv) V1_call(hash types.Hash, function, params string) map[string]interface{} {
+	if function == "Test_fail" {
+		return map[string]interface{}{"success": false, "error": "wasm trap"}
+	}
+
+	return map[string]interface{}{"success": true, "value": params + "ff"}
+}
+
+func (s *MockSrv) V1_storage(
+	ctx context.Context,
+	hash types.Hash,
+	items []storageQueryItem,
+	childTrie *string,
+) (*gethrpc.Subscription, error) {
+	notifier, ok := gethrpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+
+	if hash != mockSrv.blockHash {
+		_ = notifier.Notify(sub.ID, map[string]interface{}{"event": "storageError", "error": "unknown block"})
+
+		return sub, nil
+	}
+
+	for _, item := range items {
+		var keys []string
+
+		switch item.Type {
+		case "value":
+			if _, ok := mockSrv.storage[item.Key]; ok {
+				keys = append(keys, item.Key)
+			}
+		case "descendantsValues":
+			keys = sortedKeys(mockSrv.storage, item.Key)
+		}
+
+		for _, key := range keys {
+			ev := map[string]interface{}{"event": "storage", "key": key, "value": mockSrv.storage[key]}
+
+			if childTrie != nil {
+				ev["childTrieKey"] = *childTrie
+			}
+
+			_ = notifier.Notify(sub.ID, ev)
+		}
+	}
+
+	_ = notifier.Notify(sub.ID, map[string]interface{}{"event": "storageDone"})
+
+	return sub, nil
+}
+
+func (s *MockSrv) V1_stopStorage(id string) {}
+
+func (s *MockSrv) V1_storageDiff(
+	ctx context.Context,
+	hash types.Hash,
+	items []storageQueryItem,
+	previousHash *types.Hash,
+) (*gethrpc.Subscription, error) {
+	notifier, ok := gethrpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+
+	if previousHash != nil && *previousHash != mockSrv.parentBlockHash {
+		_ = notifier.Notify(sub.ID, map[string]interface{}{"event": "storageDiffError", "error": "unknown block"})
+
+		return sub, nil
+	}
+
+	for _, item := range items {
+		for _, key := range sortedKeys(mockSrv.storage, item.Key) {
+			previous, ok := mockSrv.parentStorage[key]
+
+			switch {
+			case !ok:
+				notifyStorageDiff(notifier, sub.ID, key, "added", mockSrv.storage[key])
+			case previous != mockSrv.storage[key]:
+				notifyStorageDiff(notifier, sub.ID, key, "modified", mockSrv.storage[key])
+			}
+		}
+
+		for _, key := range sortedKeys(mockSrv.parentStorage, item.Key) {
+			if _, ok := mockSrv.storage[key]; !ok {
+				notifyStorageDiff(notifier, sub.ID, key, "deleted", "")
+			}
+		}
+	}
+
+	_ = notifier.Notify(sub.ID, map[string]interface{}{"event": "storageDiffDone"})
+
+	return sub, nil
+}
+
+func (s *MockSrv) V1_storageDiff_stopStorageDiff(id string) {}
+
+func notifyStorageDiff(notifier *gethrpc.Notifier, id gethrpc.ID, key, diffType, value string) {
+	ev := map[string]interface{}{"event": "storageDiff", "key": key, "type": diffType}
+
+	if value != "" {
+		ev["value"] = value
+	}
+
+	_ = notifier.Notify(id, ev)
+}
+
+func sortedKeys(storage map[string]string, prefix string) []string {
+	var keys []string
+
+	for key := range storage {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,55 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// callResult is the result of archive_v1_call.
+type callResult struct {
+	Success bool   `json:"success"`
+	Value   string `json:"value"`
+	Error   string `json:"error"`
+}
+
+// Call calls a runtime function with the SCALE encoded parameters at the block, and returns its SCALE encoded
+// output.
+func (a *archive) Call(ctx context.Context, blockHash types.Hash, function string, params []byte) (types.Bytes, error) {
+	var res *callResult
+
+	err := a.client.CallContext(
+		ctx, &res, "archive_v1_call", blockHash.Hex(), function, codec.HexEncodeToString(params),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBlockNotFound, blockHash.Hex())
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%w: %s", ErrCallFailed, res.Error)
+	}
+
+	return codec.HexDecodeString(res.Value)
+}
@@ -0,0 +1,31 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// HashByHeight returns the hashes of the blocks at the height, several blocks are returned for the heights that are
+// not finalized yet. No block is returned for the heights above the best block.
+func (a *archive) HashByHeight(ctx context.Context, height uint64) ([]types.Hash, error) {
+	var hashes []types.Hash
+	err := a.client.CallContext(ctx, &hashes, "archive_v1_hashByHeight", height)
+	return hashes, err
+}
@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Header returns the header of the block.
+func (a *archive) Header(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	var res *string
+
+	if err := a.client.CallContext(ctx, &res, "archive_v1_header", blockHash.Hex()); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBlockNotFound, blockHash.Hex())
+	}
+
+	var header types.Header
+
+	if err := codec.DecodeFromHex(*res, &header); err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
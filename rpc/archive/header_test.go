@@ -0,0 +1,36 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestArchive_Header(t *testing.T) {
+	header, err := testArchive.Header(context.Background(), mockSrv.blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(2), header.Number)
+	assert.Equal(t, mockSrv.parentBlockHash, header.ParentHash)
+
+	_, err = testArchive.Header(context.Background(), mockSrv.unknownHash)
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Storage queries the storage of the block, in the child trie if childTrie is not nil. The descendants queries
+// return all the keys that start with the queried key.
+func (a *archive) Storage(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []StorageQueryItem,
+	childTrie *types.StorageKey,
+) ([]StorageResult, error) {
+	var results []StorageResult
+
+	err := a.StoragePages(ctx, blockHash, items, childTrie, 0, func(page []StorageResult) error {
+		results = page
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// StoragePages queries the storage of the block like Storage, but calls fn with pages of at most pageSize results
+// as soon as they are received instead of collecting them, which bounds the memory used by large descendants
+// queries. The query is stopped if fn returns an error.
+func (a *archive) StoragePages(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []StorageQueryItem,
+	childTrie *types.StorageKey,
+	pageSize int,
+	fn func([]StorageResult) error,
+) error {
+	var childTrieHex *string
+
+	if childTrie != nil {
+		hex := childTrie.Hex()
+		childTrieHex = &hex
+	}
+
+	return streamPages(ctx, a.client, storageQuerySubscription, pageSize, fn, blockHash.Hex(), items, childTrieHex)
+}
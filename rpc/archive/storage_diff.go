@@ -0,0 +1,68 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// StorageDiff returns the differences of the storage of the block with the storage of previousBlockHash, or with
+// the storage of its parent if previousBlockHash is nil.
+func (a *archive) StorageDiff(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []StorageDiffItem,
+	previousBlockHash *types.Hash,
+) ([]StorageDiffResult, error) {
+	var results []StorageDiffResult
+
+	err := a.StorageDiffPages(ctx, blockHash, items, previousBlockHash, 0, func(page []StorageDiffResult) error {
+		results = page
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// StorageDiffPages returns the differences of the storage like StorageDiff, but calls fn with pages of at most
+// pageSize results as soon as they are received instead of collecting them. The query is stopped if fn returns an
+// error.
+func (a *archive) StorageDiffPages(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []StorageDiffItem,
+	previousBlockHash *types.Hash,
+	pageSize int,
+	fn func([]StorageDiffResult) error,
+) error {
+	var previousBlockHashHex *string
+
+	if previousBlockHash != nil {
+		hex := previousBlockHash.Hex()
+		previousBlockHashHex = &hex
+	}
+
+	return streamPages(
+		ctx, a.client, storageDiffSubscription, pageSize, fn, blockHash.Hex(), items, previousBlockHashHex,
+	)
+}
@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestArchive_StorageDiff(t *testing.T) {
+	items := []StorageDiffItem{
+		{Key: types.NewStorageKey([]byte{0x02}), ReturnType: StorageQueryValue},
+	}
+
+	results, err := testArchive.StorageDiff(context.Background(), mockSrv.blockHash, items, nil)
+	assert.NoError(t, err)
+
+	bb := types.NewStorageDataRaw([]byte{0xbb})
+	cc := types.NewStorageDataRaw([]byte{0xcc})
+	dd := types.NewStorageDataRaw([]byte{0xdd})
+
+	assert.Equal(t, []StorageDiffResult{
+		{Key: types.NewStorageKey([]byte{0x02, 0x01}), Type: StorageDiffModified, Value: &bb},
+		{Key: types.NewStorageKey([]byte{0x02, 0x02}), Type: StorageDiffAdded, Value: &cc},
+		{Key: types.NewStorageKey([]byte{0x02, 0x03}), Type: StorageDiffAdded, Value: &dd},
+		{Key: types.NewStorageKey([]byte{0x02, 0x04}), Type: StorageDiffDeleted},
+	}, results)
+
+	var pages int
+
+	err = testArchive.StorageDiffPages(context.Background(), mockSrv.blockHash, items, &mockSrv.parentBlockHash, 3,
+		func(page []StorageDiffResult) error {
+			pages++
+
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pages)
+
+	_, err = testArchive.StorageDiff(context.Background(), mockSrv.blockHash, items, &mockSrv.unknownHash)
+	assert.ErrorIs(t, err, ErrStorageFailed)
+}
+
+func TestStorageDiffItem_MarshalJSON(t *testing.T) {
+	childTrie := types.NewStorageKey([]byte{0x0c})
+	item := StorageDiffItem{Key: types.NewStorageKey([]byte{0x01}), ReturnType: StorageQueryHash, ChildTrieKey: &childTrie}
+
+	bz, err := item.MarshalJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"0x01","returnType":"hash","childTrieKey":"0x0c"}`, string(bz))
+}
@@ -0,0 +1,91 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func storageResultKeys(results []StorageResult) []string {
+	keys := make([]string, len(results))
+
+	for i, res := range results {
+		keys[i] = res.Key.Hex()
+	}
+
+	return keys
+}
+
+func TestArchive_Storage(t *testing.T) {
+	items := []StorageQueryItem{
+		{Key: types.NewStorageKey([]byte{0x01}), Type: StorageQueryValue},
+		{Key: types.NewStorageKey([]byte{0x02}), Type: StorageQueryDescendantsValues},
+		{Key: types.NewStorageKey([]byte{0x04}), Type: StorageQueryValue},
+	}
+
+	results, err := testArchive.Storage(context.Background(), mockSrv.blockHash, items, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x01", "0x0201", "0x0202", "0x0203"}, storageResultKeys(results))
+	assert.Equal(t, types.NewStorageDataRaw([]byte{0xaa}), *results[0].Value)
+	assert.Nil(t, results[0].Hash)
+	assert.Nil(t, results[0].ChildTrieKey)
+
+	childTrie := types.NewStorageKey([]byte{0x0c})
+
+	results, err = testArchive.Storage(context.Background(), mockSrv.blockHash, items[:1], &childTrie)
+	assert.NoError(t, err)
+	assert.Equal(t, &childTrie, results[0].ChildTrieKey)
+
+	_, err = testArchive.Storage(context.Background(), mockSrv.unknownHash, items, nil)
+	assert.ErrorIs(t, err, ErrStorageFailed)
+	assert.ErrorContains(t, err, "unknown block")
+}
+
+func TestArchive_StoragePages(t *testing.T) {
+	items := []StorageQueryItem{
+		{Key: types.NewStorageKey([]byte{}), Type: StorageQueryDescendantsValues},
+	}
+
+	var pages [][]string
+
+	err := testArchive.StoragePages(context.Background(), mockSrv.blockHash, items, nil, 2,
+		func(page []StorageResult) error {
+			pages = append(pages, storageResultKeys(page))
+
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"0x01", "0x0201"}, {"0x0202", "0x0203"}, {"0x03"}}, pages)
+
+	errStop := errors.New("stop")
+	calls := 0
+
+	err = testArchive.StoragePages(context.Background(), mockSrv.blockHash, items, nil, 2,
+		func(page []StorageResult) error {
+			calls++
+
+			return errStop
+		})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,219 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// StorageQueryType is the type of a storage query item.
+type StorageQueryType string
+
+const (
+	// StorageQueryValue queries the value of the key.
+	StorageQueryValue StorageQueryType = "value"
+	// StorageQueryHash queries the hash of the value of the key.
+	StorageQueryHash StorageQueryType = "hash"
+	// StorageQueryClosestDescendantMerkleValue queries the Merkle value of the closest descendant of the key.
+	StorageQueryClosestDescendantMerkleValue StorageQueryType = "closestDescendantMerkleValue"
+	// StorageQueryDescendantsValues queries the values of all the keys that start with the key.
+	StorageQueryDescendantsValues StorageQueryType = "descendantsValues"
+	// StorageQueryDescendantsHashes queries the hashes of the values of all the keys that start with the key.
+	StorageQueryDescendantsHashes StorageQueryType = "descendantsHashes"
+)
+
+// StorageQueryItem is an item of a storage query.
+type StorageQueryItem struct {
+	Key  types.StorageKey
+	Type StorageQueryType
+}
+
+// MarshalJSON returns a JSON encoded StorageQueryItem, with a hex encoded key.
+func (i StorageQueryItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Key  string           `json:"key"`
+		Type StorageQueryType `json:"type"`
+	}{
+		Key:  i.Key.Hex(),
+		Type: i.Type,
+	})
+}
+
+// StorageResult is an item of the result of a storage query. Only the field of the queried type is set.
+type StorageResult struct {
+	Key                          types.StorageKey
+	Value                        *types.StorageDataRaw
+	Hash                         *types.Hash
+	ClosestDescendantMerkleValue *types.Bytes
+	// ChildTrieKey is the key of the child trie that holds the key, if the query was made in a child trie.
+	ChildTrieKey *types.StorageKey
+}
+
+// UnmarshalJSON fills StorageResult with the JSON encoded byte array given by b, whose fields are hex encoded
+func (r *StorageResult) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Key                          string      `json:"key"`
+		Value                        *string     `json:"value"`
+		Hash                         *types.Hash `json:"hash"`
+		ClosestDescendantMerkleValue *string     `json:"closestDescendantMerkleValue"`
+		ChildTrieKey                 *string     `json:"childTrieKey"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	key, err := codec.HexDecodeString(tmp.Key)
+	if err != nil {
+		return err
+	}
+
+	*r = StorageResult{Key: key, Hash: tmp.Hash}
+
+	if r.Value, err = decodeStorageData(tmp.Value); err != nil {
+		return err
+	}
+
+	if tmp.ClosestDescendantMerkleValue != nil {
+		merkleValue, err := codec.HexDecodeString(*tmp.ClosestDescendantMerkleValue)
+		if err != nil {
+			return err
+		}
+
+		bz := types.NewBytes(merkleValue)
+		r.ClosestDescendantMerkleValue = &bz
+	}
+
+	r.ChildTrieKey, err = decodeStorageKey(tmp.ChildTrieKey)
+
+	return err
+}
+
+// StorageDiffType is the type of the change of a key.
+type StorageDiffType string
+
+const (
+	// StorageDiffAdded reports a key that was added.
+	StorageDiffAdded StorageDiffType = "added"
+	// StorageDiffModified reports a key whose value was modified.
+	StorageDiffModified StorageDiffType = "modified"
+	// StorageDiffDeleted reports a key that was deleted.
+	StorageDiffDeleted StorageDiffType = "deleted"
+)
+
+// StorageDiffItem is an item of a storage diff query, which reports the changes of all the keys that start with the
+// key.
+type StorageDiffItem struct {
+	Key types.StorageKey
+	// ReturnType is either StorageQueryValue or StorageQueryHash.
+	ReturnType StorageQueryType
+	// ChildTrieKey is the key of the child trie to query, or nil to query the main trie.
+	ChildTrieKey *types.StorageKey
+}
+
+// MarshalJSON returns a JSON encoded StorageDiffItem, with hex encoded keys.
+func (i StorageDiffItem) MarshalJSON() ([]byte, error) {
+	var childTrieKey *string
+
+	if i.ChildTrieKey != nil {
+		hex := i.ChildTrieKey.Hex()
+		childTrieKey = &hex
+	}
+
+	return json.Marshal(struct {
+		Key          string           `json:"key"`
+		ReturnType   StorageQueryType `json:"returnType"`
+		ChildTrieKey *string          `json:"childTrieKey,omitempty"`
+	}{
+		Key:          i.Key.Hex(),
+		ReturnType:   i.ReturnType,
+		ChildTrieKey: childTrieKey,
+	})
+}
+
+// StorageDiffResult is a change of a key. Only the field of the queried return type is set, and none of them is
+// set for the deleted keys.
+type StorageDiffResult struct {
+	Key          types.StorageKey
+	Type         StorageDiffType
+	Value        *types.StorageDataRaw
+	Hash         *types.Hash
+	ChildTrieKey *types.StorageKey
+}
+
+// UnmarshalJSON fills StorageDiffResult with the JSON encoded byte array given by b, whose fields are hex encoded
+func (r *StorageDiffResult) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Key          string          `json:"key"`
+		Type         StorageDiffType `json:"type"`
+		Value        *string         `json:"value"`
+		Hash         *types.Hash     `json:"hash"`
+		ChildTrieKey *string         `json:"childTrieKey"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	key, err := codec.HexDecodeString(tmp.Key)
+	if err != nil {
+		return err
+	}
+
+	*r = StorageDiffResult{Key: key, Type: tmp.Type, Hash: tmp.Hash}
+
+	if r.Value, err = decodeStorageData(tmp.Value); err != nil {
+		return err
+	}
+
+	r.ChildTrieKey, err = decodeStorageKey(tmp.ChildTrieKey)
+
+	return err
+}
+
+func decodeStorageData(hex *string) (*types.StorageDataRaw, error) {
+	if hex == nil {
+		return nil, nil
+	}
+
+	bz, err := codec.HexDecodeString(*hex)
+	if err != nil {
+		return nil, err
+	}
+
+	data := types.NewStorageDataRaw(bz)
+
+	return &data, nil
+}
+
+func decodeStorageKey(hex *string) (*types.StorageKey, error) {
+	if hex == nil {
+		return nil, nil
+	}
+
+	bz, err := codec.HexDecodeString(*hex)
+	if err != nil {
+		return nil, err
+	}
+
+	key := types.NewStorageKey(bz)
+
+	return &key, nil
+}
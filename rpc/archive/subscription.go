@@ -0,0 +1,138 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// storageEvent is the common part of the events of the storage and storage diff subscriptions.
+type storageEvent struct {
+	Event string `json:"event"`
+	Error string `json:"error"`
+}
+
+// storageSubscription describes a subscription that streams the items of a storage query, followed by a done or
+// an error event.
+type storageSubscription struct {
+	subscribeMethodSuffix    string
+	unsubscribeMethodSuffix  string
+	notificationMethodSuffix string
+	itemEvent                string
+	errorEvent               string
+	doneEvent                string
+}
+
+var (
+	storageQuerySubscription = storageSubscription{
+		subscribeMethodSuffix:    "v1_storage",
+		unsubscribeMethodSuffix:  "v1_stopStorage",
+		notificationMethodSuffix: "v1_storageEvent",
+		itemEvent:                "storage",
+		errorEvent:               "storageError",
+		doneEvent:                "storageDone",
+	}
+	storageDiffSubscription = storageSubscription{
+		subscribeMethodSuffix:    "v1_storageDiff",
+		unsubscribeMethodSuffix:  "v1_storageDiff_stopStorageDiff",
+		notificationMethodSuffix: "v1_storageDiffEvent",
+		itemEvent:                "storageDiff",
+		errorEvent:               "storageDiffError",
+		doneEvent:                "storageDiffDone",
+	}
+)
+
+// streamPages subscribes to the storage subscription and calls fn with pages of at most pageSize items, until the
+// done event. The subscription is stopped if fn returns an error or the context is done. A pageSize of 0 or less
+// means that all the items are delivered in a single page.
+func streamPages[T any](
+	ctx context.Context,
+	cl client.Client,
+	s storageSubscription,
+	pageSize int,
+	fn func([]T) error,
+	args ...interface{},
+) error {
+	subCtx, cancel := client.SubscribeContext(ctx)
+	defer cancel()
+
+	ch := make(chan json.RawMessage)
+
+	sub, err := cl.Subscribe(
+		subCtx, "archive", s.subscribeMethodSuffix, s.unsubscribeMethodSuffix, s.notificationMethodSuffix, ch, args...,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer sub.Unsubscribe()
+
+	var page []T
+
+	for {
+		select {
+		case raw := <-ch:
+			var ev storageEvent
+
+			if err := json.Unmarshal(raw, &ev); err != nil {
+				return err
+			}
+
+			switch ev.Event {
+			case s.itemEvent:
+				var item T
+
+				if err := json.Unmarshal(raw, &item); err != nil {
+					return err
+				}
+
+				page = append(page, item)
+
+				if pageSize > 0 && len(page) == pageSize {
+					if err := fn(page); err != nil {
+						return err
+					}
+
+					page = nil
+				}
+			case s.errorEvent:
+				return fmt.Errorf("%w: %s", ErrStorageFailed, ev.Error)
+			case s.doneEvent:
+				if len(page) == 0 {
+					return nil
+				}
+
+				return fn(page)
+			default:
+				return fmt.Errorf("archive_%s: unknown event %q", s.subscribeMethodSuffix, ev.Event)
+			}
+		case err := <-sub.Err():
+			if err == nil {
+				err = gethrpc.ErrClientQuit
+			}
+
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
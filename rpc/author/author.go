@@ -19,6 +19,8 @@
 package author
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
@@ -28,6 +30,16 @@ type Author interface {
 	SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*ExtrinsicStatusSubscription, error)
 	SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error)
 	PendingExtrinsics() ([]string, error)
+
+	SubmitAndWatchExtrinsicContext(ctx context.Context, xt extrinsic.Extrinsic) (*ExtrinsicStatusSubscription, error)
+	SubmitExtrinsicContext(ctx context.Context, xt extrinsic.Extrinsic) (types.Hash, error)
+	PendingExtrinsicsContext(ctx context.Context) ([]string, error)
+
+	RotateKeys(ctx context.Context) (types.Bytes, error)
+	InsertKey(ctx context.Context, keyType types.KeyTypeID, suri string, publicKey []byte) error
+	HasKey(ctx context.Context, publicKey []byte, keyType types.KeyTypeID) (bool, error)
+	HasSessionKeys(ctx context.Context, sessionKeys []byte) (bool, error)
+	RemoveExtrinsic(ctx context.Context, extrinsics []ExtrinsicOrHash) ([]types.Hash, error)
 }
 
 // author exposes methods for authoring of network items
@@ -0,0 +1,75 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockAuthor creates an Author connected to an RPC Mock Server that serves the provided mock. It is used for
+// testing key management calls that are not available on a shared test node.
+func newMockAuthor(t *testing.T, authorSrv *mockAuthorSrv) author.Author {
+	s := rpcmocksrv.New()
+
+	err := s.RegisterName("author", authorSrv)
+	assert.NoError(t, err)
+
+	cl, err := client.Connect(s.URL)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		cl.Close()
+		s.Stop()
+	})
+
+	return author.NewAuthor(cl)
+}
+
+// mockAuthorSrv holds data and methods exposed under the author namespace by the RPC Mock Server
+type mockAuthorSrv struct {
+	sessionKeysHex  string
+	insertedKeys    map[string]string
+	removedHashes   []string
+	removeExtrinsic []map[string]string
+}
+
+func (s *mockAuthorSrv) RotateKeys() string {
+	return s.sessionKeysHex
+}
+
+func (s *mockAuthorSrv) InsertKey(keyType, suri, publicKey string) {
+	s.insertedKeys[keyType+publicKey] = suri
+}
+
+func (s *mockAuthorSrv) HasKey(publicKey, keyType string) bool {
+	_, ok := s.insertedKeys[keyType+publicKey]
+	return ok
+}
+
+func (s *mockAuthorSrv) HasSessionKeys(sessionKeys string) bool {
+	return sessionKeys == s.sessionKeysHex
+}
+
+func (s *mockAuthorSrv) RemoveExtrinsic(extrinsics []map[string]string) []string {
+	s.removeExtrinsic = extrinsics
+	return s.removedHashes
+}
@@ -0,0 +1,35 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// HasSessionKeys checks if the keystore of the node has the private keys for all the SCALE encoded session keys,
+// as returned by RotateKeys
+func (a *author) HasSessionKeys(ctx context.Context, sessionKeys []byte) (bool, error) {
+	var res bool
+	err := a.client.CallContext(ctx, &res, "author_hasSessionKeys", codec.HexEncodeToString(sessionKeys))
+	if err != nil {
+		return false, err
+	}
+
+	return res, nil
+}
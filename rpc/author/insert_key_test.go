@@ -0,0 +1,49 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthor_InsertKey_HasKey(t *testing.T) {
+	authorSrv := &mockAuthorSrv{insertedKeys: map[string]string{}}
+	testAuthor := newMockAuthor(t, authorSrv)
+
+	publicKey := []byte{1, 2, 3}
+	keyType := types.NewKeyTypeID("gran")
+
+	ok, err := testAuthor.HasKey(context.Background(), publicKey, keyType)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = testAuthor.InsertKey(context.Background(), keyType, "//Alice", publicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"gran0x010203": "//Alice"}, authorSrv.insertedKeys)
+
+	ok, err = testAuthor.HasKey(context.Background(), publicKey, keyType)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = testAuthor.HasKey(context.Background(), publicKey, types.NewKeyTypeID("babe"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	author "github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
 	extrinsic "github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 
@@ -16,6 +18,62 @@ type Author struct {
 	mock.Mock
 }
 
+// HasKey provides a mock function with given fields: ctx, publicKey, keyType
+func (_m *Author) HasKey(ctx context.Context, publicKey []byte, keyType types.KeyTypeID) (bool, error) {
+	ret := _m.Called(ctx, publicKey, keyType)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, types.KeyTypeID) bool); ok {
+		r0 = rf(ctx, publicKey, keyType)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, types.KeyTypeID) error); ok {
+		r1 = rf(ctx, publicKey, keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasSessionKeys provides a mock function with given fields: ctx, sessionKeys
+func (_m *Author) HasSessionKeys(ctx context.Context, sessionKeys []byte) (bool, error) {
+	ret := _m.Called(ctx, sessionKeys)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) bool); ok {
+		r0 = rf(ctx, sessionKeys)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, sessionKeys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertKey provides a mock function with given fields: ctx, keyType, suri, publicKey
+func (_m *Author) InsertKey(ctx context.Context, keyType types.KeyTypeID, suri string, publicKey []byte) error {
+	ret := _m.Called(ctx, keyType, suri, publicKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.KeyTypeID, string, []byte) error); ok {
+		r0 = rf(ctx, keyType, suri, publicKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PendingExtrinsics provides a mock function with given fields:
 func (_m *Author) PendingExtrinsics() ([]string, error) {
 	ret := _m.Called()
@@ -39,6 +97,75 @@ func (_m *Author) PendingExtrinsics() ([]string, error) {
 	return r0, r1
 }
 
+// PendingExtrinsicsContext provides a mock function with given fields: ctx
+func (_m *Author) PendingExtrinsicsContext(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveExtrinsic provides a mock function with given fields: ctx, extrinsics
+func (_m *Author) RemoveExtrinsic(ctx context.Context, extrinsics []author.ExtrinsicOrHash) ([]types.Hash, error) {
+	ret := _m.Called(ctx, extrinsics)
+
+	var r0 []types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, []author.ExtrinsicOrHash) []types.Hash); ok {
+		r0 = rf(ctx, extrinsics)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []author.ExtrinsicOrHash) error); ok {
+		r1 = rf(ctx, extrinsics)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateKeys provides a mock function with given fields: ctx
+func (_m *Author) RotateKeys(ctx context.Context) (types.Bytes, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Bytes
+	if rf, ok := ret.Get(0).(func(context.Context) types.Bytes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitAndWatchExtrinsic provides a mock function with given fields: xt
 func (_m *Author) SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
 	ret := _m.Called(xt)
@@ -62,6 +189,29 @@ func (_m *Author) SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*author.Extri
 	return r0, r1
 }
 
+// SubmitAndWatchExtrinsicContext provides a mock function with given fields: ctx, xt
+func (_m *Author) SubmitAndWatchExtrinsicContext(ctx context.Context, xt extrinsic.Extrinsic) (*author.ExtrinsicStatusSubscription, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 *author.ExtrinsicStatusSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, extrinsic.Extrinsic) *author.ExtrinsicStatusSubscription); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*author.ExtrinsicStatusSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, extrinsic.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitExtrinsic provides a mock function with given fields: xt
 func (_m *Author) SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error) {
 	ret := _m.Called(xt)
@@ -85,6 +235,29 @@ func (_m *Author) SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error) {
 	return r0, r1
 }
 
+// SubmitExtrinsicContext provides a mock function with given fields: ctx, xt
+func (_m *Author) SubmitExtrinsicContext(ctx context.Context, xt extrinsic.Extrinsic) (types.Hash, error) {
+	ret := _m.Called(ctx, xt)
+
+	var r0 types.Hash
+	if rf, ok := ret.Get(0).(func(context.Context, extrinsic.Extrinsic) types.Hash); ok {
+		r0 = rf(ctx, xt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, extrinsic.Extrinsic) error); ok {
+		r1 = rf(ctx, xt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewAuthorT interface {
 	mock.TestingT
 	Cleanup(func())
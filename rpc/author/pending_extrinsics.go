@@ -16,10 +16,17 @@
 
 package author
 
+import "context"
+
 // PendingExtrinsics returns all pending extrinsics.
 func (a *author) PendingExtrinsics() ([]string, error) {
+	return a.PendingExtrinsicsContext(context.Background())
+}
+
+// PendingExtrinsicsContext returns all pending extrinsics, using the provided context.
+func (a *author) PendingExtrinsicsContext(ctx context.Context) ([]string, error) {
 	var extrinsics []string
-	err := a.client.Call(&extrinsics, "author_pendingExtrinsics")
+	err := a.client.CallContext(ctx, &extrinsics, "author_pendingExtrinsics")
 	if err != nil {
 		return nil, err
 	}
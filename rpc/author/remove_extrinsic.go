@@ -0,0 +1,80 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+)
+
+// ExtrinsicOrHash identifies a pending extrinsic either by its hash or by its SCALE encoded bytes
+type ExtrinsicOrHash struct {
+	IsHash      bool
+	AsHash      types.Hash
+	IsExtrinsic bool
+	AsExtrinsic types.Bytes
+}
+
+// NewExtrinsicOrHashFromHash creates a new ExtrinsicOrHash that identifies an extrinsic by its hash
+func NewExtrinsicOrHashFromHash(hash types.Hash) ExtrinsicOrHash {
+	return ExtrinsicOrHash{IsHash: true, AsHash: hash}
+}
+
+// NewExtrinsicOrHashFromExtrinsic creates a new ExtrinsicOrHash that identifies an extrinsic by its encoded bytes
+func NewExtrinsicOrHashFromExtrinsic(xt extrinsic.Extrinsic) (ExtrinsicOrHash, error) {
+	b, err := codec.Encode(xt)
+	if err != nil {
+		return ExtrinsicOrHash{}, err
+	}
+
+	return ExtrinsicOrHash{IsExtrinsic: true, AsExtrinsic: b}, nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of ExtrinsicOrHash
+func (e ExtrinsicOrHash) MarshalJSON() ([]byte, error) {
+	if e.IsExtrinsic {
+		return json.Marshal(map[string]string{"extrinsic": codec.HexEncodeToString(e.AsExtrinsic)})
+	}
+
+	return json.Marshal(map[string]string{"hash": e.AsHash.Hex()})
+}
+
+// RemoveExtrinsic removes the provided extrinsics from the pool of the node and returns the hashes of all the
+// removed extrinsics, including the ones that were removed because they depend on the provided ones
+func (a *author) RemoveExtrinsic(ctx context.Context, extrinsics []ExtrinsicOrHash) ([]types.Hash, error) {
+	var res []string
+	err := a.client.CallContext(ctx, &res, "author_removeExtrinsic", extrinsics)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]types.Hash, 0, len(res))
+	for _, r := range res {
+		hash, err := types.NewHashFromHexString(r)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
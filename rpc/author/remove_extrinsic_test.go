@@ -0,0 +1,54 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthor_RemoveExtrinsic(t *testing.T) {
+	removedHash := types.Hash{1, 2, 3}
+	authorSrv := &mockAuthorSrv{removedHashes: []string{removedHash.Hex()}}
+	testAuthor := newMockAuthor(t, authorSrv)
+
+	xt := extrinsic.NewExtrinsic(types.Call{CallIndex: types.CallIndex{SectionIndex: 1, MethodIndex: 2}})
+
+	xtOrHash, err := author.NewExtrinsicOrHashFromExtrinsic(xt)
+	assert.NoError(t, err)
+
+	encodedXt, err := codec.EncodeToHex(xt)
+	assert.NoError(t, err)
+
+	res, err := testAuthor.RemoveExtrinsic(
+		context.Background(),
+		[]author.ExtrinsicOrHash{author.NewExtrinsicOrHashFromHash(removedHash), xtOrHash},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{removedHash}, res)
+	assert.Equal(
+		t,
+		[]map[string]string{{"hash": removedHash.Hex()}, {"extrinsic": encodedXt}},
+		authorSrv.removeExtrinsic,
+	)
+}
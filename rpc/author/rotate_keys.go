@@ -0,0 +1,36 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// RotateKeys generates new session keys in the keystore of the node and returns their SCALE encoded public keys.
+// The returned bytes can be used when setting the session keys of a validator.
+func (a *author) RotateKeys(ctx context.Context) (types.Bytes, error) {
+	var res string
+	err := a.client.CallContext(ctx, &res, "author_rotateKeys")
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.HexDecodeString(res)
+}
@@ -0,0 +1,33 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package author_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthor_RotateKeys(t *testing.T) {
+	authorSrv := &mockAuthorSrv{sessionKeysHex: "0x0102030405"}
+
+	res, err := newMockAuthor(t, authorSrv).RotateKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString(authorSrv.sessionKeysHex), []byte(res))
+}
@@ -18,72 +18,43 @@ package author
 
 import (
 	"context"
-	"sync"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
-	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 )
 
 // ExtrinsicStatusSubscription is a subscription established through one of the Client's subscribe methods.
-type ExtrinsicStatusSubscription struct {
-	sub      *gethrpc.ClientSubscription
-	channel  chan types.ExtrinsicStatus
-	quitOnce sync.Once // ensures quit is closed once
-}
-
-// Chan returns the subscription channel.
-//
-// The channel is closed when Unsubscribe is called on the subscription.
-func (s *ExtrinsicStatusSubscription) Chan() <-chan types.ExtrinsicStatus {
-	return s.channel
-}
-
-// Err returns the subscription error channel. The intended use of Err is to schedule
-// resubscription when the client connection is closed unexpectedly.
-//
-// The error channel receives a value when the subscription has ended due
-// to an error. The received error is nil if Close has been called
-// on the underlying client and no other error has occurred.
-//
-// The error channel is closed when Unsubscribe is called on the subscription.
-func (s *ExtrinsicStatusSubscription) Err() <-chan error {
-	return s.sub.Err()
-}
-
-// Unsubscribe unsubscribes the notification and closes the error channel.
-// It can safely be called more than once.
-func (s *ExtrinsicStatusSubscription) Unsubscribe() {
-	s.sub.Unsubscribe()
-	s.quitOnce.Do(func() {
-		close(s.channel)
-	})
-}
+type ExtrinsicStatusSubscription = client.Subscription[types.ExtrinsicStatus]
 
 // SubmitAndWatchExtrinsic will submit and subscribe to watch an extrinsic until unsubscribed, returning a subscription
 // that will receive server notifications containing the extrinsic status updates.
 func (a *author) SubmitAndWatchExtrinsic(xt extrinsic.Extrinsic) (*ExtrinsicStatusSubscription, error) { //nolint:lll
+	return a.SubmitAndWatchExtrinsicContext(context.Background(), xt)
+}
+
+// SubmitAndWatchExtrinsicContext will submit and subscribe to watch an extrinsic until unsubscribed, using the
+// provided context for setting up the subscription. If the context has no deadline, the default subscribe timeout
+// is applied.
+func (a *author) SubmitAndWatchExtrinsicContext(
+	ctx context.Context,
+	xt extrinsic.Extrinsic,
+) (*ExtrinsicStatusSubscription, error) {
 	hexEncodedExtrinsic, err := codec.EncodeToHex(xt)
 	if err != nil {
 		return nil, err
 	}
 
-	return a.submitAndWatchExtrinsic(hexEncodedExtrinsic)
+	return a.submitAndWatchExtrinsic(ctx, hexEncodedExtrinsic)
 }
 
-func (a *author) submitAndWatchExtrinsic(hexEncodedExtrinsic string) (*ExtrinsicStatusSubscription, error) { //nolint:lll
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
-	defer cancel()
-
-	c := make(chan types.ExtrinsicStatus)
-
-	sub, err := a.client.Subscribe(ctx, "author", "submitAndWatchExtrinsic", "unwatchExtrinsic", "extrinsicUpdate",
-		c, hexEncodedExtrinsic)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ExtrinsicStatusSubscription{sub: sub, channel: c}, nil
+func (a *author) submitAndWatchExtrinsic(
+	ctx context.Context,
+	hexEncodedExtrinsic string,
+) (*ExtrinsicStatusSubscription, error) {
+	return client.Subscribe[types.ExtrinsicStatus](
+		ctx, a.client,
+		"author", "submitAndWatchExtrinsic", "unwatchExtrinsic", "extrinsicUpdate", []interface{}{hexEncodedExtrinsic},
+	)
 }
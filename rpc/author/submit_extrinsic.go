@@ -17,6 +17,8 @@
 package author
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
@@ -24,13 +26,18 @@ import (
 
 // SubmitExtrinsic will submit a fully formatted extrinsic for block inclusion
 func (a *author) SubmitExtrinsic(xt extrinsic.Extrinsic) (types.Hash, error) {
+	return a.SubmitExtrinsicContext(context.Background(), xt)
+}
+
+// SubmitExtrinsicContext will submit a fully formatted extrinsic for block inclusion, using the provided context
+func (a *author) SubmitExtrinsicContext(ctx context.Context, xt extrinsic.Extrinsic) (types.Hash, error) {
 	enc, err := codec.EncodeToHex(xt)
 	if err != nil {
 		return types.Hash{}, err
 	}
 
 	var res string
-	err = a.client.Call(&res, "author_submitExtrinsic", enc)
+	err = a.client.CallContext(ctx, &res, "author_submitExtrinsic", enc)
 	if err != nil {
 		return types.Hash{}, err
 	}
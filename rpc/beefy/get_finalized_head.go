@@ -26,7 +26,7 @@ import (
 func (b *beefy) GetFinalizedHead(ctx context.Context) (types.Hash, error) {
 	var res string
 
-	err := b.client.Call(&res, "beefy_getFinalizedHead")
+	err := b.client.CallContext(ctx, &res, "beefy_getFinalizedHead")
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -18,61 +18,18 @@ package beefy
 
 import (
 	"context"
-	"sync"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
-	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // JustificationsSubscription is a subscription established through one of the Client's subscribe methods.
-type JustificationsSubscription struct {
-	sub      *gethrpc.ClientSubscription
-	channel  chan types.SignedCommitment
-	quitOnce sync.Once // ensures quit is closed once
-}
-
-// Chan returns the subscription channel.
-//
-// The channel is closed when Unsubscribe is called on the subscription.
-func (s *JustificationsSubscription) Chan() <-chan types.SignedCommitment {
-	return s.channel
-}
-
-// Err returns the subscription error channel. The intended use of Err is to schedule
-// resubscription when the client connection is closed unexpectedly.
-//
-// The error channel receives a value when the subscription has ended due
-// to an error. The received error is nil if Close has been called
-// on the underlying client and no other error has occurred.
-//
-// The error channel is closed when Unsubscribe is called on the subscription.
-func (s *JustificationsSubscription) Err() <-chan error {
-	return s.sub.Err()
-}
-
-// Unsubscribe unsubscribes the notification and closes the error channel.
-// It can safely be called more than once.
-func (s *JustificationsSubscription) Unsubscribe() {
-	s.sub.Unsubscribe()
-	s.quitOnce.Do(func() {
-		close(s.channel)
-	})
-}
+type JustificationsSubscription = client.Subscription[types.SignedCommitment]
 
 // SubscribeJustifications subscribes beefy justifications, returning a subscription that will
 // receive server notifications containing the Header.
 func (b *beefy) SubscribeJustifications(ctx context.Context) (*JustificationsSubscription, error) {
-	ctx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
-	defer cancel()
-
-	ch := make(chan types.SignedCommitment)
-
-	sub, err := b.client.Subscribe(ctx, "beefy", "subscribeJustifications", "unsubscribeJustifications",
-		"justifications", ch)
-	if err != nil {
-		return nil, err
-	}
-
-	return &JustificationsSubscription{sub: sub, channel: ch}, nil
+	return client.Subscribe[types.SignedCommitment](
+		ctx, b.client, "beefy", "subscribeJustifications", "unsubscribeJustifications", "justifications", nil,
+	)
 }
@@ -33,11 +33,13 @@ type Chain interface {
 	SubscribeNewHeads(ctx context.Context) (*NewHeadsSubscription, error)
 	GetBlockHash(ctx context.Context, blockNumber uint64) (types.Hash, error)
 	GetBlockHashLatest(ctx context.Context) (types.Hash, error)
+	GetBlockHashes(ctx context.Context, from, to uint64) ([]BlockHashResult, error)
 	GetFinalizedHead(ctx context.Context) (types.Hash, error)
 	GetBlock(ctx context.Context, blockHash types.Hash) (*block.SignedBlock, error)
 	GetBlockLatest(ctx context.Context) (*block.SignedBlock, error)
 	GetHeader(ctx context.Context, blockHash types.Hash) (*types.Header, error)
 	GetHeaderLatest(ctx context.Context) (*types.Header, error)
+	GetHeaders(ctx context.Context, blockHashes []types.Hash) ([]HeaderResult, error)
 }
 
 // chain exposes methods for retrieval of chain data
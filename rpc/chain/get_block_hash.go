@@ -37,9 +37,9 @@ func (c *chain) getBlockHash(ctx context.Context, blockNumber *uint64) (types.Ha
 	var err error
 
 	if blockNumber == nil {
-		err = c.client.Call(&res, "chain_getBlockHash")
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash")
 	} else {
-		err = c.client.Call(&res, "chain_getBlockHash", *blockNumber)
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash", *blockNumber)
 	}
 
 	if err != nil {
@@ -0,0 +1,85 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	// ErrBlockNotFound is returned for the blocks that are not known by the node.
+	ErrBlockNotFound = errors.New("block not found")
+	// ErrInvalidBlockRange is returned when the start of a block range is after its end.
+	ErrInvalidBlockRange = errors.New("invalid block range")
+)
+
+// BlockHashResult is the result of the retrieval of a single block hash by GetBlockHashes.
+type BlockHashResult struct {
+	Number uint64
+	Hash   types.Hash
+	// Err is the error returned for the block number, ErrBlockNotFound if there is no block at that height.
+	Err error
+}
+
+// GetBlockHashes returns the block hashes for the block heights in the range [from, to], using JSON-RPC batch
+// requests. The results are returned in the order of the block numbers, with the error of each block number reported
+// in its result. The returned error is only set if the range is invalid or the requests could not be sent.
+func (c *chain) GetBlockHashes(ctx context.Context, from, to uint64) ([]BlockHashResult, error) {
+	if from > to {
+		return nil, fmt.Errorf("%w: %d > %d", ErrInvalidBlockRange, from, to)
+	}
+
+	count := to - from + 1
+
+	batch := make([]gethrpc.BatchElem, count)
+	res := make([]*string, count)
+
+	for i := range batch {
+		batch[i] = gethrpc.BatchElem{
+			Method: "chain_getBlockHash",
+			Args:   []interface{}{from + uint64(i)},
+			Result: &res[i],
+		}
+	}
+
+	if err := client.BatchCallInChunks(ctx, c.client, batch, client.DefaultBatchSize); err != nil {
+		return nil, err
+	}
+
+	results := make([]BlockHashResult, count)
+
+	for i := range results {
+		results[i].Number = from + uint64(i)
+
+		switch {
+		case batch[i].Error != nil:
+			results[i].Err = batch[i].Error
+		case res[i] == nil:
+			results[i].Err = ErrBlockNotFound
+		default:
+			results[i].Hash, results[i].Err = types.NewHashFromHexString(*res[i])
+		}
+	}
+
+	return results, nil
+}
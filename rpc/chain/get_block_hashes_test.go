@@ -0,0 +1,52 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_GetBlockHashes(t *testing.T) {
+	res, err := testChain.GetBlockHashes(context.Background(), 1, 3)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	for i, r := range res {
+		assert.NoError(t, r.Err)
+		assert.Equal(t, uint64(i+1), r.Number)
+
+		hash, err := testChain.GetBlockHash(context.Background(), r.Number)
+		assert.NoError(t, err)
+		assert.Equal(t, hash, r.Hash)
+	}
+}
+
+func TestChain_GetBlockHashes_NotFound(t *testing.T) {
+	res, err := testChain.GetBlockHashes(context.Background(), 1<<40, 1<<40)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.ErrorIs(t, res[0].Err, ErrBlockNotFound)
+}
+
+func TestChain_GetBlockHashes_InvalidRange(t *testing.T) {
+	res, err := testChain.GetBlockHashes(context.Background(), 2, 1)
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+	assert.Nil(t, res)
+}
@@ -26,7 +26,7 @@ import (
 func (c *chain) GetFinalizedHead(ctx context.Context) (types.Hash, error) {
 	var res string
 
-	err := c.client.Call(&res, "chain_getFinalizedHead")
+	err := c.client.CallContext(ctx, &res, "chain_getFinalizedHead")
 	if err != nil {
 		return types.Hash{}, err
 	}
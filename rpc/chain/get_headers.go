@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// HeaderResult is the result of the retrieval of a single header by GetHeaders.
+type HeaderResult struct {
+	Hash types.Hash
+	// Header is the retrieved header. It is nil if Err is set.
+	Header *types.Header
+	// Err is the error returned for the block hash, ErrBlockNotFound if the block is not known by the node.
+	Err error
+}
+
+// GetHeaders retrieves the headers of the provided blocks, using JSON-RPC batch requests. The results are returned
+// in the order of the block hashes, with the error of each block hash reported in its result. The returned error is
+// only set if the requests could not be sent.
+func (c *chain) GetHeaders(ctx context.Context, blockHashes []types.Hash) ([]HeaderResult, error) {
+	batch := make([]gethrpc.BatchElem, len(blockHashes))
+	res := make([]*types.Header, len(blockHashes))
+
+	for i, blockHash := range blockHashes {
+		batch[i] = gethrpc.BatchElem{
+			Method: "chain_getHeader",
+			Args:   []interface{}{blockHash.Hex()},
+			Result: &res[i],
+		}
+	}
+
+	if err := client.BatchCallInChunks(ctx, c.client, batch, client.DefaultBatchSize); err != nil {
+		return nil, err
+	}
+
+	results := make([]HeaderResult, len(blockHashes))
+
+	for i, blockHash := range blockHashes {
+		results[i].Hash = blockHash
+
+		switch {
+		case batch[i].Error != nil:
+			results[i].Err = batch[i].Error
+		case res[i] == nil:
+			results[i].Err = ErrBlockNotFound
+		default:
+			results[i].Header = res[i]
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,44 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_GetHeaders(t *testing.T) {
+	hash, err := testChain.GetBlockHash(context.Background(), 1)
+	assert.NoError(t, err)
+
+	unknown := types.Hash{0xff}
+
+	res, err := testChain.GetHeaders(context.Background(), []types.Hash{hash, unknown})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	assert.NoError(t, res[0].Err)
+	assert.Equal(t, hash, res[0].Hash)
+	assert.Equal(t, types.BlockNumber(1), res[0].Header.Number)
+
+	assert.ErrorIs(t, res[1].Err, ErrBlockNotFound)
+	assert.Equal(t, unknown, res[1].Hash)
+	assert.Nil(t, res[1].Header)
+}
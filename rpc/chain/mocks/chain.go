@@ -96,6 +96,32 @@ func (_m *Chain) GetBlockHashLatest(ctx context.Context) (types.Hash, error) {
 	return r0, r1
 }
 
+// GetBlockHashes provides a mock function with given fields: ctx, from, to
+func (_m *Chain) GetBlockHashes(ctx context.Context, from uint64, to uint64) ([]chain.BlockHashResult, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 []chain.BlockHashResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]chain.BlockHashResult, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []chain.BlockHashResult); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]chain.BlockHashResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockLatest provides a mock function with given fields: ctx
 func (_m *Chain) GetBlockLatest(ctx context.Context) (*block.SignedBlock, error) {
 	ret := _m.Called(ctx)
@@ -200,6 +226,32 @@ func (_m *Chain) GetHeaderLatest(ctx context.Context) (*types.Header, error) {
 	return r0, r1
 }
 
+// GetHeaders provides a mock function with given fields: ctx, blockHashes
+func (_m *Chain) GetHeaders(ctx context.Context, blockHashes []types.Hash) ([]chain.HeaderResult, error) {
+	ret := _m.Called(ctx, blockHashes)
+
+	var r0 []chain.HeaderResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Hash) ([]chain.HeaderResult, error)); ok {
+		return rf(ctx, blockHashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.Hash) []chain.HeaderResult); ok {
+		r0 = rf(ctx, blockHashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]chain.HeaderResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.Hash) error); ok {
+		r1 = rf(ctx, blockHashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubscribeFinalizedHeads provides a mock function with given fields: ctx
 func (_m *Chain) SubscribeFinalizedHeads(ctx context.Context) (*chain.FinalizedHeadsSubscription, error) {
 	ret := _m.Called(ctx)
@@ -18,60 +18,18 @@ package chain
 
 import (
 	"context"
-	"sync"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
-	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // NewHeadsSubscription is a subscription established through one of the Client's subscribe methods.
-type NewHeadsSubscription struct {
-	sub      *gethrpc.ClientSubscription
-	channel  chan types.Header
-	quitOnce sync.Once // ensures quit is closed once
-}
-
-// Chan returns the subscription channel.
-//
-// The channel is closed when Unsubscribe is called on the subscription.
-func (s *NewHeadsSubscription) Chan() <-chan types.Header {
-	return s.channel
-}
-
-// Err returns the subscription error channel. The intended use of Err is to schedule
-// resubscription when the client connection is closed unexpectedly.
-//
-// The error channel receives a value when the subscription has ended due
-// to an error. The received error is nil if Close has been called
-// on the underlying client and no other error has occurred.
-//
-// The error channel is closed when Unsubscribe is called on the subscription.
-func (s *NewHeadsSubscription) Err() <-chan error {
-	return s.sub.Err()
-}
-
-// Unsubscribe unsubscribes the notification and closes the error channel.
-// It can safely be called more than once.
-func (s *NewHeadsSubscription) Unsubscribe() {
-	s.sub.Unsubscribe()
-	s.quitOnce.Do(func() {
-		close(s.channel)
-	})
-}
+type NewHeadsSubscription = client.Subscription[types.Header]
 
 // SubscribeNewHeads subscribes the best headers, returning a subscription that will
 // receive server notifications containing the Header.
 func (c *chain) SubscribeNewHeads(ctx context.Context) (*NewHeadsSubscription, error) {
-	ctx, cancel := context.WithTimeout(ctx, config.Default().SubscribeTimeout)
-	defer cancel()
-
-	ch := make(chan types.Header)
-
-	sub, err := c.client.Subscribe(ctx, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
-	if err != nil {
-		return nil, err
-	}
-
-	return &NewHeadsSubscription{sub: sub, channel: ch}, nil
+	return client.Subscribe[types.Header](
+		ctx, c.client, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", nil,
+	)
 }
@@ -0,0 +1,62 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Call calls a runtime function with the SCALE encoded parameters at a pinned block, and returns its SCALE encoded
+// output. The subscription must have been started with the runtime.
+func (s *FollowSubscription) Call(
+	ctx context.Context,
+	blockHash types.Hash,
+	function string,
+	params []byte,
+) (types.Bytes, error) {
+	if err := s.checkPinned(blockHash); err != nil {
+		return nil, err
+	}
+
+	op, _, err := s.startOperation(
+		ctx, "chainHead_v1_call", s.ID(), blockHash.Hex(), function, codec.HexEncodeToString(params),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.finishOperation(op)
+
+	ev, err := s.next(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := operationError(ev); err != nil {
+		return nil, err
+	}
+
+	if ev.Type != followEventOperationCallDone {
+		return nil, fmt.Errorf("%w: %s event for call operation", ErrInvalidFollowEvent, ev.Type)
+	}
+
+	return codec.HexDecodeString(ev.Output)
+}
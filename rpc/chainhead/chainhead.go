@@ -0,0 +1,60 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name ChainHead --filename chainhead.go
+
+package chainhead
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+)
+
+var (
+	// ErrFollowStopped is returned when the follow subscription was stopped, either by the node or by Unfollow.
+	ErrFollowStopped = errors.New("follow subscription stopped")
+	// ErrInvalidFollowEvent is returned when the node sends a follow event that violates the protocol.
+	ErrInvalidFollowEvent = errors.New("invalid follow event")
+	// ErrLimitReached is returned when the node refuses to start an operation because of its resource limits.
+	ErrLimitReached = errors.New("operation limit reached")
+	// ErrBlockNotPinned is returned when querying a block that is not pinned by the follow subscription.
+	ErrBlockNotPinned = errors.New("block not pinned")
+	// ErrOperationInaccessible is returned when the node could not access the data of an operation. The operation
+	// might succeed if it is started again.
+	ErrOperationInaccessible = errors.New("operation inaccessible")
+	// ErrOperationFailed is returned when an operation failed, e.g. because a runtime call failed.
+	ErrOperationFailed = errors.New("operation failed")
+)
+
+// ChainHead exposes the chainHead_v1 methods of the Substrate JSON-RPC spec, which replace the legacy chain and
+// state methods.
+type ChainHead interface {
+	// Follow starts a follow subscription, which pins the blocks it reports and allows querying them. If withRuntime
+	// is true, the events report the runtime of the blocks and the runtime calls are allowed.
+	Follow(ctx context.Context, withRuntime bool) (*FollowSubscription, error)
+}
+
+// chainHead exposes methods for following the head of the chain
+type chainHead struct {
+	client client.Client
+}
+
+// NewChainHead creates a new chainHead struct
+func NewChainHead(cl client.Client) ChainHead {
+	return &chainHead{cl}
+}
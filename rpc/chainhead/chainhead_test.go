@@ -0,0 +1,406 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var testChainHead ChainHead
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("chainHead", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.RegisterSubscription("chainHead_v1_follow", "")
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testChainHead = NewChainHead(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	mu sync.Mutex
+
+	notifier *gethrpc.Notifier
+	subID    gethrpc.ID
+
+	// storagePageSize is the number of items sent in a page of a storage operation.
+	storagePageSize int
+	// storageMaxItems is the number of items processed by a storage operation, the other ones are discarded.
+	storageMaxItems int
+
+	operations   int
+	continues    map[string]chan struct{}
+	stopped      []string
+	unpinned     []string
+	unfollowed   []string
+	limitReached bool
+}
+
+var mockSrv = MockSrv{
+	storagePageSize: 2,
+	storageMaxItems: 2,
+	continues:       make(map[string]chan struct{}),
+}
+
+var mockSrvStorage = map[string]string{
+	"0x01":   "0xaa",
+	"0x0201": "0xbb",
+	"0x0202": "0xcc",
+	"0x0203": "0xdd",
+	"0x03":   "0xee",
+}
+
+func testBlockHash(n byte) types.Hash {
+	return types.Hash{n}
+}
+
+type storageQueryItem struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+func (s *MockSrv) V1_follow(ctx context.Context, withRuntime bool) (*gethrpc.Subscription, error) {
+	notifier, ok := gethrpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+
+	s.mu.Lock()
+	s.notifier = notifier
+	s.subID = sub.ID
+	s.mu.Unlock()
+
+	s.emit(map[string]interface{}{
+		"event":                "initialized",
+		"finalizedBlockHashes": []types.Hash{testBlockHash(0)},
+	})
+
+	return sub, nil
+}
+
+func (s *MockSrv) V1_unfollow(followSubscription string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unfollowed = append(s.unfollowed, followSubscription)
+}
+
+func (s *MockSrv) V1_header(followSubscription, hash string) (*string, error) {
+	h, err := types.NewHashFromHexString(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if h[0] == 0xff {
+		return nil, nil
+	}
+
+	header, err := codec.EncodeToHex(types.Header{Number: types.BlockNumber(h[0])})
+	if err != nil {
+		return nil, err
+	}
+
+	return &header, nil
+}
+
+func (s *MockSrv) V1_body(followSubscription, hash string) map[string]interface{} {
+	id := s.startOperation()
+
+	s.emit(map[string]interface{}{
+		"event":       "operationBodyDone",
+		"operationId": id,
+		"value":       []string{"0x0102", "0x0304"},
+	})
+
+	return startedOperation(id, 0)
+}
+
+func (s *MockSrv) V1_call(followSubscription, hash, function, params string) (map[string]interface{}, error) {
+	if s.isLimitReached() {
+		return map[string]interface{}{"result": "limitReached"}, nil
+	}
+
+	id := s.startOperation()
+
+	if function == "Test_fail" {
+		s.emit(map[string]interface{}{
+			"event":       "operationError",
+			"operationId": id,
+			"error":       "runtime call failed",
+		})
+	} else {
+		s.emit(map[string]interface{}{
+			"event":       "operationCallDone",
+			"operationId": id,
+			"output":      params + "ff",
+		})
+	}
+
+	return startedOperation(id, 0), nil
+}
+
+func (s *MockSrv) V1_storage(
+	followSubscription, hash string,
+	items []storageQueryItem,
+	childTrie *string,
+) map[string]interface{} {
+	id := s.startOperation()
+
+	s.mu.Lock()
+	maxItems := s.storageMaxItems
+	pageSize := s.storagePageSize
+	continues := make(chan struct{})
+	s.continues[id] = continues
+	s.mu.Unlock()
+
+	discarded := 0
+	if len(items) > maxItems {
+		discarded = len(items) - maxItems
+		items = items[:maxItems]
+	}
+
+	var results []map[string]interface{}
+
+	for _, item := range items {
+		switch item.Type {
+		case "value":
+			if value, ok := mockSrvStorage[item.Key]; ok {
+				results = append(results, map[string]interface{}{"key": item.Key, "value": value})
+			}
+		case "descendantsValues":
+			var keys []string
+
+			for key := range mockSrvStorage {
+				if strings.HasPrefix(key, item.Key) {
+					keys = append(keys, key)
+				}
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				results = append(results, map[string]interface{}{"key": key, "value": mockSrvStorage[key]})
+			}
+		}
+	}
+
+	go func() {
+		for len(results) > pageSize {
+			s.emit(map[string]interface{}{
+				"event":       "operationStorageItems",
+				"operationId": id,
+				"items":       results[:pageSize],
+			})
+			s.emit(map[string]interface{}{
+				"event":       "operationWaitingForContinue",
+				"operationId": id,
+			})
+
+			results = results[pageSize:]
+
+			select {
+			case <-continues:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+
+		if len(results) > 0 {
+			s.emit(map[string]interface{}{
+				"event":       "operationStorageItems",
+				"operationId": id,
+				"items":       results,
+			})
+		}
+
+		s.emit(map[string]interface{}{
+			"event":       "operationStorageDone",
+			"operationId": id,
+		})
+	}()
+
+	return startedOperation(id, discarded)
+}
+
+func (s *MockSrv) V1_continue(followSubscription, operationID string) error {
+	s.mu.Lock()
+	continues, ok := s.continues[operationID]
+	s.mu.Unlock()
+
+	if !ok {
+		return errors.New("unknown operation")
+	}
+
+	continues <- struct{}{}
+
+	return nil
+}
+
+func (s *MockSrv) V1_stopOperation(followSubscription, operationID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = append(s.stopped, operationID)
+}
+
+func (s *MockSrv) V1_unpin(followSubscription string, hashes []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unpinned = append(s.unpinned, hashes...)
+}
+
+func (s *MockSrv) unfollowedIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.unfollowed...)
+}
+
+func (s *MockSrv) stoppedOperations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.stopped...)
+}
+
+func (s *MockSrv) unpinnedHashes() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.unpinned...)
+}
+
+func (s *MockSrv) setLimitReached(limitReached bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limitReached = limitReached
+}
+
+func (s *MockSrv) lastOperation() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fmt.Sprintf("op-%d", s.operations)
+}
+
+func (s *MockSrv) emit(ev interface{}) {
+	s.mu.Lock()
+	notifier, id := s.notifier, s.subID
+	s.mu.Unlock()
+
+	_ = notifier.Notify(id, ev)
+}
+
+func (s *MockSrv) startOperation() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.operations++
+
+	return fmt.Sprintf("op-%d", s.operations)
+}
+
+func (s *MockSrv) isLimitReached() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.limitReached
+}
+
+func startedOperation(id string, discarded int) map[string]interface{} {
+	return map[string]interface{}{
+		"result":         "started",
+		"operationId":    id,
+		"discardedItems": discarded,
+	}
+}
+
+// follow starts a follow subscription and waits for its initialized event.
+func follow(t *testing.T) *FollowSubscription {
+	sub, err := testChainHead.Follow(context.Background(), true)
+	require.NoError(t, err)
+
+	t.Cleanup(sub.Unfollow)
+
+	ev := nextEvent(t, sub)
+	require.Equal(t, FollowEventInitialized, ev.Type)
+
+	return sub
+}
+
+func nextEvent(t *testing.T, sub *FollowSubscription) FollowEvent {
+	select {
+	case ev, ok := <-sub.Events():
+		require.True(t, ok, "events channel closed")
+
+		return ev
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for follow event")
+	}
+
+	return FollowEvent{}
+}
+
+func waitDone(t *testing.T, sub *FollowSubscription) {
+	select {
+	case <-sub.Done():
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for the end of the subscription")
+	}
+}
+
+func TestChainHead_StorageQueryItemJSON(t *testing.T) {
+	item := StorageQueryItem{Key: types.NewStorageKey([]byte{0x01, 0x02}), Type: StorageQueryDescendantsValues}
+
+	bz, err := item.MarshalJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"0x0102","type":"descendantsValues"}`, string(bz))
+}
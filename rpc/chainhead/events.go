@@ -0,0 +1,215 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// FollowEventType is the type of a follow event.
+type FollowEventType string
+
+const (
+	// FollowEventInitialized is the first event of a follow subscription, which reports the current finalized blocks.
+	FollowEventInitialized FollowEventType = "initialized"
+	// FollowEventNewBlock reports a new block, whose parent was reported before.
+	FollowEventNewBlock FollowEventType = "newBlock"
+	// FollowEventBestBlockChanged reports a new best block.
+	FollowEventBestBlockChanged FollowEventType = "bestBlockChanged"
+	// FollowEventFinalized reports newly finalized blocks, along with the blocks that can no longer be finalized.
+	FollowEventFinalized FollowEventType = "finalized"
+	// FollowEventStop reports that the node stopped the subscription. It is the last event of a subscription.
+	FollowEventStop FollowEventType = "stop"
+
+	followEventOperationBodyDone           = "operationBodyDone"
+	followEventOperationCallDone           = "operationCallDone"
+	followEventOperationStorageItems       = "operationStorageItems"
+	followEventOperationWaitingForContinue = "operationWaitingForContinue"
+	followEventOperationStorageDone        = "operationStorageDone"
+	followEventOperationInaccessible       = "operationInaccessible"
+	followEventOperationError              = "operationError"
+	operationStartedResult                 = "started"
+	operationLimitReachedResult            = "limitReached"
+	runtimeValidType                       = "valid"
+)
+
+// FollowEvent is an event of a follow subscription that reports a change of the chain. The fields that are set
+// depend on the type of the event.
+type FollowEvent struct {
+	Type FollowEventType `json:"event"`
+
+	// FinalizedBlockHashes holds the finalized blocks reported by the initialized and finalized events, in ascending
+	// order.
+	FinalizedBlockHashes []types.Hash `json:"finalizedBlockHashes,omitempty"`
+	// FinalizedBlockRuntime is the runtime of the last finalized block of the initialized event, if the
+	// subscription follows the runtime.
+	FinalizedBlockRuntime *RuntimeEvent `json:"finalizedBlockRuntime,omitempty"`
+
+	// BlockHash is the block reported by the newBlock event.
+	BlockHash types.Hash `json:"blockHash,omitempty"`
+	// ParentBlockHash is the parent of the block reported by the newBlock event.
+	ParentBlockHash types.Hash `json:"parentBlockHash,omitempty"`
+	// NewRuntime is set by the newBlock event if the runtime of the block differs from the one of its parent.
+	NewRuntime *RuntimeEvent `json:"newRuntime,omitempty"`
+
+	// BestBlockHash is the block reported by the bestBlockChanged event.
+	BestBlockHash types.Hash `json:"bestBlockHash,omitempty"`
+
+	// PrunedBlockHashes holds the blocks of the finalized event that can no longer be finalized. They stay pinned
+	// until they are unpinned.
+	PrunedBlockHashes []types.Hash `json:"prunedBlockHashes,omitempty"`
+}
+
+// RuntimeEvent describes the runtime of a block.
+type RuntimeEvent struct {
+	// Type is either "valid" or "invalid".
+	Type string `json:"type"`
+	// Spec is the specification of a valid runtime.
+	Spec *RuntimeSpec `json:"spec,omitempty"`
+	// Error describes why the runtime is invalid.
+	Error string `json:"error,omitempty"`
+}
+
+// IsValid returns true if the runtime is valid.
+func (r *RuntimeEvent) IsValid() bool {
+	return r.Type == runtimeValidType
+}
+
+// RuntimeSpec is the specification of a runtime.
+type RuntimeSpec struct {
+	SpecName           string `json:"specName"`
+	ImplName           string `json:"implName"`
+	SpecVersion        uint32 `json:"specVersion"`
+	ImplVersion        uint32 `json:"implVersion"`
+	TransactionVersion uint32 `json:"transactionVersion"`
+	// APIs maps the hex encoded identifiers of the runtime APIs to their versions.
+	APIs map[string]uint32 `json:"apis"`
+}
+
+// followEvent is the JSON representation of all the follow events, including the operation events.
+type followEvent struct {
+	FollowEvent
+
+	OperationID string          `json:"operationId,omitempty"`
+	Value       []string        `json:"value,omitempty"`
+	Output      string          `json:"output,omitempty"`
+	Items       []StorageResult `json:"items,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// operationStarted is the response of the methods that start an operation.
+type operationStarted struct {
+	Result         string `json:"result"`
+	OperationID    string `json:"operationId"`
+	DiscardedItems int    `json:"discardedItems"`
+}
+
+// StorageQueryType is the type of a storage query item.
+type StorageQueryType string
+
+const (
+	// StorageQueryValue queries the value of the key.
+	StorageQueryValue StorageQueryType = "value"
+	// StorageQueryHash queries the hash of the value of the key.
+	StorageQueryHash StorageQueryType = "hash"
+	// StorageQueryClosestDescendantMerkleValue queries the Merkle value of the closest descendant of the key.
+	StorageQueryClosestDescendantMerkleValue StorageQueryType = "closestDescendantMerkleValue"
+	// StorageQueryDescendantsValues queries the values of all the keys that start with the key.
+	StorageQueryDescendantsValues StorageQueryType = "descendantsValues"
+	// StorageQueryDescendantsHashes queries the hashes of the values of all the keys that start with the key.
+	StorageQueryDescendantsHashes StorageQueryType = "descendantsHashes"
+)
+
+// StorageQueryItem is an item of a storage query.
+type StorageQueryItem struct {
+	Key  types.StorageKey
+	Type StorageQueryType
+}
+
+// MarshalJSON returns a JSON encoded StorageQueryItem, with a hex encoded key.
+func (i StorageQueryItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Key  string           `json:"key"`
+		Type StorageQueryType `json:"type"`
+	}{
+		Key:  i.Key.Hex(),
+		Type: i.Type,
+	})
+}
+
+// StorageResult is an item of the result of a storage query. Only the field of the queried type is set.
+type StorageResult struct {
+	Key                          types.StorageKey
+	Value                        *types.StorageDataRaw
+	Hash                         *types.Hash
+	ClosestDescendantMerkleValue *types.Bytes
+}
+
+// UnmarshalJSON fills StorageResult with the JSON encoded byte array given by b, whose fields are hex encoded
+func (r *StorageResult) UnmarshalJSON(b []byte) error {
+	var tmp struct {
+		Key                          string  `json:"key"`
+		Value                        *string `json:"value"`
+		Hash                         *string `json:"hash"`
+		ClosestDescendantMerkleValue *string `json:"closestDescendantMerkleValue"`
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	key, err := codec.HexDecodeString(tmp.Key)
+	if err != nil {
+		return err
+	}
+
+	*r = StorageResult{Key: key}
+
+	if tmp.Value != nil {
+		value, err := codec.HexDecodeString(*tmp.Value)
+		if err != nil {
+			return err
+		}
+
+		data := types.NewStorageDataRaw(value)
+		r.Value = &data
+	}
+
+	if tmp.Hash != nil {
+		hash, err := types.NewHashFromHexString(*tmp.Hash)
+		if err != nil {
+			return err
+		}
+
+		r.Hash = &hash
+	}
+
+	if tmp.ClosestDescendantMerkleValue != nil {
+		merkleValue, err := codec.HexDecodeString(*tmp.ClosestDescendantMerkleValue)
+		if err != nil {
+			return err
+		}
+
+		bz := types.NewBytes(merkleValue)
+		r.ClosestDescendantMerkleValue = &bz
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// FollowSubscription is a chainHead_v1_follow subscription. It checks that the events sent by the node follow the
+// protocol, keeps track of the pinned blocks and allows querying them.
+//
+// The events must be consumed from Events, the blocks they report stay pinned on the node until they are unpinned.
+type FollowSubscription struct {
+	client client.Client
+	sub    *gethrpc.ClientSubscription
+	raw    chan followEvent
+	events chan FollowEvent
+	quit   chan struct{}
+	done   chan struct{}
+
+	mu          sync.Mutex
+	err         error
+	initialized bool
+	// pinned holds the blocks that are pinned on the node.
+	pinned map[types.Hash]struct{}
+	// reported holds the blocks that can be the parent of a new block, i.e. the last finalized block and its
+	// descendants that were not pruned.
+	reported   map[types.Hash]struct{}
+	finalized  types.Hash
+	best       types.Hash
+	operations map[string]*operation
+	// early holds the events of the operations that are not registered yet, the node might send them before the
+	// response of the method that started the operation is processed. The IDs of these operations are kept in
+	// earlyIDs, oldest first, and the events of at most maxEarlyOperations operations are kept.
+	early    map[string][]followEvent
+	earlyIDs []string
+
+	unfollowOnce sync.Once
+}
+
+// Follow starts a follow subscription, which pins the blocks it reports and allows querying them. If withRuntime
+// is true, the events report the runtime of the blocks and the runtime calls are allowed.
+func (c *chainHead) Follow(ctx context.Context, withRuntime bool) (*FollowSubscription, error) {
+	ctx, cancel := client.SubscribeContext(ctx)
+	defer cancel()
+
+	raw := make(chan followEvent)
+
+	sub, err := c.client.Subscribe(ctx, "chainHead", "v1_follow", "v1_unfollow", "v1_followEvent", raw, withRuntime)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FollowSubscription{
+		client:     c.client,
+		sub:        sub,
+		raw:        raw,
+		events:     make(chan FollowEvent),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+		pinned:     make(map[types.Hash]struct{}),
+		reported:   make(map[types.Hash]struct{}),
+		operations: make(map[string]*operation),
+		early:      make(map[string][]followEvent),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// ID returns the ID of the subscription, which identifies it in the chainHead_v1 methods.
+func (s *FollowSubscription) ID() string {
+	return s.sub.ID()
+}
+
+// Events returns the channel that receives the follow events. The operation events are not reported, they are
+// handled by the methods that started the operations.
+//
+// The channel is closed when the subscription has ended, after the stop event if the node stopped it.
+func (s *FollowSubscription) Events() <-chan FollowEvent {
+	return s.events
+}
+
+// Done returns a channel that is closed when the subscription has ended.
+func (s *FollowSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that ended the subscription, or nil if it is still running. It is ErrFollowStopped if the
+// subscription was stopped by the node or by Unfollow.
+func (s *FollowSubscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// Pinned returns the blocks that are currently pinned by the subscription.
+func (s *FollowSubscription) Pinned() []types.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashes := make([]types.Hash, 0, len(s.pinned))
+
+	for hash := range s.pinned {
+		hashes = append(hashes, hash)
+	}
+
+	return hashes
+}
+
+// IsPinned returns true if the block is currently pinned by the subscription.
+func (s *FollowSubscription) IsPinned(hash types.Hash) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.pinned[hash]
+
+	return ok
+}
+
+// Finalized returns the last finalized block reported by the subscription.
+func (s *FollowSubscription) Finalized() types.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.finalized
+}
+
+// Best returns the best block reported by the subscription.
+func (s *FollowSubscription) Best() types.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.best
+}
+
+// Unfollow stops the subscription, which unpins all of its blocks and stops its operations.
+// It can safely be called more than once.
+func (s *FollowSubscription) Unfollow() {
+	s.unfollowOnce.Do(func() {
+		s.sub.Unsubscribe()
+		close(s.quit)
+	})
+
+	<-s.done
+}
+
+func (s *FollowSubscription) run() {
+	var (
+		queue []FollowEvent
+		err   error
+	)
+
+	for err == nil {
+		var (
+			out  chan FollowEvent
+			next FollowEvent
+		)
+
+		if len(queue) > 0 {
+			out = s.events
+			next = queue[0]
+		}
+
+		select {
+		case ev := <-s.raw:
+			var report bool
+
+			report, err = s.handle(ev)
+			if report {
+				queue = append(queue, ev.FollowEvent)
+			}
+		case out <- next:
+			queue = queue[1:]
+		case subErr, ok := <-s.sub.Err():
+			err = subErr
+			if !ok || err == nil {
+				err = ErrFollowStopped
+			}
+		case <-s.quit:
+			err = ErrFollowStopped
+		}
+	}
+
+	s.sub.Unsubscribe()
+	s.stop(err)
+
+	// The events received before the end of the subscription are still delivered, unless it was unfollowed.
+	for _, ev := range queue {
+		select {
+		case s.events <- ev:
+		case <-s.quit:
+			close(s.events)
+			return
+		}
+	}
+
+	close(s.events)
+}
+
+// handle updates the state of the subscription with the event, it returns true if the event must be reported.
+func (s *FollowSubscription) handle(ev followEvent) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ev.OperationID != "" {
+		if op, ok := s.operations[ev.OperationID]; ok {
+			op.push(ev)
+		} else {
+			s.pushEarly(ev)
+		}
+
+		return false, nil
+	}
+
+	if !s.initialized && ev.Type != FollowEventInitialized && ev.Type != FollowEventStop {
+		return false, fmt.Errorf("%w: %s event before initialized event", ErrInvalidFollowEvent, ev.Type)
+	}
+
+	switch ev.Type {
+	case FollowEventInitialized:
+		if s.initialized {
+			return false, fmt.Errorf("%w: duplicate initialized event", ErrInvalidFollowEvent)
+		}
+
+		if len(ev.FinalizedBlockHashes) == 0 {
+			return false, fmt.Errorf("%w: initialized event without finalized blocks", ErrInvalidFollowEvent)
+		}
+
+		for _, hash := range ev.FinalizedBlockHashes {
+			s.pinned[hash] = struct{}{}
+		}
+
+		s.initialized = true
+		s.finalized = ev.FinalizedBlockHashes[len(ev.FinalizedBlockHashes)-1]
+		s.best = s.finalized
+		s.reported[s.finalized] = struct{}{}
+	case FollowEventNewBlock:
+		if _, ok := s.reported[ev.ParentBlockHash]; !ok {
+			return false, fmt.Errorf("%w: unknown parent %s of new block %s", ErrInvalidFollowEvent,
+				ev.ParentBlockHash.Hex(), ev.BlockHash.Hex())
+		}
+
+		if _, ok := s.reported[ev.BlockHash]; ok {
+			return false, fmt.Errorf("%w: duplicate new block %s", ErrInvalidFollowEvent, ev.BlockHash.Hex())
+		}
+
+		s.pinned[ev.BlockHash] = struct{}{}
+		s.reported[ev.BlockHash] = struct{}{}
+	case FollowEventBestBlockChanged:
+		if _, ok := s.reported[ev.BestBlockHash]; !ok {
+			return false, fmt.Errorf("%w: unknown best block %s", ErrInvalidFollowEvent, ev.BestBlockHash.Hex())
+		}
+
+		s.best = ev.BestBlockHash
+	case FollowEventFinalized:
+		if len(ev.FinalizedBlockHashes) == 0 {
+			return false, fmt.Errorf("%w: finalized event without finalized blocks", ErrInvalidFollowEvent)
+		}
+
+		hashes := make([]types.Hash, 0, len(ev.FinalizedBlockHashes)+len(ev.PrunedBlockHashes))
+		hashes = append(hashes, ev.FinalizedBlockHashes...)
+		hashes = append(hashes, ev.PrunedBlockHashes...)
+
+		for _, hash := range hashes {
+			if _, ok := s.reported[hash]; !ok {
+				return false, fmt.Errorf("%w: unknown finalized or pruned block %s", ErrInvalidFollowEvent,
+					hash.Hex())
+			}
+		}
+
+		// Only the last finalized block and its descendants can be the parent of a new block.
+		delete(s.reported, s.finalized)
+
+		for _, hash := range hashes {
+			delete(s.reported, hash)
+		}
+
+		s.finalized = ev.FinalizedBlockHashes[len(ev.FinalizedBlockHashes)-1]
+		s.reported[s.finalized] = struct{}{}
+
+		if _, ok := s.reported[s.best]; !ok {
+			s.best = s.finalized
+		}
+	case FollowEventStop:
+		return true, ErrFollowStopped
+	default:
+		return false, fmt.Errorf("%w: unknown event %q", ErrInvalidFollowEvent, ev.Type)
+	}
+
+	return true, nil
+}
+
+// stop ends the subscription and its operations with the error.
+func (s *FollowSubscription) stop(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+	s.pinned = make(map[types.Hash]struct{})
+	s.reported = make(map[types.Hash]struct{})
+	s.early = make(map[string][]followEvent)
+	s.earlyIDs = nil
+
+	close(s.done)
+}
@@ -0,0 +1,159 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestFollowSubscription_Events(t *testing.T) {
+	sub := follow(t)
+
+	assert.NotEmpty(t, sub.ID())
+	assert.Equal(t, testBlockHash(0), sub.Finalized())
+	assert.Equal(t, testBlockHash(0), sub.Best())
+	assert.Equal(t, []types.Hash{testBlockHash(0)}, sub.Pinned())
+
+	mockSrv.emit(map[string]interface{}{
+		"event":           "newBlock",
+		"blockHash":       testBlockHash(1),
+		"parentBlockHash": testBlockHash(0),
+	})
+	mockSrv.emit(map[string]interface{}{
+		"event":           "newBlock",
+		"blockHash":       testBlockHash(2),
+		"parentBlockHash": testBlockHash(0),
+	})
+	mockSrv.emit(map[string]interface{}{
+		"event":         "bestBlockChanged",
+		"bestBlockHash": testBlockHash(2),
+	})
+
+	ev := nextEvent(t, sub)
+	assert.Equal(t, FollowEventNewBlock, ev.Type)
+	assert.Equal(t, testBlockHash(1), ev.BlockHash)
+	assert.Equal(t, testBlockHash(0), ev.ParentBlockHash)
+
+	ev = nextEvent(t, sub)
+	assert.Equal(t, FollowEventNewBlock, ev.Type)
+	assert.Equal(t, testBlockHash(2), ev.BlockHash)
+
+	ev = nextEvent(t, sub)
+	assert.Equal(t, FollowEventBestBlockChanged, ev.Type)
+	assert.Equal(t, testBlockHash(2), sub.Best())
+
+	mockSrv.emit(map[string]interface{}{
+		"event":                "finalized",
+		"finalizedBlockHashes": []types.Hash{testBlockHash(1)},
+		"prunedBlockHashes":    []types.Hash{testBlockHash(2)},
+	})
+
+	ev = nextEvent(t, sub)
+	assert.Equal(t, FollowEventFinalized, ev.Type)
+	assert.Equal(t, []types.Hash{testBlockHash(1)}, ev.FinalizedBlockHashes)
+	assert.Equal(t, []types.Hash{testBlockHash(2)}, ev.PrunedBlockHashes)
+	assert.Equal(t, testBlockHash(1), sub.Finalized())
+	// The best block was pruned, the best block is the finalized block until a new best block is reported.
+	assert.Equal(t, testBlockHash(1), sub.Best())
+	// The pruned blocks stay pinned until they are unpinned.
+	assert.ElementsMatch(t, []types.Hash{testBlockHash(0), testBlockHash(1), testBlockHash(2)}, sub.Pinned())
+
+	mockSrv.emit(map[string]interface{}{"event": "stop"})
+
+	ev = nextEvent(t, sub)
+	assert.Equal(t, FollowEventStop, ev.Type)
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+
+	waitDone(t, sub)
+	assert.ErrorIs(t, sub.Err(), ErrFollowStopped)
+	assert.Empty(t, sub.Pinned())
+}
+
+func TestFollowSubscription_InvalidEvent(t *testing.T) {
+	sub := follow(t)
+
+	// The parent of the new block is not known, e.g. because a finalized event was missed.
+	mockSrv.emit(map[string]interface{}{
+		"event":           "newBlock",
+		"blockHash":       testBlockHash(4),
+		"parentBlockHash": testBlockHash(3),
+	})
+
+	waitDone(t, sub)
+	assert.ErrorIs(t, sub.Err(), ErrInvalidFollowEvent)
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+
+	_, err := sub.Header(context.Background(), testBlockHash(0))
+	assert.ErrorIs(t, err, ErrInvalidFollowEvent)
+}
+
+func TestFollowSubscription_Unfollow(t *testing.T) {
+	sub := follow(t)
+
+	sub.Unfollow()
+	sub.Unfollow()
+
+	assert.ErrorIs(t, sub.Err(), ErrFollowStopped)
+	require.Contains(t, mockSrv.unfollowedIDs(), sub.ID())
+}
+
+func TestFollowSubscription_EarlyOperationEvents(t *testing.T) {
+	sub := follow(t)
+
+	// The operations are never registered, e.g. because the methods that started them failed.
+	for i := 0; i < maxEarlyOperations+2; i++ {
+		mockSrv.emit(map[string]interface{}{
+			"event":       "operationError",
+			"operationId": fmt.Sprintf("unknown-%d", i),
+			"error":       "operation failed",
+		})
+	}
+
+	// The events are handled in order, once the new block is received the operation events were handled too.
+	mockSrv.emit(map[string]interface{}{
+		"event":           "newBlock",
+		"blockHash":       testBlockHash(1),
+		"parentBlockHash": testBlockHash(0),
+	})
+
+	ev := nextEvent(t, sub)
+	assert.Equal(t, FollowEventNewBlock, ev.Type)
+
+	sub.mu.Lock()
+	assert.Len(t, sub.early, maxEarlyOperations)
+	assert.Len(t, sub.earlyIDs, maxEarlyOperations)
+	assert.NotContains(t, sub.early, "unknown-0")
+	assert.NotContains(t, sub.early, "unknown-1")
+	assert.Contains(t, sub.early, fmt.Sprintf("unknown-%d", maxEarlyOperations+1))
+	sub.mu.Unlock()
+
+	// The events of the operations that are registered later are still delivered.
+	body, err := sub.Body(context.Background(), testBlockHash(1))
+	assert.NoError(t, err)
+	assert.Len(t, body, 2)
+}
@@ -0,0 +1,190 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// operation is an operation started by one of the chainHead_v1 methods, whose results are reported by follow
+// events.
+type operation struct {
+	id     string
+	events []followEvent
+	notify chan struct{}
+}
+
+// push queues the event of the operation. It must be called with the lock of the subscription held.
+func (o *operation) push(ev followEvent) {
+	o.events = append(o.events, ev)
+
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+// maxEarlyOperations is the maximum number of unregistered operations whose events are kept, the events of the
+// operations that are never registered, e.g. because the method that started them failed, are eventually dropped.
+const maxEarlyOperations = 16
+
+// pushEarly keeps the event of an operation that is not registered yet, dropping the events of the oldest
+// unregistered operation if needed. It must be called with the lock of the subscription held.
+func (s *FollowSubscription) pushEarly(ev followEvent) {
+	if _, ok := s.early[ev.OperationID]; !ok {
+		if len(s.earlyIDs) == maxEarlyOperations {
+			delete(s.early, s.earlyIDs[0])
+			s.earlyIDs = s.earlyIDs[1:]
+		}
+
+		s.earlyIDs = append(s.earlyIDs, ev.OperationID)
+	}
+
+	s.early[ev.OperationID] = append(s.early[ev.OperationID], ev)
+}
+
+// takeEarly returns and forgets the events received before the operation was registered. It must be called with
+// the lock of the subscription held.
+func (s *FollowSubscription) takeEarly(operationID string) []followEvent {
+	events, ok := s.early[operationID]
+	if !ok {
+		return nil
+	}
+
+	delete(s.early, operationID)
+
+	for i, id := range s.earlyIDs {
+		if id == operationID {
+			s.earlyIDs = append(s.earlyIDs[:i:i], s.earlyIDs[i+1:]...)
+			break
+		}
+	}
+
+	return events
+}
+
+// checkPinned returns ErrBlockNotPinned if the block is not pinned, or the error that ended the subscription.
+func (s *FollowSubscription) checkPinned(hash types.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if _, ok := s.pinned[hash]; !ok {
+		return fmt.Errorf("%w: %s", ErrBlockNotPinned, hash.Hex())
+	}
+
+	return nil
+}
+
+// startOperation calls the method, which starts an operation, and registers the operation.
+func (s *FollowSubscription) startOperation(
+	ctx context.Context,
+	method string,
+	args ...interface{},
+) (*operation, int, error) {
+	var res operationStarted
+
+	if err := s.client.CallContext(ctx, &res, method, args...); err != nil {
+		return nil, 0, err
+	}
+
+	switch res.Result {
+	case operationStartedResult:
+	case operationLimitReachedResult:
+		return nil, 0, ErrLimitReached
+	default:
+		return nil, 0, fmt.Errorf("%s: unexpected result %q", method, res.Result)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+
+	op := &operation{
+		id:     res.OperationID,
+		events: s.takeEarly(res.OperationID),
+		notify: make(chan struct{}, 1),
+	}
+
+	s.operations[op.id] = op
+
+	return op, res.DiscardedItems, nil
+}
+
+// next waits for the next event of the operation. The operation is stopped if the context is done.
+func (s *FollowSubscription) next(ctx context.Context, op *operation) (followEvent, error) {
+	for {
+		s.mu.Lock()
+
+		if len(op.events) > 0 {
+			ev := op.events[0]
+			op.events = op.events[1:]
+			s.mu.Unlock()
+
+			return ev, nil
+		}
+
+		s.mu.Unlock()
+
+		select {
+		case <-op.notify:
+		case <-s.done:
+			return followEvent{}, s.Err()
+		case <-ctx.Done():
+			s.stopOperation(ctx, op)
+
+			return followEvent{}, ctx.Err()
+		}
+	}
+}
+
+// finishOperation unregisters the operation.
+func (s *FollowSubscription) finishOperation(op *operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.operations, op.id)
+}
+
+// stopOperation stops the operation on the node, it is best effort since the operation might have already ended.
+func (s *FollowSubscription) stopOperation(ctx context.Context, op *operation) {
+	var res interface{}
+
+	_ = s.client.CallContext(context.WithoutCancel(ctx), &res, "chainHead_v1_stopOperation", s.ID(), op.id)
+}
+
+// operationError returns the error reported by an operation event, or nil if the event does not end the operation
+// with an error.
+func operationError(ev followEvent) error {
+	switch ev.Type {
+	case followEventOperationInaccessible:
+		return ErrOperationInaccessible
+	case followEventOperationError:
+		return fmt.Errorf("%w: %s", ErrOperationFailed, ev.Error)
+	default:
+		return nil
+	}
+}
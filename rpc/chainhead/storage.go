@@ -0,0 +1,129 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Storage queries the storage of a pinned block, in the child trie if childTrie is not nil. The descendants queries
+// return all the keys that start with the queried key, the node sends them in pages which are all collected.
+func (s *FollowSubscription) Storage(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []StorageQueryItem,
+	childTrie *types.StorageKey,
+) ([]StorageResult, error) {
+	var results []StorageResult
+
+	err := s.StorageEach(ctx, blockHash, items, childTrie, func(page []StorageResult) error {
+		results = append(results, page...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// StorageEach queries the storage of a pinned block like Storage, but calls fn with each page of results as soon as
+// it is received instead of collecting them, which bounds the memory used by large descendants queries. The next
+// page is only requested once fn returns, the query is stopped if fn returns an error.
+//
+// The items that the node discards because of its limits are queried again once the query of the other items is
+// done.
+func (s *FollowSubscription) StorageEach(
+	ctx context.Context,
+	blockHash types.Hash,
+	items []StorageQueryItem,
+	childTrie *types.StorageKey,
+	fn func([]StorageResult) error,
+) error {
+	if err := s.checkPinned(blockHash); err != nil {
+		return err
+	}
+
+	var childTrieHex *string
+
+	if childTrie != nil {
+		hex := childTrie.Hex()
+		childTrieHex = &hex
+	}
+
+	for len(items) > 0 {
+		op, discarded, err := s.startOperation(ctx, "chainHead_v1_storage", s.ID(), blockHash.Hex(), items, childTrieHex)
+		if err != nil {
+			return err
+		}
+
+		err = s.storagePages(ctx, op, fn)
+
+		s.finishOperation(op)
+
+		if err != nil {
+			return err
+		}
+
+		if discarded >= len(items) {
+			return ErrLimitReached
+		}
+
+		items = items[len(items)-discarded:]
+	}
+
+	return nil
+}
+
+// storagePages calls fn with the pages of results of the storage operation, until it is done.
+func (s *FollowSubscription) storagePages(ctx context.Context, op *operation, fn func([]StorageResult) error) error {
+	for {
+		ev, err := s.next(ctx, op)
+		if err != nil {
+			return err
+		}
+
+		if err := operationError(ev); err != nil {
+			return err
+		}
+
+		switch ev.Type {
+		case followEventOperationStorageItems:
+			if err := fn(ev.Items); err != nil {
+				s.stopOperation(ctx, op)
+
+				return err
+			}
+		case followEventOperationWaitingForContinue:
+			var res interface{}
+
+			if err := s.client.CallContext(ctx, &res, "chainHead_v1_continue", s.ID(), op.id); err != nil {
+				s.stopOperation(ctx, op)
+
+				return err
+			}
+		case followEventOperationStorageDone:
+			return nil
+		default:
+			return fmt.Errorf("%w: %s event for storage operation", ErrInvalidFollowEvent, ev.Type)
+		}
+	}
+}
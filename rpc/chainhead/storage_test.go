@@ -0,0 +1,91 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+func storageResultValues(results []StorageResult) map[string]string {
+	values := make(map[string]string, len(results))
+
+	for _, res := range results {
+		values[res.Key.Hex()] = codec.HexEncodeToString(*res.Value)
+	}
+
+	return values
+}
+
+func TestFollowSubscription_Storage(t *testing.T) {
+	sub := follow(t)
+
+	items := []StorageQueryItem{
+		{Key: types.NewStorageKey([]byte{0x01}), Type: StorageQueryValue},
+		{Key: types.NewStorageKey([]byte{0x02}), Type: StorageQueryDescendantsValues},
+		// Discarded by the node, which only processes two items per operation.
+		{Key: types.NewStorageKey([]byte{0x03}), Type: StorageQueryValue},
+		{Key: types.NewStorageKey([]byte{0x04}), Type: StorageQueryValue},
+	}
+
+	results, err := sub.Storage(context.Background(), testBlockHash(0), items, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"0x01":   "0xaa",
+		"0x0201": "0xbb",
+		"0x0202": "0xcc",
+		"0x0203": "0xdd",
+		"0x03":   "0xee",
+	}, storageResultValues(results))
+}
+
+func TestFollowSubscription_StorageEach(t *testing.T) {
+	sub := follow(t)
+
+	items := []StorageQueryItem{
+		{Key: types.NewStorageKey([]byte{0x02}), Type: StorageQueryDescendantsValues},
+	}
+
+	var pages [][]StorageResult
+
+	err := sub.StorageEach(context.Background(), testBlockHash(0), items, nil, func(page []StorageResult) error {
+		pages = append(pages, page)
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Len(t, pages[0], 2)
+	assert.Len(t, pages[1], 1)
+
+	errStop := errors.New("stop")
+
+	err = sub.StorageEach(context.Background(), testBlockHash(0), items, nil, func(page []StorageResult) error {
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Contains(t, mockSrv.stoppedOperations(), mockSrv.lastOperation())
+
+	_, err = sub.Storage(context.Background(), testBlockHash(1), items, nil)
+	assert.ErrorIs(t, err, ErrBlockNotPinned)
+}
@@ -0,0 +1,54 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Unpin unpins the blocks, which can no longer be queried. The blocks reported by the follow events must be
+// unpinned once they are no longer needed, the node stops the subscription if too many blocks are pinned.
+func (s *FollowSubscription) Unpin(ctx context.Context, blockHashes ...types.Hash) error {
+	for _, hash := range blockHashes {
+		if err := s.checkPinned(hash); err != nil {
+			return err
+		}
+	}
+
+	hashes := make([]string, len(blockHashes))
+
+	for i, hash := range blockHashes {
+		hashes[i] = hash.Hex()
+	}
+
+	var res interface{}
+
+	if err := s.client.CallContext(ctx, &res, "chainHead_v1_unpin", s.ID(), hashes); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, hash := range blockHashes {
+		delete(s.pinned, hash)
+	}
+
+	return nil
+}
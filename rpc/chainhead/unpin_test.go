@@ -0,0 +1,50 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainhead
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestFollowSubscription_Unpin(t *testing.T) {
+	sub := follow(t)
+
+	mockSrv.emit(map[string]interface{}{
+		"event":           "newBlock",
+		"blockHash":       testBlockHash(1),
+		"parentBlockHash": testBlockHash(0),
+	})
+
+	nextEvent(t, sub)
+
+	err := sub.Unpin(context.Background(), testBlockHash(0))
+	assert.NoError(t, err)
+	assert.False(t, sub.IsPinned(testBlockHash(0)))
+	assert.Equal(t, []types.Hash{testBlockHash(1)}, sub.Pinned())
+	assert.Contains(t, mockSrv.unpinnedHashes(), testBlockHash(0).Hex())
+
+	err = sub.Unpin(context.Background(), testBlockHash(0))
+	assert.ErrorIs(t, err, ErrBlockNotPinned)
+
+	_, err = sub.Body(context.Background(), testBlockHash(0))
+	assert.ErrorIs(t, err, ErrBlockNotPinned)
+}
@@ -0,0 +1,76 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainspec
+
+import (
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var testChainSpec ChainSpec
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("chainSpec", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testChainSpec = NewChainSpec(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	chainName   string
+	genesisHash types.Hash
+	properties  types.ChainProperties
+}
+
+func (s *MockSrv) V1_chainName() string {
+	return mockSrv.chainName
+}
+
+func (s *MockSrv) V1_genesisHash() types.Hash {
+	return mockSrv.genesisHash
+}
+
+func (s *MockSrv) V1_properties() types.ChainProperties {
+	return mockSrv.properties
+}
+
+var mockSrv = MockSrv{
+	chainName:   "Development",
+	genesisHash: types.NewHash([]byte{0x01, 0x02, 0x03}),
+	properties: types.ChainProperties{
+		IsSS58Format:    true,
+		AsSS58Format:    42,
+		IsTokenDecimals: true,
+		AsTokenDecimals: 12,
+		IsTokenSymbol:   true,
+		AsTokenSymbol:   "UNIT",
+	},
+}
@@ -0,0 +1,51 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Grandpa --filename grandpa.go
+
+package grandpa
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ErrFinalityProofUnavailable is returned when the node cannot prove the finality of the queried block.
+var ErrFinalityProofUnavailable = errors.New("finality proof unavailable")
+
+// Grandpa exposes the methods of the GRANDPA finality gadget.
+type Grandpa interface {
+	// RoundState returns the state of the current best round and of the background rounds.
+	RoundState(ctx context.Context) (ReportedRoundStates, error)
+	// ProveFinality returns the proof of finality of the block, which is the justification of the first block
+	// finalized after it, together with the headers between the two blocks.
+	ProveFinality(ctx context.Context, blockNumber uint32) (*types.GrandpaFinalityProof, error)
+	// SubscribeJustifications subscribes to the justifications of the blocks finalized by GRANDPA.
+	SubscribeJustifications(ctx context.Context) (*JustificationsSubscription, error)
+}
+
+// grandpa exposes methods for querying the GRANDPA finality gadget
+type grandpa struct {
+	client client.Client
+}
+
+// NewGrandpa creates a new grandpa struct
+func NewGrandpa(cl client.Client) Grandpa {
+	return &grandpa{cl}
+}
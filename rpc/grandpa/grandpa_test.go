@@ -0,0 +1,140 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var testGrandpa Grandpa
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("grandpa", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.RegisterSubscription("grandpa_subscribeJustifications", "")
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testGrandpa = NewGrandpa(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	roundStates    ReportedRoundStates
+	finalizedBlock uint32
+	justification  types.GrandpaJustification
+}
+
+var mockSrv = MockSrv{
+	roundStates: ReportedRoundStates{
+		SetID: 3,
+		Best: RoundState{
+			Round:           42,
+			TotalWeight:     4,
+			ThresholdWeight: 3,
+			Prevotes:        Votes{CurrentWeight: 4, Missing: []string{}},
+			Precommits: Votes{
+				CurrentWeight: 3,
+				Missing:       []string{"5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"},
+			},
+		},
+		Background: []RoundState{},
+	},
+	finalizedBlock: 10,
+	justification: types.GrandpaJustification{
+		Round: 42,
+		Commit: types.GrandpaCommit{
+			TargetHash:   types.NewHash([]byte{0x0a}),
+			TargetNumber: 10,
+			Precommits: []types.GrandpaSignedPrecommit{
+				{
+					Precommit: types.GrandpaPrecommit{TargetHash: types.NewHash([]byte{0x0a}), TargetNumber: 10},
+					Signature: types.SignatureHash{0x01},
+					ID:        types.AuthorityID{0x02},
+				},
+			},
+		},
+		VotesAncestries: []types.Header{{Number: 10, ParentHash: types.NewHash([]byte{0x09})}},
+	},
+}
+
+func (s *MockSrv) RoundState() ReportedRoundStates {
+	return mockSrv.roundStates
+}
+
+func (s *MockSrv) ProveFinality(blockNumber uint32) (*string, error) {
+	if blockNumber > mockSrv.finalizedBlock {
+		return nil, nil
+	}
+
+	justification, err := codec.Encode(mockSrv.justification)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := codec.EncodeToHex(types.GrandpaFinalityProof{
+		Block:          mockSrv.justification.Commit.TargetHash,
+		Justification:  justification,
+		UnknownHeaders: []types.Header{{Number: types.BlockNumber(blockNumber)}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proof, nil
+}
+
+func (s *MockSrv) SubscribeJustifications(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, ok := gethrpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+
+	justification, err := codec.EncodeToHex(mockSrv.justification)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = notifier.Notify(sub.ID, justification)
+
+	return sub, nil
+}
+
+func (s *MockSrv) UnsubscribeJustifications(id string) bool {
+	return true
+}
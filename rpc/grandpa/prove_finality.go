@@ -0,0 +1,47 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ProveFinality returns the proof of finality of the block, which is the justification of the first block
+// finalized after it, together with the headers between the two blocks.
+func (g *grandpa) ProveFinality(ctx context.Context, blockNumber uint32) (*types.GrandpaFinalityProof, error) {
+	var res *string
+
+	if err := g.client.CallContext(ctx, &res, "grandpa_proveFinality", blockNumber); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, fmt.Errorf("%w: block %d", ErrFinalityProofUnavailable, blockNumber)
+	}
+
+	var proof types.GrandpaFinalityProof
+
+	if err := codec.DecodeFromHex(*res, &proof); err != nil {
+		return nil, err
+	}
+
+	return &proof, nil
+}
@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestGrandpa_ProveFinality(t *testing.T) {
+	proof, err := testGrandpa.ProveFinality(context.Background(), 8)
+	require.NoError(t, err)
+	assert.Equal(t, mockSrv.justification.Commit.TargetHash, proof.Block)
+	assert.Equal(t, []types.Header{{Number: 8}}, proof.UnknownHeaders)
+
+	justification, err := proof.DecodeJustification()
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.justification, justification)
+
+	_, err = testGrandpa.ProveFinality(context.Background(), 11)
+	assert.ErrorIs(t, err, ErrFinalityProofUnavailable)
+}
@@ -0,0 +1,54 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ReportedRoundStates is the state of the rounds of the current authority set
+type ReportedRoundStates struct {
+	SetID      types.U32    `json:"setId"`
+	Best       RoundState   `json:"best"`
+	Background []RoundState `json:"background"`
+}
+
+// RoundState is the state of a round, with the weights of the votes received so far
+type RoundState struct {
+	Round           types.U32 `json:"round"`
+	TotalWeight     types.U32 `json:"totalWeight"`
+	ThresholdWeight types.U32 `json:"thresholdWeight"`
+	Prevotes        Votes     `json:"prevotes"`
+	Precommits      Votes     `json:"precommits"`
+}
+
+// Votes holds the weight of the votes of a round and the SS58 addresses of the authorities that did not vote
+type Votes struct {
+	CurrentWeight types.U32 `json:"currentWeight"`
+	Missing       []string  `json:"missing"`
+}
+
+// RoundState returns the state of the current best round and of the background rounds.
+func (g *grandpa) RoundState(ctx context.Context) (ReportedRoundStates, error) {
+	var res ReportedRoundStates
+
+	err := g.client.CallContext(ctx, &res, "grandpa_roundState")
+
+	return res, err
+}
@@ -0,0 +1,69 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// GenerateLeavesProof generates the proof of the leaves of the blocks with the numbers, at the block. The proof is
+// generated against the MMR of the best known block if bestKnownBlockNumber is not nil.
+func (c *mmr) GenerateLeavesProof(
+	ctx context.Context,
+	blockNumbers []uint32,
+	bestKnownBlockNumber *uint32,
+	blockHash types.Hash,
+) (types.MMRLeavesProof, error) {
+	return c.generateLeavesProof(ctx, blockNumbers, bestKnownBlockNumber, &blockHash)
+}
+
+// GenerateLeavesProofLatest generates the proof of the leaves of the blocks with the numbers, at the best block.
+// The proof is generated against the MMR of the best known block if bestKnownBlockNumber is not nil.
+func (c *mmr) GenerateLeavesProofLatest(
+	ctx context.Context,
+	blockNumbers []uint32,
+	bestKnownBlockNumber *uint32,
+) (types.MMRLeavesProof, error) {
+	return c.generateLeavesProof(ctx, blockNumbers, bestKnownBlockNumber, nil)
+}
+
+func (c *mmr) generateLeavesProof(
+	ctx context.Context,
+	blockNumbers []uint32,
+	bestKnownBlockNumber *uint32,
+	blockHash *types.Hash,
+) (types.MMRLeavesProof, error) {
+	var res types.MMRLeavesProof
+
+	err := client.CallWithBlockHashContext(
+		ctx,
+		c.client,
+		&res,
+		"mmr_generateProof",
+		blockHash,
+		blockNumbers,
+		bestKnownBlockNumber,
+	)
+	if err != nil {
+		return types.MMRLeavesProof{}, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,63 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mmrlib "github.com/centrifuge/go-substrate-rpc-client/v4/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestMMR_GenerateLeavesProof(t *testing.T) {
+	proof, err := testMMR.GenerateLeavesProof(context.Background(), []uint32{3, 7}, nil, mockSrv.blockHash)
+	require.NoError(t, err)
+	assert.Equal(t, types.H256(mockSrv.blockHash), proof.BlockHash)
+	assert.Equal(t, []types.U64{2, 6}, proof.Proof.LeafIndices)
+	assert.Equal(t, types.U64(10), proof.Proof.LeafCount)
+
+	leaves, err := proof.DecodeLeaves()
+	assert.NoError(t, err)
+	require.Len(t, leaves, 2)
+	assert.Equal(t, types.U32(6), leaves[1].ParentNumberAndHash.ParentNumber)
+
+	root, err := mockSrv.tree(10).Root()
+	require.NoError(t, err)
+	assert.NoError(t, mmrlib.VerifyLeavesProof(root, proof))
+
+	_, err = testMMR.GenerateLeavesProof(context.Background(), []uint32{3}, nil, types.NewHash([]byte{0xff}))
+	assert.Error(t, err)
+}
+
+func TestMMR_GenerateLeavesProofLatest(t *testing.T) {
+	bestKnown := uint32(8)
+
+	proof, err := testMMR.GenerateLeavesProofLatest(context.Background(), []uint32{5}, &bestKnown)
+	require.NoError(t, err)
+	assert.Equal(t, types.U64(8), proof.Proof.LeafCount)
+
+	root, err := mockSrv.tree(8).Root()
+	require.NoError(t, err)
+	assert.NoError(t, mmrlib.VerifyLeavesProof(root, proof))
+
+	_, err = testMMR.GenerateLeavesProofLatest(context.Background(), []uint32{9}, &bestKnown)
+	assert.Error(t, err)
+}
@@ -27,8 +27,37 @@ import (
 
 // MMR exposes methods for retrieval of MMR data
 type MMR interface {
+	// GenerateProof generates the proof of the leaf with the index, with the single leaf API of the older nodes.
+	//
+	// Deprecated: use GenerateLeavesProof, which is the API of the current nodes.
 	GenerateProof(ctx context.Context, leafIndex uint64, blockHash types.Hash) (types.GenerateMMRProofResponse, error)
+	// GenerateProofLatest generates the proof of the leaf with the index, with the single leaf API of the older nodes.
+	//
+	// Deprecated: use GenerateLeavesProofLatest, which is the API of the current nodes.
 	GenerateProofLatest(ctx context.Context, leafIndex uint64) (types.GenerateMMRProofResponse, error)
+	// Root returns the root of the MMR at the block.
+	Root(ctx context.Context, blockHash types.Hash) (types.H256, error)
+	// RootLatest returns the root of the MMR at the best block.
+	RootLatest(ctx context.Context) (types.H256, error)
+	// GenerateLeavesProof generates the proof of the leaves of the blocks with the numbers, at the block. The proof is
+	// generated against the MMR of the best known block if bestKnownBlockNumber is not nil.
+	GenerateLeavesProof(
+		ctx context.Context,
+		blockNumbers []uint32,
+		bestKnownBlockNumber *uint32,
+		blockHash types.Hash,
+	) (types.MMRLeavesProof, error)
+	// GenerateLeavesProofLatest generates the proof of the leaves of the blocks with the numbers, at the best block.
+	// The proof is generated against the MMR of the best known block if bestKnownBlockNumber is not nil.
+	GenerateLeavesProofLatest(
+		ctx context.Context,
+		blockNumbers []uint32,
+		bestKnownBlockNumber *uint32,
+	) (types.MMRLeavesProof, error)
+	// VerifyProof verifies the proof against the MMR root stored on chain at the block of the proof.
+	VerifyProof(ctx context.Context, proof types.MMRLeavesProof) (bool, error)
+	// VerifyProofStateless verifies the proof against the MMR root.
+	VerifyProofStateless(ctx context.Context, root types.H256, proof types.MMRLeavesProof) (bool, error)
 }
 
 type mmr struct {
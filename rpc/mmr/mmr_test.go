@@ -0,0 +1,162 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	mmrlib "github.com/centrifuge/go-substrate-rpc-client/v4/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var testMMR MMR
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("mmr", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testMMR = NewMMR(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	blockHash types.Hash
+	// leaves holds the leaves of the blocks, the leaf of the block n being at the index n-1.
+	leaves []types.MMREncodableOpaqueLeaf
+}
+
+var mockSrv = MockSrv{
+	blockHash: types.NewHash([]byte{0x0a}),
+	leaves:    newTestLeaves(10),
+}
+
+func newTestLeaves(count int) []types.MMREncodableOpaqueLeaf {
+	var leaves []types.MMREncodableOpaqueLeaf
+
+	for i := 0; i < count; i++ {
+		leaf, err := codec.Encode(types.MMRLeaf{ParentNumberAndHash: types.ParentNumberAndHash{ParentNumber: types.U32(i)}})
+		if err != nil {
+			panic(err)
+		}
+
+		leaves = append(leaves, leaf)
+	}
+
+	return leaves
+}
+
+// tree returns the MMR of the leaves of the blocks up to the block number.
+func (s *MockSrv) tree(blockNumber uint32) *mmrlib.Tree {
+	tree := mmrlib.NewTree()
+
+	for _, leaf := range mockSrv.leaves[:blockNumber] {
+		tree.Append(mmrlib.HashOpaqueLeaf(leaf))
+	}
+
+	return tree
+}
+
+func (s *MockSrv) checkBlockHash(blockHash *types.Hash) error {
+	if blockHash != nil && *blockHash != mockSrv.blockHash {
+		return fmt.Errorf("unknown block %s", blockHash.Hex())
+	}
+
+	return nil
+}
+
+func (s *MockSrv) Root(blockHash *types.Hash) (string, error) {
+	if err := s.checkBlockHash(blockHash); err != nil {
+		return "", err
+	}
+
+	root, err := s.tree(uint32(len(mockSrv.leaves))).Root()
+	if err != nil {
+		return "", err
+	}
+
+	return root.Hex(), nil
+}
+
+func (s *MockSrv) GenerateProof(
+	blockNumbers []uint32,
+	bestKnownBlockNumber *uint32,
+	blockHash *types.Hash,
+) (*types.MMRLeavesProof, error) {
+	if err := s.checkBlockHash(blockHash); err != nil {
+		return nil, err
+	}
+
+	bestKnown := uint32(len(mockSrv.leaves))
+	if bestKnownBlockNumber != nil {
+		bestKnown = *bestKnownBlockNumber
+	}
+
+	res := types.MMRLeavesProof{BlockHash: types.H256(mockSrv.blockHash)}
+
+	var leafIndices []uint64
+
+	for _, blockNumber := range blockNumbers {
+		if blockNumber == 0 || blockNumber > bestKnown {
+			return nil, fmt.Errorf("invalid block number %d", blockNumber)
+		}
+
+		leafIndices = append(leafIndices, uint64(blockNumber-1))
+		res.Leaves = append(res.Leaves, mockSrv.leaves[blockNumber-1])
+	}
+
+	proof, err := s.tree(bestKnown).Proof(leafIndices...)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Proof = proof
+
+	return &res, nil
+}
+
+func (s *MockSrv) VerifyProof(proof types.MMRLeavesProof) (bool, error) {
+	root, err := s.tree(uint32(len(mockSrv.leaves))).Root()
+	if err != nil {
+		return false, err
+	}
+
+	return mmrlib.VerifyLeavesProof(root, proof) == nil, nil
+}
+
+func (s *MockSrv) VerifyProofStateless(root string, proof types.MMRLeavesProof) (bool, error) {
+	var h types.H256
+
+	if err := codec.DecodeFromHex(root, &h); err != nil {
+		return false, err
+	}
+
+	return mmrlib.VerifyLeavesProof(h, proof) == nil, nil
+}
@@ -0,0 +1,52 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Root returns the root of the MMR at the block.
+func (c *mmr) Root(ctx context.Context, blockHash types.Hash) (types.H256, error) {
+	return c.root(ctx, &blockHash)
+}
+
+// RootLatest returns the root of the MMR at the best block.
+func (c *mmr) RootLatest(ctx context.Context) (types.H256, error) {
+	return c.root(ctx, nil)
+}
+
+func (c *mmr) root(ctx context.Context, blockHash *types.Hash) (types.H256, error) {
+	var res string
+
+	err := client.CallWithBlockHashContext(ctx, c.client, &res, "mmr_root", blockHash)
+	if err != nil {
+		return types.H256{}, err
+	}
+
+	var root types.H256
+
+	if err := codec.DecodeFromHex(res, &root); err != nil {
+		return types.H256{}, err
+	}
+
+	return root, nil
+}
@@ -0,0 +1,43 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+func TestMMR_Root(t *testing.T) {
+	expected, err := mockSrv.tree(10).Root()
+	require.NoError(t, err)
+
+	root, err := testMMR.Root(context.Background(), mockSrv.blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, root)
+
+	root, err = testMMR.RootLatest(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, root)
+
+	_, err = testMMR.Root(context.Background(), types.NewHash([]byte{0xff}))
+	assert.Error(t, err)
+}
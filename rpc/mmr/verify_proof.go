@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// VerifyProof verifies the proof against the MMR root stored on chain at the block of the proof.
+func (c *mmr) VerifyProof(ctx context.Context, proof types.MMRLeavesProof) (bool, error) {
+	var res bool
+
+	err := c.client.CallContext(ctx, &res, "mmr_verifyProof", proof)
+
+	return res, err
+}
+
+// VerifyProofStateless verifies the proof against the MMR root.
+func (c *mmr) VerifyProofStateless(ctx context.Context, root types.H256, proof types.MMRLeavesProof) (bool, error) {
+	var res bool
+
+	err := c.client.CallContext(ctx, &res, "mmr_verifyProofStateless", root.Hex(), proof)
+
+	return res, err
+}
@@ -0,0 +1,61 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMMR_VerifyProof(t *testing.T) {
+	proof, err := testMMR.GenerateLeavesProofLatest(context.Background(), []uint32{1, 10}, nil)
+	require.NoError(t, err)
+
+	ok, err := testMMR.VerifyProof(context.Background(), proof)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	proof.Leaves[0], proof.Leaves[1] = proof.Leaves[1], proof.Leaves[0]
+
+	ok, err = testMMR.VerifyProof(context.Background(), proof)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMMR_VerifyProofStateless(t *testing.T) {
+	bestKnown := uint32(6)
+
+	proof, err := testMMR.GenerateLeavesProofLatest(context.Background(), []uint32{2}, &bestKnown)
+	require.NoError(t, err)
+
+	root, err := mockSrv.tree(6).Root()
+	require.NoError(t, err)
+
+	ok, err := testMMR.VerifyProofStateless(context.Background(), root, proof)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	latestRoot, err := testMMR.RootLatest(context.Background())
+	require.NoError(t, err)
+
+	ok, err = testMMR.VerifyProofStateless(context.Background(), latestRoot, proof)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -19,6 +19,8 @@
 package offchain
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -26,6 +28,8 @@ import (
 type Offchain interface {
 	LocalStorageGet(kind StorageKind, key []byte) (*types.StorageDataRaw, error)
 	LocalStorageSet(kind StorageKind, key []byte, value []byte) error
+	LocalStorageGetContext(ctx context.Context, kind StorageKind, key []byte) (*types.StorageDataRaw, error)
+	LocalStorageSetContext(ctx context.Context, kind StorageKind, key []byte, value []byte) error
 }
 
 // offchain exposes methods for retrieval of off-chain data
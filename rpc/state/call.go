@@ -0,0 +1,133 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	ErrSessionKeysNotDecodable = libErr.Error("session keys not decodable by the runtime")
+
+	queryCallInfoMethod     = "TransactionPaymentCallApi_query_call_info"
+	decodeSessionKeysMethod = "SessionKeys_decode_session_keys"
+)
+
+// CallRaw calls the runtime API method with the SCALE encoded args at the given block and returns the SCALE encoded
+// result, without decoding it
+func (s *state) CallRaw(ctx context.Context, method string, args []byte, blockHash types.Hash) (types.Bytes, error) {
+	return s.callRaw(ctx, method, args, &blockHash)
+}
+
+// CallRawLatest calls the runtime API method with the SCALE encoded args at the latest block and returns the SCALE
+// encoded result, without decoding it
+func (s *state) CallRawLatest(ctx context.Context, method string, args []byte) (types.Bytes, error) {
+	return s.callRaw(ctx, method, args, nil)
+}
+
+func (s *state) callRaw(ctx context.Context, method string, args []byte, blockHash *types.Hash) (types.Bytes, error) {
+	var res string
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_call", blockHash, method, codec.HexEncodeToString(args))
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.HexDecodeString(res)
+}
+
+// QueryCallInfo returns the dispatch info of the SCALE encoded call, as estimated by the
+// TransactionPaymentCallApi_query_call_info runtime API for an extrinsic of the given length, at the given block or
+// at the latest block if blockHash is nil
+func QueryCallInfo(
+	ctx context.Context,
+	s State,
+	call []byte,
+	extrinsicLength uint32,
+	blockHash *types.Hash,
+) (types.RuntimeDispatchInfo, error) {
+	encodedLength, err := codec.Encode(types.NewU32(extrinsicLength))
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	args := append(append([]byte{}, call...), encodedLength...)
+
+	var res types.Bytes
+
+	if blockHash == nil {
+		res, err = s.CallRawLatest(ctx, queryCallInfoMethod, args)
+	} else {
+		res, err = s.CallRaw(ctx, queryCallInfoMethod, args, *blockHash)
+	}
+
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	var info types.RuntimeDispatchInfo
+
+	if err := codec.Decode(res, &info); err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	return info, nil
+}
+
+// DecodeSessionKeys decodes the SCALE encoded session keys, as returned by author_rotateKeys, into the public key and
+// key type of every session key, using the SessionKeys runtime API at the given block or at the latest block if
+// blockHash is nil
+func DecodeSessionKeys(
+	ctx context.Context,
+	s State,
+	sessionKeys []byte,
+	blockHash *types.Hash,
+) ([]types.SessionKey, error) {
+	args, err := codec.Encode(types.NewBytes(sessionKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.Bytes
+
+	if blockHash == nil {
+		res, err = s.CallRawLatest(ctx, decodeSessionKeysMethod, args)
+	} else {
+		res, err = s.CallRaw(ctx, decodeSessionKeysMethod, args, *blockHash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded types.Option[[]types.SessionKey]
+
+	if err := codec.Decode(res, &decoded); err != nil {
+		return nil, err
+	}
+
+	ok, keys := decoded.Unwrap()
+	if !ok {
+		return nil, ErrSessionKeysNotDecodable
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,80 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_CallRawLatest(t *testing.T) {
+	res, err := testState.CallRawLatest(context.Background(), mockSrv.callMethod, codec.MustHexDecodeString(mockSrv.callArgsHex))
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString(mockSrv.callResultHex), []byte(res))
+}
+
+func TestState_CallRaw(t *testing.T) {
+	res, err := testState.CallRaw(
+		context.Background(),
+		mockSrv.callMethod,
+		codec.MustHexDecodeString(mockSrv.callArgsHex),
+		mockSrv.blockHashLatest,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString(mockSrv.callResultHex), []byte(res))
+
+	_, err = testState.CallRaw(context.Background(), "Unknown_method", nil, mockSrv.blockHashLatest)
+	assert.Error(t, err)
+}
+
+func TestQueryCallInfo(t *testing.T) {
+	call := []byte{0x01, 0x02}
+
+	for _, blockHash := range []*types.Hash{nil, &mockSrv.blockHashLatest} {
+		info, err := QueryCallInfo(context.Background(), testState, call, 10, blockHash)
+		assert.NoError(t, err)
+		assert.Equal(t, types.RuntimeDispatchInfo{
+			Weight:     types.NewWeight(types.NewUCompactFromUInt(11), types.NewUCompactFromUInt(12)),
+			Class:      types.DispatchClass{IsNormal: true},
+			PartialFee: types.NewU128(*big.NewInt(13)),
+		}, info)
+	}
+
+	_, err := QueryCallInfo(context.Background(), testState, call, 11, nil)
+	assert.Error(t, err)
+}
+
+func TestDecodeSessionKeys(t *testing.T) {
+	sessionKeys := []byte{1, 2, 3, 4, 5}
+
+	for _, blockHash := range []*types.Hash{nil, &mockSrv.blockHashLatest} {
+		keys, err := DecodeSessionKeys(context.Background(), testState, sessionKeys, blockHash)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.SessionKey{
+			{PublicKey: types.NewBytes([]byte{1, 2, 3}), KeyType: types.NewKeyTypeID("babe")},
+			{PublicKey: types.NewBytes([]byte{4, 5}), KeyType: types.NewKeyTypeID("gran")},
+		}, keys)
+	}
+
+	_, err := DecodeSessionKeys(context.Background(), testState, []byte{1, 2, 3}, nil)
+	assert.ErrorIs(t, err, ErrSessionKeysNotDecodable)
+}
@@ -0,0 +1,48 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetChildReadProofLatest(t *testing.T) {
+	proof, err := testState.GetChildReadProofLatest(context.Background(), childStorageKey, []types.StorageKey{key})
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.childReadProof, proof)
+}
+
+func TestState_GetChildReadProof(t *testing.T) {
+	proof, err := testState.GetChildReadProof(
+		context.Background(),
+		childStorageKey,
+		[]types.StorageKey{key},
+		mockSrv.blockHashLatest,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.childReadProof, proof)
+
+	values, err := trie.VerifyChildProof(mockSrv.stateRoot, proof.Proof, childStorageKey, []types.StorageKey{key})
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString(mockSrv.childStorageTrieValueHex), []byte(values[0].StorageData))
+}
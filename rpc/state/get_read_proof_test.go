@@ -0,0 +1,54 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetReadProofLatest(t *testing.T) {
+	key := types.NewStorageKey(codec.MustHexDecodeString(mockSrv.storageKeyHex))
+
+	proof, err := testState.GetReadProofLatest(context.Background(), []types.StorageKey{key})
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.readProof, proof)
+}
+
+func TestState_GetReadProof(t *testing.T) {
+	key := types.NewStorageKey(codec.MustHexDecodeString(mockSrv.storageKeyHex))
+
+	proof, err := testState.GetReadProof(context.Background(), []types.StorageKey{key}, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, &mockSrv.readProof, proof)
+
+	values, err := trie.VerifyProof(mockSrv.stateRoot, proof.Proof, []types.StorageKey{key})
+	assert.NoError(t, err)
+	assert.Equal(t, codec.MustHexDecodeString(mockSrv.storageDataHex), []byte(values[0].StorageData))
+}
+
+func TestState_GetReadProof_Error(t *testing.T) {
+	key := types.NewStorageKey(codec.MustHexDecodeString(mockSrv.storageKeyHexEmpty))
+
+	_, err := testState.GetReadProof(context.Background(), []types.StorageKey{key}, mockSrv.blockHashLatest)
+	assert.EqualError(t, err, "key not found")
+}
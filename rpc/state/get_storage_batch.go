@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// StorageResult is the result of the retrieval of a single key by GetStorageRawBatch.
+type StorageResult struct {
+	Key types.StorageKey
+	// Data is the stored data, which is empty if there is no value stored for the key. It is nil if Err is set.
+	Data *types.StorageDataRaw
+	// Err is the error returned for the key.
+	Err error
+}
+
+// GetStorageRawBatch retrieves the stored data of the provided keys as raw bytes, using JSON-RPC batch requests.
+// The results are returned in the order of the keys, with the error of each key reported in its result. The
+// returned error is only set if the requests could not be sent.
+func (s *state) GetStorageRawBatch(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash types.Hash,
+) ([]StorageResult, error) {
+	return s.getStorageRawBatch(ctx, keys, &blockHash)
+}
+
+// GetStorageRawBatchLatest retrieves the stored data of the provided keys for the latest block height as raw bytes,
+// using JSON-RPC batch requests. Since the latest block can change while the requests are processed, GetStorageRawBatch
+// should be preferred when the values need to be consistent.
+func (s *state) GetStorageRawBatchLatest(ctx context.Context, keys []types.StorageKey) ([]StorageResult, error) {
+	return s.getStorageRawBatch(ctx, keys, nil)
+}
+
+func (s *state) getStorageRawBatch(
+	ctx context.Context,
+	keys []types.StorageKey,
+	blockHash *types.Hash,
+) ([]StorageResult, error) {
+	var hexHash string
+
+	if blockHash != nil {
+		var err error
+
+		hexHash, err = codec.Hex(*blockHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	batch := make([]gethrpc.BatchElem, len(keys))
+	res := make([]string, len(keys))
+
+	for i, key := range keys {
+		args := []interface{}{key.Hex()}
+
+		if blockHash != nil {
+			args = append(args, hexHash)
+		}
+
+		batch[i] = gethrpc.BatchElem{
+			Method: "state_getStorage",
+			Args:   args,
+			Result: &res[i],
+		}
+	}
+
+	if err := client.BatchCallInChunks(ctx, s.client, batch, client.DefaultBatchSize); err != nil {
+		return nil, err
+	}
+
+	results := make([]StorageResult, len(keys))
+
+	for i, key := range keys {
+		results[i].Key = key
+
+		if batch[i].Error != nil {
+			results[i].Err = batch[i].Error
+			continue
+		}
+
+		bz, err := codec.HexDecodeString(res[i])
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		data := types.NewStorageDataRaw(bz)
+		results[i].Data = &data
+	}
+
+	return results, nil
+}
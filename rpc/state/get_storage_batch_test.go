@@ -0,0 +1,73 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetStorageRawBatch(t *testing.T) {
+	keys := []types.StorageKey{
+		codec.MustHexDecodeString(mockSrv.storageKeyHex),
+		codec.MustHexDecodeString(mockSrv.storageKeyHexError),
+		codec.MustHexDecodeString(mockSrv.storageKeyHexEmpty),
+	}
+
+	res, err := testState.GetStorageRawBatch(context.Background(), keys, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assertStorageResults(t, keys, res)
+}
+
+func TestState_GetStorageRawBatchLatest(t *testing.T) {
+	keys := []types.StorageKey{
+		codec.MustHexDecodeString(mockSrv.storageKeyHex),
+		codec.MustHexDecodeString(mockSrv.storageKeyHexError),
+		codec.MustHexDecodeString(mockSrv.storageKeyHexEmpty),
+	}
+
+	res, err := testState.GetStorageRawBatchLatest(context.Background(), keys)
+	assert.NoError(t, err)
+	assertStorageResults(t, keys, res)
+}
+
+func TestState_GetStorageRawBatchEmpty(t *testing.T) {
+	res, err := testState.GetStorageRawBatchLatest(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func assertStorageResults(t *testing.T, keys []types.StorageKey, res []StorageResult) {
+	assert.Len(t, res, len(keys))
+
+	for i, key := range keys {
+		assert.Equal(t, key, res[i].Key)
+	}
+
+	assert.NoError(t, res[0].Err)
+	assert.Equal(t, mockSrv.storageDataHex, res[0].Data.Hex())
+
+	assert.EqualError(t, res[1].Err, "storage unavailable")
+	assert.Nil(t, res[1].Data)
+
+	assert.NoError(t, res[2].Err)
+	assert.Empty(t, *res[2].Data)
+}
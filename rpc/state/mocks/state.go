@@ -16,6 +16,66 @@ type State struct {
 	mock.Mock
 }
 
+// CallRaw provides a mock function with given fields: ctx, method, args, blockHash
+func (_m *State) CallRaw(ctx context.Context, method string, args []byte, blockHash types.Hash) (types.Bytes, error) {
+	ret := _m.Called(ctx, method, args, blockHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CallRaw")
+	}
+
+	var r0 types.Bytes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, types.Hash) (types.Bytes, error)); ok {
+		return rf(ctx, method, args, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, types.Hash) types.Bytes); ok {
+		r0 = rf(ctx, method, args, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, types.Hash) error); ok {
+		r1 = rf(ctx, method, args, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallRawLatest provides a mock function with given fields: ctx, method, args
+func (_m *State) CallRawLatest(ctx context.Context, method string, args []byte) (types.Bytes, error) {
+	ret := _m.Called(ctx, method, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CallRawLatest")
+	}
+
+	var r0 types.Bytes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) (types.Bytes, error)); ok {
+		return rf(ctx, method, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) types.Bytes); ok {
+		r0 = rf(ctx, method, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Bytes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte) error); ok {
+		r1 = rf(ctx, method, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildKeys provides a mock function with given fields: ctx, childStorageKey, prefix, blockHash
 func (_m *State) GetChildKeys(ctx context.Context, childStorageKey types.StorageKey, prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
 	ret := _m.Called(ctx, childStorageKey, prefix, blockHash)
@@ -76,6 +136,66 @@ func (_m *State) GetChildKeysLatest(ctx context.Context, childStorageKey types.S
 	return r0, r1
 }
 
+// GetChildReadProof provides a mock function with given fields: ctx, childStorageKey, keys, blockHash
+func (_m *State) GetChildReadProof(ctx context.Context, childStorageKey types.StorageKey, keys []types.StorageKey, blockHash types.Hash) (*types.ReadProof, error) {
+	ret := _m.Called(ctx, childStorageKey, keys, blockHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildReadProof")
+	}
+
+	var r0 *types.ReadProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, []types.StorageKey, types.Hash) (*types.ReadProof, error)); ok {
+		return rf(ctx, childStorageKey, keys, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, []types.StorageKey, types.Hash) *types.ReadProof); ok {
+		r0 = rf(ctx, childStorageKey, keys, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ReadProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, childStorageKey, keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChildReadProofLatest provides a mock function with given fields: ctx, childStorageKey, keys
+func (_m *State) GetChildReadProofLatest(ctx context.Context, childStorageKey types.StorageKey, keys []types.StorageKey) (*types.ReadProof, error) {
+	ret := _m.Called(ctx, childStorageKey, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildReadProofLatest")
+	}
+
+	var r0 *types.ReadProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, []types.StorageKey) (*types.ReadProof, error)); ok {
+		return rf(ctx, childStorageKey, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.StorageKey, []types.StorageKey) *types.ReadProof); ok {
+		r0 = rf(ctx, childStorageKey, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ReadProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.StorageKey, []types.StorageKey) error); ok {
+		r1 = rf(ctx, childStorageKey, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChildStorage provides a mock function with given fields: ctx, childStorageKey, key, target, blockHash
 func (_m *State) GetChildStorage(ctx context.Context, childStorageKey types.StorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
 	ret := _m.Called(ctx, childStorageKey, key, target, blockHash)
@@ -428,6 +548,66 @@ func (_m *State) GetMetadataLatest(ctx context.Context) (*types.Metadata, error)
 	return r0, r1
 }
 
+// GetReadProof provides a mock function with given fields: ctx, keys, blockHash
+func (_m *State) GetReadProof(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) (*types.ReadProof, error) {
+	ret := _m.Called(ctx, keys, blockHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadProof")
+	}
+
+	var r0 *types.ReadProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) (*types.ReadProof, error)); ok {
+		return rf(ctx, keys, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) *types.ReadProof); ok {
+		r0 = rf(ctx, keys, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ReadProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadProofLatest provides a mock function with given fields: ctx, keys
+func (_m *State) GetReadProofLatest(ctx context.Context, keys []types.StorageKey) (*types.ReadProof, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadProofLatest")
+	}
+
+	var r0 *types.ReadProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) (*types.ReadProof, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) *types.ReadProof); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ReadProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRuntimeVersion provides a mock function with given fields: ctx, blockHash
 func (_m *State) GetRuntimeVersion(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error) {
 	ret := _m.Called(ctx, blockHash)
@@ -634,6 +814,66 @@ func (_m *State) GetStorageRaw(ctx context.Context, key types.StorageKey, blockH
 	return r0, r1
 }
 
+// GetStorageRawBatch provides a mock function with given fields: ctx, keys, blockHash
+func (_m *State) GetStorageRawBatch(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) ([]state.StorageResult, error) {
+	ret := _m.Called(ctx, keys, blockHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageRawBatch")
+	}
+
+	var r0 []state.StorageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) ([]state.StorageResult, error)); ok {
+		return rf(ctx, keys, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey, types.Hash) []state.StorageResult); ok {
+		r0 = rf(ctx, keys, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.StorageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey, types.Hash) error); ok {
+		r1 = rf(ctx, keys, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageRawBatchLatest provides a mock function with given fields: ctx, keys
+func (_m *State) GetStorageRawBatchLatest(ctx context.Context, keys []types.StorageKey) ([]state.StorageResult, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageRawBatchLatest")
+	}
+
+	var r0 []state.StorageResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) ([]state.StorageResult, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.StorageKey) []state.StorageResult); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.StorageResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.StorageKey) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageRawLatest provides a mock function with given fields: ctx, key
 func (_m *State) GetStorageRawLatest(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error) {
 	ret := _m.Called(ctx, key)
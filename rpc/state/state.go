@@ -30,6 +30,8 @@ type State interface {
 	GetStorageLatest(ctx context.Context, key types.StorageKey, target interface{}) (ok bool, err error)
 	GetStorageRaw(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error)
 	GetStorageRawLatest(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error)
+	GetStorageRawBatch(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) ([]StorageResult, error)
+	GetStorageRawBatchLatest(ctx context.Context, keys []types.StorageKey) ([]StorageResult, error)
 
 	GetChildStorageSize(ctx context.Context, childStorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error)
 	GetChildStorageSizeLatest(ctx context.Context, childStorageKey, key types.StorageKey) (types.U64, error)
@@ -68,6 +70,14 @@ type State interface {
 
 	GetChildStorageHash(ctx context.Context, childStorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error)
 	GetChildStorageHashLatest(ctx context.Context, childStorageKey, key types.StorageKey) (types.Hash, error)
+
+	CallRaw(ctx context.Context, method string, args []byte, blockHash types.Hash) (types.Bytes, error)
+	CallRawLatest(ctx context.Context, method string, args []byte) (types.Bytes, error)
+
+	GetReadProof(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) (*types.ReadProof, error)
+	GetReadProofLatest(ctx context.Context, keys []types.StorageKey) (*types.ReadProof, error)
+	GetChildReadProof(ctx context.Context, childStorageKey types.StorageKey, keys []types.StorageKey, blockHash types.Hash) (*types.ReadProof, error)
+	GetChildReadProofLatest(ctx context.Context, childStorageKey types.StorageKey, keys []types.StorageKey) (*types.ReadProof, error)
 }
 
 // state exposes methods for querying state
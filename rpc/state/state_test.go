@@ -17,12 +17,14 @@
 package state
 
 import (
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
@@ -30,8 +32,13 @@ import (
 var testState State
 
 func TestMain(m *testing.M) {
+	err := setReadProofs(&mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
 	s := rpcmocksrv.New()
-	err := s.RegisterName("state", &mockSrv)
+	err = s.RegisterName("state", &mockSrv)
 	if err != nil {
 		panic(err)
 	}
@@ -54,6 +61,7 @@ type MockSrv struct {
 	runtimeVersion           types.RuntimeVersion
 	storageKeyHex            string
 	storageKeyHexEmpty       string
+	storageKeyHexError       string
 	storageChangeSets        []types.StorageChangeSet
 	storageDataHex           string
 	storageSize              types.U64
@@ -64,6 +72,16 @@ type MockSrv struct {
 	childStorageTrieValue    ChildStorageTrieTestVal
 	childStorageTrieSize     types.U64
 	childStorageTrieHashHex  string
+	callMethod               string
+	callArgsHex              string
+	callResultHex            string
+	callInfoArgsHex          string
+	callInfoResultHex        string
+	sessionKeysArgsHex       string
+	sessionKeysResultHex     string
+	stateRoot                types.Hash // the root of the trie holding the storage and child storage values
+	readProof                types.ReadProof
+	childReadProof           types.ReadProof
 }
 
 func (s *MockSrv) GetMetadata(hash *string) string {
@@ -81,11 +99,14 @@ func (s *MockSrv) GetKeys(key string, hash *string) []string {
 	return []string{mockSrv.storageKeyHex}
 }
 
-func (s *MockSrv) GetStorage(key string, hash *string) string {
+func (s *MockSrv) GetStorage(key string, hash *string) (string, error) {
+	if key == s.storageKeyHexError {
+		return "", errors.New("storage unavailable")
+	}
 	if key != s.storageKeyHex {
-		return ""
+		return "", nil
 	}
-	return mockSrv.storageDataHex
+	return mockSrv.storageDataHex, nil
 }
 
 func (s *MockSrv) GetStorageSize(key string, hash *string) types.U64 {
@@ -161,6 +182,41 @@ func (s *MockSrv) QueryStorageAt(keys []string, hash *string) []types.StorageCha
 	return mockSrv.storageChangeSets
 }
 
+func (s *MockSrv) Call(method, args string, hash *string) (string, error) {
+	switch {
+	case method == mockSrv.callMethod && args == mockSrv.callArgsHex:
+		return mockSrv.callResultHex, nil
+	case method == queryCallInfoMethod && args == mockSrv.callInfoArgsHex:
+		return mockSrv.callInfoResultHex, nil
+	case method == decodeSessionKeysMethod && args == mockSrv.sessionKeysArgsHex:
+		return mockSrv.sessionKeysResultHex, nil
+	case method == decodeSessionKeysMethod:
+		// The runtime returns None for session keys it cannot decode.
+		return "0x00", nil
+	default:
+		return "", errors.New("runtime API method not found")
+	}
+}
+
+func (s *MockSrv) GetReadProof(keys []string, hash *string) (types.ReadProof, error) {
+	if len(keys) != 1 || keys[0] != mockSrv.storageKeyHex {
+		return types.ReadProof{}, errors.New("key not found")
+	}
+
+	return mockSrv.readProof, nil
+}
+
+func (s *MockSrv) GetChildReadProof(childStorageKey string, keys []string, hash *string) (types.ReadProof, error) {
+	if childStorageKey != mockSrv.childStorageKeyHex {
+		return types.ReadProof{}, errors.New("childStorageKey not found")
+	}
+	if len(keys) != 1 || keys[0] != mockSrv.childStorageTrieKeyHex {
+		return types.ReadProof{}, errors.New("key not found")
+	}
+
+	return mockSrv.childReadProof, nil
+}
+
 // func (s *MockSrv) SubscribeStorage(args []string) {
 // 	fmt.Println("Hit")
 // }
@@ -180,6 +236,7 @@ var mockSrv = MockSrv{
 	runtimeVersion:           types.RuntimeVersion{APIs: []types.RuntimeVersionAPI{{APIID: "0xdf6acb689907609b", Version: 0x2}, {APIID: "0x37e397fc7c91f5e4", Version: 0x1}, {APIID: "0x40fe3ad401f8959a", Version: 0x3}, {APIID: "0xd2bc9897eed08f15", Version: 0x1}, {APIID: "0xf78b278be53f454c", Version: 0x1}, {APIID: "0xed99c5acb25eedf5", Version: 0x2}, {APIID: "0xdd718d5cc53262d4", Version: 0x1}, {APIID: "0x7801759919ee83e5", Version: 0x1}}, AuthoringVersion: 0xa, ImplName: "substrate-node", ImplVersion: 0x3e, SpecName: "node", SpecVersion: 0x3c}, //nolint:lll
 	storageKeyHex:            "0x0e4944cfd98d6f4cc374d16f5a4e3f9c",
 	storageKeyHexEmpty:       "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+	storageKeyHexError:       "0xdead",
 	storageChangeSets:        []types.StorageChangeSet{{Block: types.Hash{0xdd, 0x18, 0x16, 0xb6, 0xf6, 0x88, 0x9f, 0x46, 0xe2, 0x3b, 0xd, 0x67, 0x50, 0xbc, 0x44, 0x1a, 0xf9, 0xda, 0xd0, 0xfd, 0xa8, 0xba, 0xe9, 0x6, 0x77, 0xc1, 0x70, 0x8d, 0x1, 0x3, 0x5f, 0xbe}, Changes: []types.KeyValueOption{{StorageKey: types.StorageKey{0xe, 0x49, 0x44, 0xcf, 0xd9, 0x8d, 0x6f, 0x4c, 0xc3, 0x74, 0xd1, 0x6f, 0x5a, 0x4e, 0x3f, 0x9c}, HasStorageData: true, StorageData: types.StorageDataRaw{0x88, 0x2, 0x66, 0x9f, 0x6e, 0x1, 0x0, 0x0}}}}, {Block: types.Hash{0x82, 0x14, 0xa1, 0x80, 0x8b, 0xd6, 0xb0, 0x46, 0xc8, 0x77, 0xa6, 0x4f, 0xce, 0xad, 0xb4, 0xa2, 0xa7, 0x3a, 0x65, 0x76, 0x9f, 0x61, 0x4, 0xc0, 0x20, 0xd7, 0x59, 0xad, 0x8f, 0x61, 0xc0, 0xd8}, Changes: []types.KeyValueOption{{StorageKey: types.StorageKey{0xe, 0x49, 0x44, 0xcf, 0xd9, 0x8d, 0x6f, 0x4c, 0xc3, 0x74, 0xd1, 0x6f, 0x5a, 0x4e, 0x3f, 0x9c}, HasStorageData: true, StorageData: types.StorageDataRaw{0x40, 0xe, 0x66, 0x9f, 0x6e, 0x1, 0x0, 0x0}}}}}, //nolint:lll
 	storageDataHex:           "0xb82d895d00000000",
 	storageSize:              926778,
@@ -194,4 +251,43 @@ var mockSrv = MockSrv{
 	},
 	childStorageTrieSize:    68,
 	childStorageTrieHashHex: "0x20e3fc48a91087d091c17de08a5c470de53ccdaebd361025b0e5b7c65b9a0d30", //nolint:lll
+	callMethod:              "Core_version",
+	callArgsHex:             "0x",
+	callResultHex:           "0x106e6f6465",
+	callInfoArgsHex:         "0x01020a000000",
+	callInfoResultHex:       "0x2c30000d000000000000000000000000000000",
+	sessionKeysArgsHex:      "0x140102030405",
+	sessionKeysResultHex:    "0x01080c010203626162650804056772616e",
+}
+
+// setReadProofs sets the state root and the read proofs of the mock server, generated from a trie holding its
+// storage and child storage values.
+func setReadProofs(s *MockSrv) error {
+	db := trie.NewTrieDB(trie.StateVersionV1)
+	db.Insert(codec.MustHexDecodeString(s.storageKeyHex), codec.MustHexDecodeString(s.storageDataHex))
+
+	childKey := codec.MustHexDecodeString(s.childStorageKeyHex)[len(trie.ChildStorageKeyPrefix):]
+	childTrieKey := codec.MustHexDecodeString(s.childStorageTrieKeyHex)
+	db.InsertChild(childKey, childTrieKey, codec.MustHexDecodeString(s.childStorageTrieValueHex))
+
+	root, err := db.Root()
+	if err != nil {
+		return err
+	}
+
+	proof, err := db.Proof([]types.StorageKey{codec.MustHexDecodeString(s.storageKeyHex)})
+	if err != nil {
+		return err
+	}
+
+	childProof, err := db.ChildProof(childKey, []types.StorageKey{childTrieKey})
+	if err != nil {
+		return err
+	}
+
+	s.stateRoot = root
+	s.readProof = types.ReadProof{At: s.blockHashLatest, Proof: proof}
+	s.childReadProof = types.ReadProof{At: s.blockHashLatest, Proof: childProof}
+
+	return nil
 }
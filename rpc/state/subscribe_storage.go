@@ -18,47 +18,13 @@ package state
 
 import (
 	"context"
-	"sync"
 
-	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
-	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // StorageSubscription is a subscription established through one of the Client's subscribe methods.
-type StorageSubscription struct {
-	sub      *gethrpc.ClientSubscription
-	channel  chan types.StorageChangeSet
-	quitOnce sync.Once // ensures quit is closed once
-}
-
-// Chan returns the subscription channel.
-//
-// The channel is closed when Unsubscribe is called on the subscription.
-func (s *StorageSubscription) Chan() <-chan types.StorageChangeSet {
-	return s.channel
-}
-
-// Err returns the subscription error channel. The intended use of Err is to schedule
-// resubscription when the client connection is closed unexpectedly.
-//
-// The error channel receives a value when the subscription has ended due
-// to an error. The received error is nil if Close has been called
-// on the underlying client and no other error has occurred.
-//
-// The error channel is closed when Unsubscribe is called on the subscription.
-func (s *StorageSubscription) Err() <-chan error {
-	return s.sub.Err()
-}
-
-// Unsubscribe unsubscribes the notification and closes the error channel.
-// It can safely be called more than once.
-func (s *StorageSubscription) Unsubscribe() {
-	s.sub.Unsubscribe()
-	s.quitOnce.Do(func() {
-		close(s.channel)
-	})
-}
+type StorageSubscription = client.Subscription[types.StorageChangeSet]
 
 // SubscribeStorageRaw subscribes the storage for the given keys, returning a subscription that will
 // receive server notifications containing the storage change sets.
@@ -68,20 +34,12 @@ func (s *StorageSubscription) Unsubscribe() {
 // large buffer on the channel or ensure that the channel usually has at least one reader to prevent this issue.
 func (s *state) SubscribeStorageRaw(ctx context.Context, keys []types.StorageKey) (
 	*StorageSubscription, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
-	defer cancel()
-
-	c := make(chan types.StorageChangeSet)
-
 	keyss := make([]string, len(keys))
 	for i := range keys {
 		keyss[i] = keys[i].Hex()
 	}
 
-	sub, err := s.client.Subscribe(ctx, "state", "subscribeStorage", "unsubscribeStorage", "storage", c, keyss)
-	if err != nil {
-		return nil, err
-	}
-
-	return &StorageSubscription{sub: sub, channel: c}, nil
+	return client.Subscribe[types.StorageChangeSet](
+		ctx, s.client, "state", "subscribeStorage", "unsubscribeStorage", "storage", []interface{}{keyss},
+	)
 }
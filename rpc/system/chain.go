@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // Chain retrieves the chain
 func (c *system) Chain() (types.Text, error) {
+	return c.ChainContext(context.Background())
+}
+
+// ChainContext retrieves the chain, using the provided context
+func (c *system) ChainContext(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_chain")
+	err := c.client.CallContext(ctx, &t, "system_chain")
 	return t, err
 }
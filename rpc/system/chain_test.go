@@ -17,6 +17,7 @@
 package system
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,3 +28,15 @@ func TestSystem_Chain(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mockSrv.chain, c)
 }
+
+func TestSystem_ChainContext(t *testing.T) {
+	c, err := testSystem.ChainContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.chain, c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = testSystem.ChainContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // Health retrieves the health status of the connected node
 func (c *system) Health() (types.Health, error) {
+	return c.HealthContext(context.Background())
+}
+
+// HealthContext retrieves the health status of the connected node, using the provided context
+func (c *system) HealthContext(ctx context.Context) (types.Health, error) {
 	var h types.Health
-	err := c.client.Call(&h, "system_health")
+	err := c.client.CallContext(ctx, &h, "system_health")
 	return h, err
 }
@@ -17,6 +17,7 @@
 package system
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,3 +28,15 @@ func TestSystem_Health(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mockSrv.health, h)
 }
+
+func TestSystem_HealthContext(t *testing.T) {
+	h, err := testSystem.HealthContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.health, h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = testSystem.HealthContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
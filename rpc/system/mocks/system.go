@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -34,6 +36,27 @@ func (_m *System) Chain() (types.Text, error) {
 	return r0, r1
 }
 
+// ChainContext provides a mock function with given fields: ctx
+func (_m *System) ChainContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Health provides a mock function with given fields:
 func (_m *System) Health() (types.Health, error) {
 	ret := _m.Called()
@@ -55,6 +78,27 @@ func (_m *System) Health() (types.Health, error) {
 	return r0, r1
 }
 
+// HealthContext provides a mock function with given fields: ctx
+func (_m *System) HealthContext(ctx context.Context) (types.Health, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Health
+	if rf, ok := ret.Get(0).(func(context.Context) types.Health); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Health)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *System) Name() (types.Text, error) {
 	ret := _m.Called()
@@ -76,6 +120,27 @@ func (_m *System) Name() (types.Text, error) {
 	return r0, r1
 }
 
+// NameContext provides a mock function with given fields: ctx
+func (_m *System) NameContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NetworkState provides a mock function with given fields:
 func (_m *System) NetworkState() (types.NetworkState, error) {
 	ret := _m.Called()
@@ -97,6 +162,27 @@ func (_m *System) NetworkState() (types.NetworkState, error) {
 	return r0, r1
 }
 
+// NetworkStateContext provides a mock function with given fields: ctx
+func (_m *System) NetworkStateContext(ctx context.Context) (types.NetworkState, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.NetworkState
+	if rf, ok := ret.Get(0).(func(context.Context) types.NetworkState); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.NetworkState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Peers provides a mock function with given fields:
 func (_m *System) Peers() ([]types.PeerInfo, error) {
 	ret := _m.Called()
@@ -120,6 +206,29 @@ func (_m *System) Peers() ([]types.PeerInfo, error) {
 	return r0, r1
 }
 
+// PeersContext provides a mock function with given fields: ctx
+func (_m *System) PeersContext(ctx context.Context) ([]types.PeerInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []types.PeerInfo
+	if rf, ok := ret.Get(0).(func(context.Context) []types.PeerInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.PeerInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Properties provides a mock function with given fields:
 func (_m *System) Properties() (types.ChainProperties, error) {
 	ret := _m.Called()
@@ -141,6 +250,27 @@ func (_m *System) Properties() (types.ChainProperties, error) {
 	return r0, r1
 }
 
+// PropertiesContext provides a mock function with given fields: ctx
+func (_m *System) PropertiesContext(ctx context.Context) (types.ChainProperties, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.ChainProperties
+	if rf, ok := ret.Get(0).(func(context.Context) types.ChainProperties); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.ChainProperties)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Version provides a mock function with given fields:
 func (_m *System) Version() (types.Text, error) {
 	ret := _m.Called()
@@ -162,6 +292,27 @@ func (_m *System) Version() (types.Text, error) {
 	return r0, r1
 }
 
+// VersionContext provides a mock function with given fields: ctx
+func (_m *System) VersionContext(ctx context.Context) (types.Text, error) {
+	ret := _m.Called(ctx)
+
+	var r0 types.Text
+	if rf, ok := ret.Get(0).(func(context.Context) types.Text); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Text)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type NewSystemT interface {
 	mock.TestingT
 	Cleanup(func())
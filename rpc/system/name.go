@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
 
 // Name retrieves the node name
 func (c *system) Name() (types.Text, error) {
+	return c.NameContext(context.Background())
+}
+
+// NameContext retrieves the node name, using the provided context
+func (c *system) NameContext(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_name")
+	err := c.client.CallContext(ctx, &t, "system_name")
 	return t, err
 }
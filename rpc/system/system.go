@@ -19,6 +19,8 @@
 package system
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 )
@@ -31,6 +33,14 @@ type System interface {
 	Chain() (types.Text, error)
 	Version() (types.Text, error)
 	NetworkState() (types.NetworkState, error)
+
+	PropertiesContext(ctx context.Context) (types.ChainProperties, error)
+	HealthContext(ctx context.Context) (types.Health, error)
+	PeersContext(ctx context.Context) ([]types.PeerInfo, error)
+	NameContext(ctx context.Context) (types.Text, error)
+	ChainContext(ctx context.Context) (types.Text, error)
+	VersionContext(ctx context.Context) (types.Text, error)
+	NetworkStateContext(ctx context.Context) (types.NetworkState, error)
 }
 
 // system exposes methods for retrieval of system data
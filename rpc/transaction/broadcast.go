@@ -0,0 +1,39 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Broadcast starts broadcasting the SCALE encoded transaction, until it is stopped or the subscription is dropped,
+// and returns the ID of the operation.
+func (t *transaction) Broadcast(ctx context.Context, tx []byte) (string, error) {
+	var res *string
+
+	if err := t.client.CallContext(ctx, &res, "transaction_v1_broadcast", codec.HexEncodeToString(tx)); err != nil {
+		return "", err
+	}
+
+	if res == nil {
+		return "", ErrLimitReached
+	}
+
+	return *res, nil
+}
@@ -0,0 +1,49 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate mockery --name Transaction --filename transaction.go
+
+package transaction
+
+import (
+	"context"
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+)
+
+// ErrLimitReached is returned when the node refuses to broadcast a transaction because of its resource limits.
+var ErrLimitReached = errors.New("broadcast limit reached")
+
+// Transaction exposes the transaction_v1 methods of the Substrate JSON-RPC spec, which broadcast transactions to
+// the peers of the node without checking their inclusion.
+type Transaction interface {
+	// Broadcast starts broadcasting the SCALE encoded transaction, until it is stopped or the subscription is
+	// dropped, and returns the ID of the operation.
+	Broadcast(ctx context.Context, tx []byte) (string, error)
+	// Stop stops broadcasting the transaction of the operation.
+	Stop(ctx context.Context, operationID string) error
+}
+
+// transaction exposes methods for broadcasting transactions
+type transaction struct {
+	client client.Client
+}
+
+// NewTransaction creates a new transaction struct
+func NewTransaction(cl client.Client) Transaction {
+	return &transaction{cl}
+}
@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transaction
+
+import (
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpcmocksrv"
+)
+
+var testTransaction Transaction
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("transaction", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testTransaction = NewTransaction(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	broadcasted []string
+	stopped     []string
+}
+
+func (s *MockSrv) V1_broadcast(tx string) *string {
+	// An empty transaction simulates a node that reached its broadcast limit.
+	if tx == "0x" {
+		return nil
+	}
+
+	s.broadcasted = append(s.broadcasted, tx)
+	id := "broadcast-1"
+	return &id
+}
+
+func (s *MockSrv) V1_stop(operationID string) {
+	s.stopped = append(s.stopped, operationID)
+}
+
+var mockSrv = MockSrv{}
@@ -18,6 +18,7 @@ package rpcmocksrv
 
 import (
 	"math/rand"
+	"net"
 	"strconv"
 	"time"
 
@@ -30,6 +31,20 @@ type Server struct {
 	Host string
 	// URL consists of protocol, hostname and port
 	URL string
+
+	listener      net.Listener
+	services      []service
+	subscriptions []subscription
+}
+
+type service struct {
+	name     string
+	receiver interface{}
+}
+
+type subscription struct {
+	method            string
+	unsubscribeMethod string
 }
 
 // New creates a new RPC mock server with a random port that allows registration of services
@@ -37,18 +52,75 @@ func New() *Server {
 	port := randomPort()
 	host := "localhost:" + strconv.Itoa(port)
 
-	_, rpcServ, err := gethrpc.StartWSEndpoint(host, []gethrpc.API{}, []string{}, []string{"*"}, true)
+	listener, rpcServ, err := gethrpc.StartWSEndpoint(host, []gethrpc.API{}, []string{}, []string{"*"}, true)
 	if err != nil {
 		panic(err)
 	}
 	s := Server{
-		Server: rpcServ,
-		Host:   host,
-		URL:    "ws://" + host,
+		Server:   rpcServ,
+		Host:     host,
+		URL:      "ws://" + host,
+		listener: listener,
 	}
 	return &s
 }
 
+// RegisterName registers the service under the given name. The service is registered again when the server
+// is restarted.
+func (s *Server) RegisterName(name string, receiver interface{}) error {
+	if err := s.Server.RegisterName(name, receiver); err != nil {
+		return err
+	}
+
+	s.services = append(s.services, service{name, receiver})
+
+	return nil
+}
+
+// RegisterSubscription registers the subscribe and unsubscribe methods of a subscription of a registered service,
+// see gethrpc.Server.RegisterSubscription. The subscription is registered again when the server is restarted.
+func (s *Server) RegisterSubscription(method, unsubscribeMethod string) error {
+	if err := s.Server.RegisterSubscription(method, unsubscribeMethod); err != nil {
+		return err
+	}
+
+	s.subscriptions = append(s.subscriptions, subscription{method, unsubscribeMethod})
+
+	return nil
+}
+
+// Close stops the server and closes all of its connections, which allows simulating a node going down.
+func (s *Server) Close() error {
+	s.Server.Stop()
+
+	return s.listener.Close()
+}
+
+// Restart starts a closed server again, on the same host and with the same services.
+func (s *Server) Restart() error {
+	listener, rpcServ, err := gethrpc.StartWSEndpoint(s.Host, []gethrpc.API{}, []string{}, []string{"*"}, true)
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range s.services {
+		if err := rpcServ.RegisterName(svc.name, svc.receiver); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range s.subscriptions {
+		if err := rpcServ.RegisterSubscription(sub.method, sub.unsubscribeMethod); err != nil {
+			return err
+		}
+	}
+
+	s.Server = rpcServ
+	s.listener = listener
+
+	return nil
+}
+
 //nolint:gosec
 func randomPort() int {
 	rand.Seed(time.Now().UnixNano())
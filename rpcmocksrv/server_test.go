@@ -17,7 +17,9 @@
 package rpcmocksrv
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +32,18 @@ func (ts *TestService) Ping(s string) string {
 	return s
 }
 
+func (ts *TestService) SubscribePings(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, _ := gethrpc.NotifierFromContext(ctx)
+
+	sub := notifier.CreateSubscription()
+
+	go func() {
+		_ = notifier.Notify(sub.ID, "ping")
+	}()
+
+	return sub, nil
+}
+
 func TestServer(t *testing.T) {
 	s := New()
 
@@ -46,3 +60,70 @@ func TestServer(t *testing.T) {
 
 	assert.Equal(t, "hello", res)
 }
+
+func TestServer_Restart(t *testing.T) {
+	s := New()
+
+	ts := new(TestService)
+	err := s.RegisterName("testserv3", ts)
+	assert.NoError(t, err)
+
+	err = s.Close()
+	assert.NoError(t, err)
+
+	_, err = gethrpc.Dial(s.URL)
+	assert.Error(t, err)
+
+	err = s.Restart()
+	assert.NoError(t, err)
+
+	defer s.Close()
+
+	c, err := gethrpc.Dial(s.URL)
+	assert.NoError(t, err)
+
+	var res string
+	err = c.Call(&res, "testserv3_ping", "hello")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "hello", res)
+}
+
+func TestServer_RegisterSubscription(t *testing.T) {
+	s := New()
+
+	ts := new(TestService)
+	err := s.RegisterName("testserv3", ts)
+	assert.NoError(t, err)
+
+	err = s.RegisterSubscription("testserv3_subscribeMissing", "")
+	assert.Error(t, err)
+
+	c, err := gethrpc.Dial(s.URL)
+	assert.NoError(t, err)
+
+	// The subscriptions are not resolved until they are registered.
+	var res interface{}
+	err = c.Call(&res, "testserv3_subscribePings")
+	assert.ErrorContains(t, err, "does not exist")
+
+	err = s.RegisterSubscription("testserv3_subscribePings", "testserv3_unsubscribePings")
+	assert.NoError(t, err)
+
+	pings := make(chan string, 1)
+
+	sub, err := c.Subscribe(context.Background(), "testserv3", "subscribePings", "unsubscribePings", "subscribePings", pings)
+	assert.NoError(t, err)
+
+	select {
+	case ping := <-pings:
+		assert.Equal(t, "ping", ping)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "no notification")
+	}
+
+	sub.Unsubscribe()
+
+	err = c.Call(&res, "testserv3_unsubscribeOther", "1")
+	assert.ErrorContains(t, err, "does not exist")
+}
@@ -0,0 +1,106 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teste2e
+
+import (
+	"context"
+	"testing"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestState_GetReadProof verifies the read proof returned by the node against the state root of the header, for
+// a small value stored in its node and a value stored as a separate node with state version 1.
+func TestState_GetReadProof(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode.")
+	}
+
+	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	hash, err := api.RPC.Chain.GetFinalizedHead(ctx)
+	require.NoError(t, err)
+
+	header, err := api.RPC.Chain.GetHeader(ctx, hash)
+	require.NoError(t, err)
+
+	meta, err := api.RPC.State.GetMetadata(ctx, hash)
+	require.NoError(t, err)
+
+	numberKey, err := types.CreateStorageKey(meta, "System", "Number")
+	require.NoError(t, err)
+
+	accountKey, err := types.CreateStorageKey(meta, "System", "Account", signature.TestKeyringPairAlice.PublicKey)
+	require.NoError(t, err)
+
+	// The key is not part of the state.
+	absentKey := append(append(types.StorageKey{}, numberKey...), 0xff)
+
+	keys := []types.StorageKey{numberKey, accountKey, absentKey}
+
+	proof, err := api.RPC.State.GetReadProof(ctx, keys, hash)
+	require.NoError(t, err)
+	assert.Equal(t, hash, proof.At)
+
+	values, err := trie.VerifyProof(header.StateRoot, proof.Proof, keys)
+	require.NoError(t, err)
+
+	for i, key := range keys {
+		raw, err := api.RPC.State.GetStorageRaw(ctx, key, hash)
+		require.NoError(t, err)
+
+		assert.Equal(t, len(*raw) > 0, values[i].HasStorageData)
+		assert.Equal(t, []byte(*raw), []byte(values[i].StorageData))
+	}
+
+	assert.False(t, values[2].HasStorageData)
+}
+
+// TestChain_VerifyExtrinsicsRoot checks the extrinsics root of a block returned by the node, computed with the
+// state version of its runtime.
+func TestChain_VerifyExtrinsicsRoot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode.")
+	}
+
+	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	hash, err := api.RPC.Chain.GetFinalizedHead(ctx)
+	require.NoError(t, err)
+
+	block, err := api.RPC.Chain.GetBlock(ctx, hash)
+	require.NoError(t, err)
+	require.NotEmpty(t, block.Block.Extrinsics)
+
+	runtimeVersion, err := api.RPC.State.GetRuntimeVersion(ctx, hash)
+	require.NoError(t, err)
+
+	err = block.Block.VerifyExtrinsicsRoot(trie.StateVersion(runtimeVersion.StateVersion))
+	assert.NoError(t, err)
+}
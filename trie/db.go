@@ -0,0 +1,230 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// TrieDB is an in-memory trie with its default child tries, whose roots are stored in the top trie under their child
+// storage key. It computes the roots and generates the read proofs of the tries, as served by the state RPC methods.
+//
+// A TrieDB is not safe for concurrent use.
+type TrieDB struct {
+	version StateVersion
+
+	entries  map[string][]byte
+	children map[string]map[string][]byte
+}
+
+// NewTrieDB creates a new empty TrieDB, whose nodes are built with the provided state version.
+func NewTrieDB(version StateVersion) *TrieDB {
+	return &TrieDB{
+		version:  version,
+		entries:  make(map[string][]byte),
+		children: make(map[string]map[string][]byte),
+	}
+}
+
+// Version returns the state version of the trie.
+func (db *TrieDB) Version() StateVersion {
+	return db.version
+}
+
+// Insert sets the value of the key in the top trie.
+func (db *TrieDB) Insert(key, value []byte) {
+	db.entries[string(key)] = append([]byte{}, value...)
+}
+
+// Remove removes the key from the top trie.
+func (db *TrieDB) Remove(key []byte) {
+	delete(db.entries, string(key))
+}
+
+// Get returns the value of the key in the top trie, and false if the key has no value.
+func (db *TrieDB) Get(key []byte) ([]byte, bool) {
+	value, ok := db.entries[string(key)]
+
+	return value, ok
+}
+
+// InsertChild sets the value of the key in the child trie with the provided unprefixed child key.
+func (db *TrieDB) InsertChild(childKey, key, value []byte) {
+	child, ok := db.children[string(childKey)]
+	if !ok {
+		child = make(map[string][]byte)
+		db.children[string(childKey)] = child
+	}
+
+	child[string(key)] = append([]byte{}, value...)
+}
+
+// RemoveChild removes the key from the child trie. The child trie is removed with its last key.
+func (db *TrieDB) RemoveChild(childKey, key []byte) {
+	child := db.children[string(childKey)]
+
+	delete(child, string(key))
+
+	if len(child) == 0 {
+		delete(db.children, string(childKey))
+	}
+}
+
+// GetChild returns the value of the key in the child trie, and false if the key has no value.
+func (db *TrieDB) GetChild(childKey, key []byte) ([]byte, bool) {
+	value, ok := db.children[string(childKey)][string(key)]
+
+	return value, ok
+}
+
+// Root returns the root of the top trie, which holds the roots of the child tries.
+func (db *TrieDB) Root() (types.Hash, error) {
+	entries, err := db.topEntries()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return buildRoot(entries, db.version, nil)
+}
+
+// ChildRoot returns the root of the child trie, which is EmptyRoot if the child trie does not exist.
+func (db *TrieDB) ChildRoot(childKey []byte) (types.Hash, error) {
+	return buildRoot(sortedEntries(db.children[string(childKey)]), db.version, nil)
+}
+
+// Proof returns the read proof of the keys in the top trie, to be checked with VerifyProof against the root of the
+// trie. The keys without value are proven to have no value.
+func (db *TrieDB) Proof(keys []types.StorageKey) ([]types.Bytes, error) {
+	entries, err := db.topEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[types.Hash]struct{})
+
+	proof, err := recordProof(entries, db.version, keys, recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	return proofNodes(proof, recorded), nil
+}
+
+// ChildProof returns the read proof of the keys in the child trie, to be checked with VerifyChildProof against the
+// root of the trie. The proof holds the nodes of the top trie proving the root of the child trie.
+func (db *TrieDB) ChildProof(childKey []byte, keys []types.StorageKey) ([]types.Bytes, error) {
+	entries, err := db.topEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[types.Hash]struct{})
+
+	top, err := recordProof(entries, db.version, []types.StorageKey{NewChildStorageKey(childKey)}, recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := recordProof(sortedEntries(db.children[string(childKey)]), db.version, keys, recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	for h, data := range child {
+		top[h] = data
+	}
+
+	return proofNodes(top, recorded), nil
+}
+
+// topEntries returns the entries of the top trie, with the roots of the non-empty child tries.
+func (db *TrieDB) topEntries() ([]nibbleEntry, error) {
+	entries := make(map[string][]byte, len(db.entries)+len(db.children))
+
+	for key, value := range db.entries {
+		entries[key] = value
+	}
+
+	for childKey := range db.children {
+		root, err := db.ChildRoot([]byte(childKey))
+		if err != nil {
+			return nil, err
+		}
+
+		entries[string(NewChildStorageKey([]byte(childKey)))] = root[:]
+	}
+
+	return sortedEntries(entries), nil
+}
+
+// recordProof builds the trie holding the entries, and records the nodes accessed by the lookups of the keys.
+func recordProof(
+	entries []nibbleEntry,
+	version StateVersion,
+	keys []types.StorageKey,
+	recorded map[types.Hash]struct{},
+) (nodeStore, error) {
+	store := make(nodeStore)
+
+	root, err := buildRoot(entries, version, store)
+	if err != nil {
+		return nil, err
+	}
+
+	db := proofDB{nodes: store, recorded: recorded}
+
+	for _, key := range keys {
+		if _, _, err := db.lookup(root, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// proofNodes returns the recorded nodes of the store, sorted for the proofs to be deterministic.
+func proofNodes(store nodeStore, recorded map[types.Hash]struct{}) []types.Bytes {
+	proof := make([]types.Bytes, 0, len(recorded))
+
+	for h := range recorded {
+		proof = append(proof, store[h])
+	}
+
+	sort.Slice(proof, func(i, j int) bool {
+		return bytes.Compare(proof[i], proof[j]) < 0
+	})
+
+	return proof
+}
+
+// sortedEntries returns the entries sorted by key, with the nibbles of their keys.
+func sortedEntries(entries map[string][]byte) []nibbleEntry {
+	res := make([]nibbleEntry, 0, len(entries))
+
+	for key, value := range entries {
+		res = append(res, nibbleEntry{key: keyNibbles([]byte(key)), value: value})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].key, res[j].key) < 0
+	})
+
+	return res
+}
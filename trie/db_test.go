@@ -0,0 +1,204 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrieDB_InsertRemoveGet(t *testing.T) {
+	db := NewTrieDB(StateVersionV1)
+
+	db.Insert([]byte{0x01}, []byte("a"))
+	db.Insert([]byte{0x01}, []byte("b"))
+
+	value, ok := db.Get([]byte{0x01})
+	assert.True(t, ok)
+	assert.Equal(t, []byte("b"), value)
+
+	db.Remove([]byte{0x01})
+
+	_, ok = db.Get([]byte{0x01})
+	assert.False(t, ok)
+
+	root, err := db.Root()
+	require.NoError(t, err)
+	assert.Equal(t, EmptyRoot, root)
+}
+
+func TestTrieDB_Root(t *testing.T) {
+	entries := newTestEntries()
+
+	for _, version := range []StateVersion{StateVersionV0, StateVersionV1} {
+		db := NewTrieDB(version)
+
+		for _, entry := range entries {
+			db.Insert(entry.Key, entry.Value)
+		}
+
+		root, err := db.Root()
+		require.NoError(t, err)
+
+		expected, err := Root(entries, version)
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+
+		db.Remove(entries[0].Key)
+
+		root, err = db.Root()
+		require.NoError(t, err)
+
+		expected, err = Root(entries[1:], version)
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	}
+}
+
+func TestTrieDB_ChildRoot(t *testing.T) {
+	entries := newTestEntries()
+
+	db := NewTrieDB(StateVersionV1)
+	db.Insert([]byte{0x01}, []byte("a"))
+
+	for _, entry := range entries {
+		db.InsertChild([]byte("child"), entry.Key, entry.Value)
+	}
+
+	value, ok := db.GetChild([]byte("child"), entries[0].Key)
+	assert.True(t, ok)
+	assert.Equal(t, entries[0].Value, value)
+
+	childRoot, err := db.ChildRoot([]byte("child"))
+	require.NoError(t, err)
+
+	expected, err := Root(entries, StateVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, childRoot)
+
+	root, err := db.Root()
+	require.NoError(t, err)
+
+	expected, err = Root([]KeyValue{
+		{Key: []byte{0x01}, Value: []byte("a")},
+		{Key: NewChildStorageKey([]byte("child")), Value: childRoot[:]},
+	}, StateVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+
+	// The child trie is removed with its last key.
+	for _, entry := range entries {
+		db.RemoveChild([]byte("child"), entry.Key)
+	}
+
+	childRoot, err = db.ChildRoot([]byte("child"))
+	require.NoError(t, err)
+	assert.Equal(t, EmptyRoot, childRoot)
+
+	root, err = db.Root()
+	require.NoError(t, err)
+
+	expected, err = Root([]KeyValue{{Key: []byte{0x01}, Value: []byte("a")}}, StateVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+}
+
+func TestTrieDB_Proof(t *testing.T) {
+	entries := newTestEntries()
+
+	keys := []types.StorageKey{
+		codec.MustHexDecodeString("0x0103"),
+		codec.MustHexDecodeString("0x1f"),
+		codec.MustHexDecodeString("0x0104"),
+		codec.MustHexDecodeString("0x02"),
+	}
+
+	for _, version := range []StateVersion{StateVersionV0, StateVersionV1} {
+		db := NewTrieDB(version)
+
+		for _, entry := range entries {
+			db.Insert(entry.Key, entry.Value)
+		}
+
+		root, err := db.Root()
+		require.NoError(t, err)
+
+		proof, err := db.Proof(keys)
+		require.NoError(t, err)
+
+		values, err := VerifyProof(root, proof, keys)
+		require.NoError(t, err)
+		assert.Equal(t, testHashedValue, []byte(values[0].StorageData))
+		assert.Equal(t, testLongValue, []byte(values[1].StorageData))
+		assert.False(t, values[2].HasStorageData)
+		assert.False(t, values[3].HasStorageData)
+
+		// The proof only holds the nodes accessed by the lookups.
+		proof, err = db.Proof(keys[1:2])
+		require.NoError(t, err)
+
+		_, err = VerifyProof(root, proof, keys[:1])
+		assert.ErrorIs(t, err, ErrIncompleteProof)
+	}
+}
+
+func TestTrieDB_ChildProof(t *testing.T) {
+	db := NewTrieDB(StateVersionV1)
+	db.Insert([]byte{0x01}, []byte("a"))
+
+	for _, entry := range newTestEntries() {
+		db.InsertChild([]byte("child"), entry.Key, entry.Value)
+	}
+
+	keys := []types.StorageKey{
+		codec.MustHexDecodeString("0x0103"),
+		codec.MustHexDecodeString("0x0104"),
+	}
+
+	root, err := db.Root()
+	require.NoError(t, err)
+
+	proof, err := db.ChildProof([]byte("child"), keys)
+	require.NoError(t, err)
+
+	values, err := VerifyChildProof(root, proof, NewChildStorageKey([]byte("child")), keys)
+	require.NoError(t, err)
+	assert.Equal(t, testHashedValue, []byte(values[0].StorageData))
+	assert.False(t, values[1].HasStorageData)
+
+	// The child trie does not exist.
+	proof, err = db.ChildProof([]byte("other"), keys)
+	require.NoError(t, err)
+
+	values, err = VerifyChildProof(root, proof, NewChildStorageKey([]byte("other")), keys)
+	require.NoError(t, err)
+	assert.False(t, values[0].HasStorageData)
+}
+
+// newTestEntries returns the entries of the trie of newTestProof.
+func newTestEntries() []KeyValue {
+	return []KeyValue{
+		{Key: codec.MustHexDecodeString("0x01"), Value: []byte("c")},
+		{Key: codec.MustHexDecodeString("0x0102"), Value: []byte("a")},
+		{Key: codec.MustHexDecodeString("0x0103"), Value: testHashedValue},
+		{Key: codec.MustHexDecodeString("0x1f"), Value: testLongValue},
+	}
+}
@@ -0,0 +1,327 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	// emptyNode is the encoding of the empty node, which is only found at the root of an empty trie.
+	emptyNode = 0x00
+
+	leafPrefix               = 0b01 << 6
+	branchWithoutValuePrefix = 0b10 << 6
+	branchWithValuePrefix    = 0b11 << 6
+	hashedValueLeafPrefix    = 0b001 << 5
+	hashedValueBranchPrefix  = 0b0001 << 4
+
+	// maxNibbles is the maximum number of nibbles of the partial key of a node.
+	maxNibbles = 65535
+
+	// hashLength is the length of the hashes of the nodes and values.
+	hashLength = 32
+)
+
+type nodeKind uint8
+
+const (
+	nodeKindEmpty nodeKind = iota
+	nodeKindLeaf
+	nodeKindBranch
+)
+
+// node is a decoded trie node.
+type node struct {
+	kind nodeKind
+	// partialKey holds the nibbles of the key of the node, relative to its parent.
+	partialKey []byte
+	// value is nil if the node has no value. It holds the hash of the value if hashedValue is set.
+	value       []byte
+	hashedValue bool
+	// children holds the references of the children of a branch, which are either the hash of the child or,
+	// for children encoded in less bytes than a hash, the encoded child itself.
+	children [16][]byte
+}
+
+// decodeNode decodes a node encoded with the Substrate node codec, for both state versions.
+func decodeNode(data []byte) (*node, error) {
+	r := bytes.NewReader(data)
+
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, ErrInvalidNode.WithMsg("empty node")
+	}
+
+	if header == emptyNode {
+		if r.Len() != 0 {
+			return nil, ErrInvalidNode.WithMsg("trailing bytes after the empty node")
+		}
+
+		return &node{kind: nodeKindEmpty}, nil
+	}
+
+	var (
+		n          node
+		hasValue   bool
+		prefixBits uint
+	)
+
+	switch {
+	case header&(0b11<<6) == leafPrefix:
+		n.kind, hasValue, prefixBits = nodeKindLeaf, true, 2
+	case header&(0b11<<6) == branchWithoutValuePrefix:
+		n.kind, hasValue, prefixBits = nodeKindBranch, false, 2
+	case header&(0b11<<6) == branchWithValuePrefix:
+		n.kind, hasValue, prefixBits = nodeKindBranch, true, 2
+	case header&(0b111<<5) == hashedValueLeafPrefix:
+		n.kind, hasValue, n.hashedValue, prefixBits = nodeKindLeaf, true, true, 3
+	case header&(0b1111<<4) == hashedValueBranchPrefix:
+		n.kind, hasValue, n.hashedValue, prefixBits = nodeKindBranch, true, true, 4
+	default:
+		return nil, ErrInvalidNode.WithMsg("unknown header %#x", header)
+	}
+
+	n.partialKey, err = decodePartialKey(header, r, prefixBits)
+	if err != nil {
+		return nil, err
+	}
+
+	var bitmap uint16
+
+	if n.kind == nodeKindBranch {
+		var b [2]byte
+
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, ErrInvalidNode.WithMsg("missing children bitmap")
+		}
+
+		bitmap = binary.LittleEndian.Uint16(b[:])
+
+		if bitmap == 0 {
+			return nil, ErrInvalidNode.WithMsg("branch without children")
+		}
+	}
+
+	if hasValue {
+		if n.hashedValue {
+			n.value, err = readBytes(r, hashLength)
+		} else {
+			n.value, err = readCompactBytes(r)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range n.children {
+		if bitmap&(1<<i) == 0 {
+			continue
+		}
+
+		n.children[i], err = readCompactBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(n.children[i]) > hashLength {
+			return nil, ErrInvalidNode.WithMsg("child reference of %d bytes", len(n.children[i]))
+		}
+	}
+
+	if r.Len() != 0 {
+		return nil, ErrInvalidNode.WithMsg("%d trailing bytes", r.Len())
+	}
+
+	return &n, nil
+}
+
+// encode encodes the node with the Substrate node codec.
+func (n *node) encode() []byte {
+	var data []byte
+
+	switch {
+	case n.kind == nodeKindEmpty:
+		return []byte{emptyNode}
+	case n.kind == nodeKindLeaf && n.hashedValue:
+		data = encodePartialKey(hashedValueLeafPrefix, 3, n.partialKey)
+	case n.kind == nodeKindLeaf:
+		data = encodePartialKey(leafPrefix, 2, n.partialKey)
+	case n.value == nil:
+		data = encodePartialKey(branchWithoutValuePrefix, 2, n.partialKey)
+	case n.hashedValue:
+		data = encodePartialKey(hashedValueBranchPrefix, 4, n.partialKey)
+	default:
+		data = encodePartialKey(branchWithValuePrefix, 2, n.partialKey)
+	}
+
+	if n.kind == nodeKindBranch {
+		var bitmap uint16
+
+		for i, child := range n.children {
+			if child != nil {
+				bitmap |= 1 << i
+			}
+		}
+
+		data = binary.LittleEndian.AppendUint16(data, bitmap)
+	}
+
+	if n.hashedValue {
+		data = append(data, n.value...)
+	} else if n.value != nil {
+		data = appendCompactBytes(data, n.value)
+	}
+
+	for _, child := range n.children {
+		if child != nil {
+			data = appendCompactBytes(data, child)
+		}
+	}
+
+	return data
+}
+
+// encodePartialKey encodes the header with the provided prefix followed by the partial key. The nibble count
+// that does not fit in the header is continued in the following bytes.
+func encodePartialKey(prefix byte, prefixBits uint, partialKey []byte) []byte {
+	maxValue := 0xff >> prefixBits
+	count := len(partialKey)
+
+	var data []byte
+
+	if count < maxValue {
+		data = []byte{prefix | byte(count)}
+	} else {
+		data = []byte{prefix | byte(maxValue)}
+
+		for rem := count - maxValue; ; rem -= 0xff {
+			if rem < 0xff {
+				data = append(data, byte(rem))
+				break
+			}
+
+			data = append(data, 0xff)
+		}
+	}
+
+	if count%2 == 1 {
+		data = append(data, partialKey[0])
+		partialKey = partialKey[1:]
+	}
+
+	for i := 0; i < len(partialKey); i += 2 {
+		data = append(data, partialKey[i]<<4|partialKey[i+1])
+	}
+
+	return data
+}
+
+// appendCompactBytes appends the provided bytes prefixed with their compact encoded length.
+func appendCompactBytes(data []byte, b []byte) []byte {
+	buf := bytes.NewBuffer(data)
+
+	// Writing to a buffer never returns an error.
+	_ = scale.NewEncoder(buf).EncodeUintCompact(*big.NewInt(int64(len(b))))
+
+	return append(buf.Bytes(), b...)
+}
+
+// decodePartialKey decodes the nibble count found in the header and the following bytes, and the nibbles of the
+// partial key. An odd number of nibbles is padded with a zero nibble in front of the first one.
+func decodePartialKey(header byte, r *bytes.Reader, prefixBits uint) ([]byte, error) {
+	maxValue := byte(0xff >> prefixBits)
+	count := int(header & maxValue)
+
+	// A nibble count that does not fit in the header is continued in the following bytes, until a byte
+	// lower than 255.
+	if count == int(maxValue) {
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				return nil, ErrInvalidNode.WithMsg("missing nibble count")
+			}
+
+			count += int(b)
+
+			if count > maxNibbles {
+				return nil, ErrInvalidNode.WithMsg("nibble count over %d", maxNibbles)
+			}
+
+			if b < 0xff {
+				break
+			}
+		}
+	}
+
+	data, err := readBytes(r, (count+1)/2)
+	if err != nil {
+		return nil, err
+	}
+
+	nibbles := keyNibbles(data)
+
+	if count%2 == 1 {
+		if nibbles[0] != 0 {
+			return nil, ErrInvalidNode.WithMsg("invalid partial key padding")
+		}
+
+		nibbles = nibbles[1:]
+	}
+
+	return nibbles, nil
+}
+
+// readCompactBytes reads a byte slice prefixed with its compact encoded length.
+func readCompactBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := scale.NewDecoder(r).DecodeUintCompact()
+	if err != nil {
+		return nil, ErrInvalidNode.Wrap(err)
+	}
+
+	if !length.IsUint64() || length.Uint64() > uint64(r.Len()) {
+		return nil, ErrInvalidNode.WithMsg("length %s over the remaining %d bytes", length, r.Len())
+	}
+
+	return readBytes(r, int(length.Uint64()))
+}
+
+func readBytes(r *bytes.Reader, length int) ([]byte, error) {
+	data := make([]byte, length)
+
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidNode.WithMsg("missing %d bytes", length)
+	}
+
+	return data, nil
+}
+
+// childHash returns the hash of a child reference, and false if the child is inlined.
+func childHash(ref []byte) (types.Hash, bool) {
+	if len(ref) != hashLength {
+		return types.Hash{}, false
+	}
+
+	return types.NewHash(ref), true
+}
@@ -0,0 +1,184 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeNode_Leaf(t *testing.T) {
+	n, err := decodeNode([]byte{0x43, 0x01, 0x23, 0x04, 0xab})
+	require.NoError(t, err)
+	assert.Equal(t, nodeKindLeaf, n.kind)
+	assert.Equal(t, []byte{1, 2, 3}, n.partialKey)
+	assert.Equal(t, []byte{0xab}, n.value)
+	assert.False(t, n.hashedValue)
+}
+
+func TestDecodeNode_HashedValueLeaf(t *testing.T) {
+	valueHash := bytes.Repeat([]byte{0xcc}, hashLength)
+
+	n, err := decodeNode(append([]byte{0x22, 0x12}, valueHash...))
+	require.NoError(t, err)
+	assert.Equal(t, nodeKindLeaf, n.kind)
+	assert.Equal(t, []byte{1, 2}, n.partialKey)
+	assert.Equal(t, valueHash, n.value)
+	assert.True(t, n.hashedValue)
+}
+
+func TestDecodeNode_Branch(t *testing.T) {
+	leaf := []byte{0x40, 0x04, 0xab}
+	childHash := bytes.Repeat([]byte{0xee}, hashLength)
+
+	data := []byte{0xc1, 0x05, 0x02, 0x80, 0x04, 0x01}
+	data = append(data, appendCompactBytes(nil, leaf)...)
+	data = append(data, appendCompactBytes(nil, childHash)...)
+
+	n, err := decodeNode(data)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKindBranch, n.kind)
+	assert.Equal(t, []byte{5}, n.partialKey)
+	assert.Equal(t, []byte{0x01}, n.value)
+	assert.Equal(t, leaf, n.children[1])
+	assert.Equal(t, childHash, n.children[15])
+
+	for i, child := range n.children {
+		if i != 1 && i != 15 {
+			assert.Nil(t, child)
+		}
+	}
+}
+
+func TestDecodeNode_LongPartialKey(t *testing.T) {
+	for _, count := range []int{62, 63, 64, 70, 63 + 255, 63 + 300} {
+		nibbles := make([]byte, count)
+		for i := range nibbles {
+			nibbles[i] = byte(i % 16)
+		}
+
+		n, err := decodeNode(encodeLeaf(nibbles, []byte{0x01}))
+		require.NoError(t, err, count)
+		assert.Equal(t, nibbles, n.partialKey, count)
+		assert.Equal(t, []byte{0x01}, n.value, count)
+	}
+}
+
+func TestNode_EncodeDecode(t *testing.T) {
+	valueHash := bytes.Repeat([]byte{0xcc}, hashLength)
+
+	nodes := []*node{
+		{kind: nodeKindEmpty},
+		{kind: nodeKindLeaf, partialKey: []byte{1, 2, 3}, value: []byte{}},
+		{kind: nodeKindLeaf, partialKey: bytes.Repeat([]byte{7}, 300), value: []byte{0xab}},
+		{kind: nodeKindLeaf, partialKey: []byte{}, value: valueHash, hashedValue: true},
+		{kind: nodeKindBranch, partialKey: []byte{4}, children: [16][]byte{3: {0x40, 0x00}}},
+		{kind: nodeKindBranch, partialKey: []byte{}, value: []byte{0x01}, children: [16][]byte{0: valueHash}},
+		{kind: nodeKindBranch, partialKey: []byte{4, 5}, value: valueHash, hashedValue: true, children: [16][]byte{
+			0: valueHash, 15: {0x40, 0x00},
+		}},
+	}
+
+	for _, n := range nodes {
+		decoded, err := decodeNode(n.encode())
+		require.NoError(t, err)
+		assert.Equal(t, n, decoded)
+	}
+}
+
+func TestDecodeNode_Invalid(t *testing.T) {
+	tests := map[string][]byte{
+		"no header":            {},
+		"unknown header":       {0x01},
+		"trailing bytes":       {0x40, 0x04, 0xab, 0x00},
+		"missing value":        {0x40, 0x08, 0xab},
+		"missing partial key":  {0x44, 0x12},
+		"invalid padding":      {0x41, 0x10, 0x00},
+		"no children":          {0x80, 0x00, 0x00},
+		"missing bitmap":       {0x80, 0x00},
+		"child too long":       append([]byte{0x80, 0x01, 0x00, 0x84}, bytes.Repeat([]byte{0x00}, 33)...),
+		"missing nibble count": {0x7f, 0xff},
+		"empty with trailing":  {0x00, 0x00},
+	}
+
+	for name, data := range tests {
+		_, err := decodeNode(data)
+		assert.ErrorIs(t, err, ErrInvalidNode, name)
+	}
+}
+
+// encodeLeaf encodes a leaf with an inline value.
+func encodeLeaf(partialKey []byte, value []byte) []byte {
+	data := encodePartialKey(leafPrefix, 2, partialKey)
+
+	return append(data, appendCompactBytes(nil, value)...)
+}
+
+// encodeHashedValueLeaf encodes a leaf with a value stored as a separate node.
+func encodeHashedValueLeaf(t *testing.T, partialKey []byte, value []byte) []byte {
+	valueHash, err := hashNode(value)
+	require.NoError(t, err)
+
+	data := encodePartialKey(hashedValueLeafPrefix, 3, partialKey)
+
+	return append(data, valueHash[:]...)
+}
+
+// encodeBranch encodes a branch with an optional inline value and the provided encoded children, which are
+// inlined when shorter than a hash.
+func encodeBranch(t *testing.T, partialKey []byte, value []byte, children map[int][]byte) []byte {
+	var data []byte
+
+	if value == nil {
+		data = encodePartialKey(branchWithoutValuePrefix, 2, partialKey)
+	} else {
+		data = encodePartialKey(branchWithValuePrefix, 2, partialKey)
+	}
+
+	var bitmap uint16
+
+	for i := range children {
+		bitmap |= 1 << i
+	}
+
+	data = append(data, byte(bitmap), byte(bitmap>>8))
+
+	if value != nil {
+		data = append(data, appendCompactBytes(nil, value)...)
+	}
+
+	for i := 0; i < 16; i++ {
+		child, ok := children[i]
+		if !ok {
+			continue
+		}
+
+		if len(child) >= hashLength {
+			h, err := hashNode(child)
+			require.NoError(t, err)
+
+			child = h[:]
+		}
+
+		data = append(data, appendCompactBytes(nil, child)...)
+	}
+
+	return data
+}
@@ -0,0 +1,198 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// VerifyProof checks the read proof against the provided state root, e.g. the StateRoot of a types.Header, and
+// returns the proven values of the keys in the order of the keys. The keys that are proven to have no value are
+// returned with HasStorageData unset. Both state versions are supported: with state version 1, the values of 33
+// bytes or more are stored as separate nodes of the proof.
+//
+// An error matching ErrIncompleteProof is returned if the proof lacks one of the nodes needed for a key, which is
+// the case for any proof that was not generated for the provided root.
+func VerifyProof(root types.Hash, proof []types.Bytes, keys []types.StorageKey) ([]types.KeyValueOption, error) {
+	db, err := newProofDB(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.lookupAll(root, keys)
+}
+
+// VerifyChildProof checks the read proof of child storage values against the provided state root, and returns the
+// proven values of the keys in the child trie. The childStorageKey is the key of the child trie root in the top
+// trie, as returned by NewChildStorageKey. The keys are all proven to have no value if the child trie does not
+// exist.
+func VerifyChildProof(
+	root types.Hash,
+	proof []types.Bytes,
+	childStorageKey types.StorageKey,
+	keys []types.StorageKey,
+) ([]types.KeyValueOption, error) {
+	db, err := newProofDB(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	childRoot, ok, err := db.lookup(root, childStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		childRoot = EmptyRoot[:]
+	}
+
+	if len(childRoot) != hashLength {
+		return nil, ErrInvalidNode.WithMsg("child trie root of %d bytes", len(childRoot))
+	}
+
+	return db.lookupAll(types.NewHash(childRoot), keys)
+}
+
+// proofDB holds the nodes and values of a proof by hash.
+type proofDB struct {
+	nodes nodeStore
+	// recorded holds the hashes of the nodes and values accessed by the lookups, if it is set.
+	recorded map[types.Hash]struct{}
+}
+
+func newProofDB(proof []types.Bytes) (proofDB, error) {
+	db := proofDB{nodes: make(nodeStore, len(proof))}
+
+	for _, item := range proof {
+		if _, err := db.nodes.hash(item); err != nil {
+			return proofDB{}, err
+		}
+	}
+
+	return db, nil
+}
+
+func (db proofDB) lookupAll(root types.Hash, keys []types.StorageKey) ([]types.KeyValueOption, error) {
+	res := make([]types.KeyValueOption, len(keys))
+
+	for i, key := range keys {
+		value, ok, err := db.lookup(root, key)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = types.KeyValueOption{
+			StorageKey:     key,
+			HasStorageData: ok,
+			StorageData:    value,
+		}
+	}
+
+	return res, nil
+}
+
+// lookup walks the trie from the root along the nibbles of the key, and returns the value of the key and true if
+// the key has a value.
+func (db proofDB) lookup(root types.Hash, key []byte) ([]byte, bool, error) {
+	if root == EmptyRoot {
+		return nil, false, nil
+	}
+
+	data, err := db.get(root)
+	if err != nil {
+		return nil, false, err
+	}
+
+	nibbles := keyNibbles(key)
+
+	for {
+		n, err := decodeNode(data)
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch n.kind {
+		case nodeKindEmpty:
+			return nil, false, nil
+		case nodeKindLeaf:
+			if !bytes.Equal(n.partialKey, nibbles) {
+				return nil, false, nil
+			}
+
+			return db.value(n)
+		}
+
+		if !bytes.HasPrefix(nibbles, n.partialKey) {
+			return nil, false, nil
+		}
+
+		nibbles = nibbles[len(n.partialKey):]
+
+		if len(nibbles) == 0 {
+			if n.value == nil {
+				return nil, false, nil
+			}
+
+			return db.value(n)
+		}
+
+		child := n.children[nibbles[0]]
+		if child == nil {
+			return nil, false, nil
+		}
+
+		nibbles = nibbles[1:]
+
+		data = child
+
+		if h, ok := childHash(child); ok {
+			data, err = db.get(h)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+	}
+}
+
+// value returns the value of the node, which is retrieved from the proof if it is hashed.
+func (db proofDB) value(n *node) ([]byte, bool, error) {
+	if !n.hashedValue {
+		return n.value, true, nil
+	}
+
+	value, err := db.get(types.NewHash(n.value))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (db proofDB) get(h types.Hash) ([]byte, error) {
+	data, ok := db.nodes[h]
+	if !ok {
+		return nil, ErrIncompleteProof.WithMsg("missing node %s", h.Hex())
+	}
+
+	if db.recorded != nil {
+		db.recorded[h] = struct{}{}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,172 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testLongValue   = bytes.Repeat([]byte{0xdd}, 40)
+	testHashedValue = bytes.Repeat([]byte{0xbb}, 40)
+)
+
+func TestEmptyRoot(t *testing.T) {
+	root, err := hashNode([]byte{emptyNode})
+	require.NoError(t, err)
+	assert.Equal(t, EmptyRoot, root)
+}
+
+func TestVerifyProof(t *testing.T) {
+	root, proof := newTestProof(t)
+
+	keys := []types.StorageKey{
+		codec.MustHexDecodeString("0x01"),
+		codec.MustHexDecodeString("0x0102"),
+		codec.MustHexDecodeString("0x0103"),
+		codec.MustHexDecodeString("0x1f"),
+		// The branch of the key has no value.
+		codec.MustHexDecodeString("0x0100"),
+		// The branch of the key has no child for the next nibble.
+		codec.MustHexDecodeString("0x0104"),
+		codec.MustHexDecodeString("0x02"),
+		// The leaf of the key has another partial key.
+		codec.MustHexDecodeString("0x1e"),
+		codec.MustHexDecodeString("0x1f00"),
+		// The key ends in the partial key of a leaf.
+		codec.MustHexDecodeString("0x"),
+	}
+
+	values, err := VerifyProof(root, proof, keys)
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.KeyValueOption{
+		{StorageKey: keys[0], HasStorageData: true, StorageData: []byte("c")},
+		{StorageKey: keys[1], HasStorageData: true, StorageData: []byte("a")},
+		{StorageKey: keys[2], HasStorageData: true, StorageData: testHashedValue},
+		{StorageKey: keys[3], HasStorageData: true, StorageData: testLongValue},
+		{StorageKey: keys[4]},
+		{StorageKey: keys[5]},
+		{StorageKey: keys[6]},
+		{StorageKey: keys[7]},
+		{StorageKey: keys[8]},
+		{StorageKey: keys[9]},
+	}, values)
+}
+
+func TestVerifyProof_IncompleteProof(t *testing.T) {
+	root, proof := newTestProof(t)
+
+	// The last item of the proof is the hashed value, which is not needed for the other keys.
+	values, err := VerifyProof(root, proof[:len(proof)-1], []types.StorageKey{codec.MustHexDecodeString("0x0102")})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), []byte(values[0].StorageData))
+
+	_, err = VerifyProof(root, proof[:len(proof)-1], []types.StorageKey{codec.MustHexDecodeString("0x0103")})
+	assert.ErrorIs(t, err, ErrIncompleteProof)
+
+	// The second item of the proof is the leaf of 0x1f.
+	_, err = VerifyProof(
+		root,
+		append([]types.Bytes{proof[0]}, proof[2:]...),
+		[]types.StorageKey{codec.MustHexDecodeString("0x1f")},
+	)
+	assert.ErrorIs(t, err, ErrIncompleteProof)
+}
+
+func TestVerifyProof_WrongRoot(t *testing.T) {
+	_, proof := newTestProof(t)
+
+	_, err := VerifyProof(types.Hash{1}, proof, []types.StorageKey{codec.MustHexDecodeString("0x01")})
+	assert.ErrorIs(t, err, ErrIncompleteProof)
+}
+
+func TestVerifyProof_EmptyTrie(t *testing.T) {
+	values, err := VerifyProof(EmptyRoot, nil, []types.StorageKey{codec.MustHexDecodeString("0x01")})
+	require.NoError(t, err)
+	assert.False(t, values[0].HasStorageData)
+}
+
+func TestVerifyChildProof(t *testing.T) {
+	childRoot, childProof := newTestProof(t)
+
+	childStorageKey := NewChildStorageKey([]byte("child"))
+	assert.Equal(t, types.StorageKey(":child_storage:default:child"), childStorageKey)
+
+	top := encodeBranch(t, nil, nil, map[int][]byte{
+		0x3: encodeLeaf(keyNibbles(childStorageKey)[1:], childRoot[:]),
+		0x9: encodeLeaf([]byte{0x1}, []byte{0x01}),
+	})
+
+	root, err := hashNode(top)
+	require.NoError(t, err)
+
+	proof := append([]types.Bytes{top, encodeLeaf(keyNibbles(childStorageKey)[1:], childRoot[:])}, childProof...)
+
+	values, err := VerifyChildProof(root, proof, childStorageKey, []types.StorageKey{
+		codec.MustHexDecodeString("0x0103"),
+		codec.MustHexDecodeString("0x0104"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testHashedValue, []byte(values[0].StorageData))
+	assert.False(t, values[1].HasStorageData)
+
+	// The child trie does not exist.
+	values, err = VerifyChildProof(root, proof, NewChildStorageKey([]byte("other")), []types.StorageKey{
+		codec.MustHexDecodeString("0x0103"),
+	})
+	require.NoError(t, err)
+	assert.False(t, values[0].HasStorageData)
+}
+
+// newTestProof returns the root and the proof of a trie mixing the state versions, holding the keys:
+//
+//   - 0x01 with value "c", in a branch.
+//   - 0x0102 with value "a", in a leaf inlined in its parent.
+//   - 0x0103 with a value of 40 bytes stored as a separate node, as done with state version 1.
+//   - 0x1f with a value of 40 bytes stored in the leaf, as done with state version 0.
+//
+// The proof holds every node of the trie, the leaf of 0x1f being the second one and the hashed value the last one.
+func newTestProof(t *testing.T) (types.Hash, []types.Bytes) {
+	longLeaf := encodeLeaf([]byte{0xf}, testLongValue)
+
+	// The nodes below are longer than a hash since they hold the hash of the value, or of a child.
+	hashedValueLeaf := encodeHashedValueLeaf(t, nil, testHashedValue)
+	innerBranch := encodeBranch(t, nil, nil, map[int][]byte{
+		0x2: encodeLeaf(nil, []byte("a")),
+		0x3: hashedValueLeaf,
+	})
+	valueBranch := encodeBranch(t, []byte{0x1}, []byte("c"), map[int][]byte{
+		0x0: innerBranch,
+	})
+
+	root := encodeBranch(t, nil, nil, map[int][]byte{
+		0x0: valueBranch,
+		0x1: longLeaf,
+	})
+
+	rootHash, err := hashNode(root)
+	require.NoError(t, err)
+
+	return rootHash, []types.Bytes{root, longLeaf, valueBranch, innerBranch, hashedValueLeaf, testHashedValue}
+}
@@ -0,0 +1,216 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// StateVersion is the version of the trie layout, which is set by the state_version of the runtime version.
+type StateVersion uint8
+
+const (
+	// StateVersionV0 stores the values in the nodes.
+	StateVersionV0 StateVersion = iota
+	// StateVersionV1 stores the values of 33 bytes or more as separate nodes, referenced by their hash.
+	StateVersionV1
+)
+
+// valueNodeThreshold is the size from which values are stored as separate nodes with StateVersionV1.
+const valueNodeThreshold = 33
+
+// KeyValue is a key of a trie with its value.
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+// Root returns the root of the trie holding the provided entries. The last value is kept for the keys provided
+// more than once.
+func Root(entries []KeyValue, version StateVersion) (types.Hash, error) {
+	sorted := make([]KeyValue, len(entries))
+	copy(sorted, entries)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Key, sorted[j].Key) < 0
+	})
+
+	nibbleEntries := make([]nibbleEntry, 0, len(sorted))
+
+	for i, entry := range sorted {
+		if i+1 < len(sorted) && bytes.Equal(entry.Key, sorted[i+1].Key) {
+			continue
+		}
+
+		nibbleEntries = append(nibbleEntries, nibbleEntry{key: keyNibbles(entry.Key), value: entry.Value})
+	}
+
+	return buildRoot(nibbleEntries, version, nil)
+}
+
+// buildRoot builds the trie holding the provided entries, which are sorted by key and have unique keys, and returns
+// its root. The hashed nodes and values are recorded in the store, if it is set.
+func buildRoot(entries []nibbleEntry, version StateVersion, store nodeStore) (types.Hash, error) {
+	if len(entries) == 0 {
+		return EmptyRoot, nil
+	}
+
+	root, err := buildNode(entries, version, store)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return store.hash(root.encode())
+}
+
+// nodeStore holds the nodes and values of a trie by hash.
+type nodeStore map[types.Hash][]byte
+
+// hash returns the hash of the encoded node or value, which is recorded if the store is set.
+func (s nodeStore) hash(data []byte) (types.Hash, error) {
+	h, err := hashNode(data)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	if s != nil {
+		s[h] = append([]byte{}, data...)
+	}
+
+	return h, nil
+}
+
+// OrderedRoot returns the root of the trie holding the provided values under their compact encoded index, such
+// as the extrinsics root of a block.
+func OrderedRoot(values [][]byte, version StateVersion) (types.Hash, error) {
+	entries := make([]KeyValue, len(values))
+
+	for i, value := range values {
+		var buf bytes.Buffer
+
+		err := scale.NewEncoder(&buf).EncodeUintCompact(*big.NewInt(int64(i)))
+		if err != nil {
+			return types.Hash{}, err
+		}
+
+		entries[i] = KeyValue{Key: buf.Bytes(), Value: value}
+	}
+
+	return Root(entries, version)
+}
+
+// nibbleEntry is an entry of the trie with the nibbles of its key that are below the node being built.
+type nibbleEntry struct {
+	key   []byte
+	value []byte
+}
+
+// buildNode builds the node holding the provided entries, which are sorted by key and have unique keys.
+func buildNode(entries []nibbleEntry, version StateVersion, store nodeStore) (*node, error) {
+	if len(entries) == 1 {
+		n := &node{kind: nodeKindLeaf, partialKey: entries[0].key}
+
+		return n, n.setValue(entries[0].value, version, store)
+	}
+
+	// Since the entries are sorted, the prefix common to all of them is the one of the first and last entries.
+	first, last := entries[0].key, entries[len(entries)-1].key
+
+	prefix := 0
+	for prefix < len(first) && prefix < len(last) && first[prefix] == last[prefix] {
+		prefix++
+	}
+
+	n := &node{kind: nodeKindBranch, partialKey: first[:prefix]}
+
+	if len(first) == prefix {
+		if err := n.setValue(entries[0].value, version, store); err != nil {
+			return nil, err
+		}
+
+		entries = entries[1:]
+	}
+
+	for len(entries) > 0 {
+		nibble := entries[0].key[prefix]
+
+		end := 1
+		for end < len(entries) && entries[end].key[prefix] == nibble {
+			end++
+		}
+
+		children := make([]nibbleEntry, end)
+		for i, entry := range entries[:end] {
+			children[i] = nibbleEntry{key: entry.key[prefix+1:], value: entry.value}
+		}
+
+		child, err := buildNode(children, version, store)
+		if err != nil {
+			return nil, err
+		}
+
+		n.children[nibble], err = childReference(child, store)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = entries[end:]
+	}
+
+	return n, nil
+}
+
+// setValue sets the value of the node, which is replaced by its hash if it is stored as a separate node.
+func (n *node) setValue(value []byte, version StateVersion, store nodeStore) error {
+	if version == StateVersionV0 || len(value) < valueNodeThreshold {
+		// The value of the node is nil only if the node has no value.
+		n.value = append([]byte{}, value...)
+		return nil
+	}
+
+	h, err := store.hash(value)
+	if err != nil {
+		return err
+	}
+
+	n.value = h[:]
+	n.hashedValue = true
+
+	return nil
+}
+
+// childReference returns the reference of the child held by its parent, which is the encoded child if shorter
+// than a hash.
+func childReference(child *node, store nodeStore) ([]byte, error) {
+	data := child.encode()
+
+	if len(data) < hashLength {
+		return data, nil
+	}
+
+	h, err := store.hash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return h[:], nil
+}
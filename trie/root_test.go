@@ -0,0 +1,141 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trie
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoot_Empty(t *testing.T) {
+	root, err := Root(nil, StateVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, EmptyRoot, root)
+
+	root, err = OrderedRoot(nil, StateVersionV0)
+	require.NoError(t, err)
+	assert.Equal(t, EmptyRoot, root)
+}
+
+func TestRoot(t *testing.T) {
+	entries := []KeyValue{
+		{Key: codec.MustHexDecodeString("0x1f"), Value: testLongValue},
+		{Key: codec.MustHexDecodeString("0x0103"), Value: testHashedValue},
+		{Key: codec.MustHexDecodeString("0x0102"), Value: []byte("b")},
+		{Key: codec.MustHexDecodeString("0x01"), Value: []byte("c")},
+		// The last value of a key is kept.
+		{Key: codec.MustHexDecodeString("0x0102"), Value: []byte("a")},
+	}
+
+	expectedV1, proofV1 := newTestRoot(t, StateVersionV1)
+
+	root, err := Root(entries, StateVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, expectedV1, root)
+
+	expectedV0, proofV0 := newTestRoot(t, StateVersionV0)
+	assert.NotEqual(t, expectedV1, expectedV0)
+
+	root, err = Root(entries, StateVersionV0)
+	require.NoError(t, err)
+	assert.Equal(t, expectedV0, root)
+
+	tests := map[types.Hash][]types.Bytes{expectedV0: proofV0, expectedV1: proofV1}
+
+	for root, proof := range tests {
+		values, err := VerifyProof(root, proof, []types.StorageKey{codec.MustHexDecodeString("0x1f")})
+		require.NoError(t, err)
+		assert.Equal(t, testLongValue, []byte(values[0].StorageData))
+	}
+}
+
+func TestRoot_SingleEntry(t *testing.T) {
+	root, err := Root([]KeyValue{{Key: []byte{0x12}, Value: []byte{0x01}}}, StateVersionV0)
+	require.NoError(t, err)
+
+	// The root node is hashed even if shorter than a hash.
+	expected, err := hashNode([]byte{0x42, 0x12, 0x04, 0x01})
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+}
+
+func TestOrderedRoot(t *testing.T) {
+	values := make([][]byte, 70)
+	entries := make([]KeyValue, 70)
+
+	for i := range values {
+		values[i] = []byte{byte(i)}
+
+		key, err := codec.Encode(types.NewUCompactFromUInt(uint64(i)))
+		require.NoError(t, err)
+
+		entries[i] = KeyValue{Key: key, Value: values[i]}
+	}
+
+	expected, err := Root(entries, StateVersionV1)
+	require.NoError(t, err)
+
+	root, err := OrderedRoot(values, StateVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+
+	// The first value is stored under the compact encoded 0, that is the nibbles 0 and 0.
+	root, err = OrderedRoot(values[:1], StateVersionV1)
+	require.NoError(t, err)
+
+	expected, err = hashNode(encodeLeaf([]byte{0, 0}, values[0]))
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+}
+
+// newTestRoot returns the root and the proof of the trie holding the keys 0x01, 0x0102, 0x0103 and 0x1f with the
+// values of newTestProof, built for the provided state version.
+func newTestRoot(t *testing.T, version StateVersion) (types.Hash, []types.Bytes) {
+	var longLeaf, hashedLeaf []byte
+
+	proof := []types.Bytes{}
+
+	if version == StateVersionV0 {
+		longLeaf = encodeLeaf([]byte{0xf}, testLongValue)
+		hashedLeaf = encodeLeaf(nil, testHashedValue)
+	} else {
+		longLeaf = encodeHashedValueLeaf(t, []byte{0xf}, testLongValue)
+		hashedLeaf = encodeHashedValueLeaf(t, nil, testHashedValue)
+		proof = append(proof, testLongValue, testHashedValue)
+	}
+
+	innerBranch := encodeBranch(t, nil, nil, map[int][]byte{
+		0x2: encodeLeaf(nil, []byte("a")),
+		0x3: hashedLeaf,
+	})
+	valueBranch := encodeBranch(t, []byte{0x1}, []byte("c"), map[int][]byte{
+		0x0: innerBranch,
+	})
+	root := encodeBranch(t, nil, nil, map[int][]byte{
+		0x0: valueBranch,
+		0x1: longLeaf,
+	})
+
+	rootHash, err := hashNode(root)
+	require.NoError(t, err)
+
+	return rootHash, append(proof, root, longLeaf, valueBranch, innerBranch, hashedLeaf)
+}
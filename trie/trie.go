@@ -0,0 +1,64 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trie implements the base-16 Patricia-Merkle trie used by Substrate for storing the state, with an
+// in-memory trie generating the roots and the read proofs of its values, and the verification of these proofs.
+package trie
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ChildStorageKeyPrefix is the prefix of the keys under which the roots of the default child tries are stored in
+// the top trie.
+const ChildStorageKeyPrefix = ":child_storage:default:"
+
+// EmptyRoot is the root of a trie without any value, which is the hash of the encoded empty node.
+var EmptyRoot = types.NewHash([]byte{
+	0x03, 0x17, 0x0a, 0x2e, 0x75, 0x97, 0xb7, 0xb7, 0xe3, 0xd8, 0x4c, 0x05, 0x39, 0x1d, 0x13, 0x9a,
+	0x62, 0xb1, 0x57, 0xe7, 0x87, 0x86, 0xd8, 0xc0, 0x82, 0xf2, 0x9d, 0xcf, 0x4c, 0x11, 0x13, 0x14,
+})
+
+// NewChildStorageKey returns the key under which the root of the default child trie with the provided key is
+// stored in the top trie. This is the key expected by the child storage RPC methods.
+func NewChildStorageKey(childKey []byte) types.StorageKey {
+	return types.NewStorageKey(append([]byte(ChildStorageKeyPrefix), childKey...))
+}
+
+// hashNode returns the blake2b-256 hash of the provided encoded node, or value.
+func hashNode(data []byte) (types.Hash, error) {
+	h, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, ErrNodeHashing.Wrap(err)
+	}
+
+	// Writing to a hash never returns an error.
+	_, _ = h.Write(data)
+
+	return types.NewHash(h.Sum(nil)), nil
+}
+
+// keyNibbles returns the nibbles of the provided key, the high nibble of each byte first.
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	return nibbles
+}
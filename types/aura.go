@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+
+// AuraEngineID is the ConsensusEngineID of Aura, [b'a', b'u', b'r', b'a']
+const AuraEngineID ConsensusEngineID = 0x61727561
+
+// FindAuraSlot returns the slot found in the Aura PreRuntime item of the digest, and false if there is none
+func FindAuraSlot(digest Digest) (U64, bool, error) {
+	for _, item := range digest {
+		if !item.IsPreRuntime || item.AsPreRuntime.ConsensusEngineID != AuraEngineID {
+			continue
+		}
+
+		var slot U64
+
+		if err := codec.Decode(item.AsPreRuntime.Bytes, &slot); err != nil {
+			return 0, false, err
+		}
+
+		return slot, true, nil
+	}
+
+	return 0, false, nil
+}
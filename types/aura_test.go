@@ -0,0 +1,56 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAuraSlot(t *testing.T) {
+	digest := Digest{
+		{IsPreRuntime: true, AsPreRuntime: PreRuntime{ConsensusEngineID: BabeEngineID, Bytes: []byte{0x02}}},
+		{
+			IsPreRuntime: true,
+			AsPreRuntime: PreRuntime{ConsensusEngineID: AuraEngineID, Bytes: []byte{0x2a, 0, 0, 0, 0, 0, 0, 0}},
+		},
+	}
+
+	slot, ok, err := FindAuraSlot(digest)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, U64(42), slot)
+
+	_, ok, err = FindAuraSlot(digest[:1])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	digest[1].AsPreRuntime.Bytes = []byte{0x01}
+
+	_, _, err = FindAuraSlot(digest)
+	assert.Error(t, err)
+}
+
+func TestAuraEngineID(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{Input: AuraEngineID, Expected: []byte("aura")},
+		{Input: BabeEngineID, Expected: []byte("BABE")},
+	})
+}
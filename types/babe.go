@@ -0,0 +1,159 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// BabeEngineID is the ConsensusEngineID of BABE, [b'B', b'A', b'B', b'E']
+const BabeEngineID ConsensusEngineID = 0x45424142
+
+// BabeAuthority is a BABE authority with its weight, as found in the Babe.Authorities storage
+type BabeAuthority struct {
+	ID     AuthorityID
+	Weight U64
+}
+
+// BabeVRFSignature is the VRF output of the slot claim and its proof
+type BabeVRFSignature struct {
+	PreOutput [32]byte
+	Proof     [64]byte
+}
+
+// BabePrimaryPreDigest is the pre-digest of a block authored in a primary slot, won through the VRF lottery
+type BabePrimaryPreDigest struct {
+	AuthorityIndex U32
+	Slot           U64
+	VRFSignature   BabeVRFSignature
+}
+
+// BabeSecondaryPlainPreDigest is the pre-digest of a block authored in a secondary slot, assigned round-robin
+type BabeSecondaryPlainPreDigest struct {
+	AuthorityIndex U32
+	Slot           U64
+}
+
+// BabeSecondaryVRFPreDigest is the pre-digest of a block authored in a secondary slot, with a VRF output
+type BabeSecondaryVRFPreDigest struct {
+	AuthorityIndex U32
+	Slot           U64
+	VRFSignature   BabeVRFSignature
+}
+
+// BabePreDigest is the BABE pre-digest found in the PreRuntime digest item with the BabeEngineID
+type BabePreDigest struct {
+	IsPrimary        bool // 1
+	AsPrimary        BabePrimaryPreDigest
+	IsSecondaryPlain bool // 2
+	AsSecondaryPlain BabeSecondaryPlainPreDigest
+	IsSecondaryVRF   bool // 3
+	AsSecondaryVRF   BabeSecondaryVRFPreDigest
+}
+
+func (d *BabePreDigest) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 1:
+		d.IsPrimary = true
+		err = decoder.Decode(&d.AsPrimary)
+	case 2:
+		d.IsSecondaryPlain = true
+		err = decoder.Decode(&d.AsSecondaryPlain)
+	case 3:
+		d.IsSecondaryVRF = true
+		err = decoder.Decode(&d.AsSecondaryVRF)
+	default:
+		return fmt.Errorf("no such variant for BabePreDigest: %d", b)
+	}
+
+	return err
+}
+
+func (d BabePreDigest) Encode(encoder scale.Encoder) error {
+	var err1, err2 error
+	switch {
+	case d.IsPrimary:
+		err1 = encoder.PushByte(1)
+		err2 = encoder.Encode(d.AsPrimary)
+	case d.IsSecondaryPlain:
+		err1 = encoder.PushByte(2)
+		err2 = encoder.Encode(d.AsSecondaryPlain)
+	case d.IsSecondaryVRF:
+		err1 = encoder.PushByte(3)
+		err2 = encoder.Encode(d.AsSecondaryVRF)
+	default:
+		return fmt.Errorf("no variant set for BabePreDigest")
+	}
+
+	if err1 != nil {
+		return err1
+	}
+
+	return err2
+}
+
+// AuthorityIndex returns the index of the block author in the authorities of the epoch
+func (d BabePreDigest) AuthorityIndex() U32 {
+	switch {
+	case d.IsPrimary:
+		return d.AsPrimary.AuthorityIndex
+	case d.IsSecondaryPlain:
+		return d.AsSecondaryPlain.AuthorityIndex
+	default:
+		return d.AsSecondaryVRF.AuthorityIndex
+	}
+}
+
+// Slot returns the slot the block was authored in
+func (d BabePreDigest) Slot() U64 {
+	switch {
+	case d.IsPrimary:
+		return d.AsPrimary.Slot
+	case d.IsSecondaryPlain:
+		return d.AsSecondaryPlain.Slot
+	default:
+		return d.AsSecondaryVRF.Slot
+	}
+}
+
+// FindBabePreDigest returns the BABE pre-digest found in the PreRuntime items of the digest, and false if there is
+// none
+func FindBabePreDigest(digest Digest) (BabePreDigest, bool, error) {
+	var preDigest BabePreDigest
+
+	for _, item := range digest {
+		if !item.IsPreRuntime || item.AsPreRuntime.ConsensusEngineID != BabeEngineID {
+			continue
+		}
+
+		if err := codec.Decode(item.AsPreRuntime.Bytes, &preDigest); err != nil {
+			return BabePreDigest{}, false, err
+		}
+
+		return preDigest, true, nil
+	}
+
+	return preDigest, false, nil
+}
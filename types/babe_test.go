@@ -0,0 +1,108 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	babePreDigestFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(d *BabePreDigest, c fuzz.Continue) {
+			switch c.Intn(3) {
+			case 0:
+				d.IsPrimary = true
+				c.Fuzz(&d.AsPrimary)
+			case 1:
+				d.IsSecondaryPlain = true
+				c.Fuzz(&d.AsSecondaryPlain)
+			case 2:
+				d.IsSecondaryVRF = true
+				c.Fuzz(&d.AsSecondaryVRF)
+			}
+		}),
+	}
+)
+
+func TestBabePreDigest_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[BabePreDigest](t, 100, babePreDigestFuzzOpts...)
+	AssertDecodeNilData[BabePreDigest](t)
+
+	_, err := Encode(BabePreDigest{})
+	assert.Error(t, err)
+
+	err = Decode([]byte{0x04}, new(BabePreDigest))
+	assert.Error(t, err)
+
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input: BabePreDigest{
+				IsSecondaryPlain: true,
+				AsSecondaryPlain: BabeSecondaryPlainPreDigest{AuthorityIndex: 2, Slot: 3},
+			},
+			Expected: []byte{0x02, 2, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0},
+		},
+	})
+}
+
+func TestBabePreDigest_Accessors(t *testing.T) {
+	vrf := BabeVRFSignature{PreOutput: [32]byte{0x01}, Proof: [64]byte{0x02}}
+
+	for _, d := range []BabePreDigest{
+		{IsPrimary: true, AsPrimary: BabePrimaryPreDigest{AuthorityIndex: 4, Slot: 9, VRFSignature: vrf}},
+		{IsSecondaryPlain: true, AsSecondaryPlain: BabeSecondaryPlainPreDigest{AuthorityIndex: 4, Slot: 9}},
+		{IsSecondaryVRF: true, AsSecondaryVRF: BabeSecondaryVRFPreDigest{AuthorityIndex: 4, Slot: 9, VRFSignature: vrf}},
+	} {
+		assert.Equal(t, U32(4), d.AuthorityIndex())
+		assert.Equal(t, U64(9), d.Slot())
+	}
+}
+
+func TestFindBabePreDigest(t *testing.T) {
+	preDigest := BabePreDigest{
+		IsPrimary: true,
+		AsPrimary: BabePrimaryPreDigest{AuthorityIndex: 1, Slot: 100},
+	}
+
+	enc, err := Encode(preDigest)
+	assert.NoError(t, err)
+
+	digest := Digest{
+		{IsPreRuntime: true, AsPreRuntime: PreRuntime{ConsensusEngineID: AuraEngineID, Bytes: []byte{0x01}}},
+		{IsPreRuntime: true, AsPreRuntime: PreRuntime{ConsensusEngineID: BabeEngineID, Bytes: enc}},
+	}
+
+	res, ok, err := FindBabePreDigest(digest)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, preDigest, res)
+
+	_, ok, err = FindBabePreDigest(digest[:1])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	digest[1].AsPreRuntime.Bytes = []byte{0x07}
+
+	_, _, err = FindBabePreDigest(digest)
+	assert.Error(t, err)
+}
@@ -22,6 +22,12 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
 
+// BeefyMMRRootPayloadID is the ID of the payload item holding the SCALE encoded root of the MMR of the block
+var BeefyMMRRootPayloadID = [2]byte{'m', 'h'}
+
+// BeefyAuthorityID is the compressed secp256k1 public key of a BEEFY authority
+type BeefyAuthorityID [33]byte
+
 // PayloadItem ...
 type PayloadItem struct {
 	ID   [2]byte
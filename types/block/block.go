@@ -2,9 +2,16 @@ package block
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var (
+	ErrExtrinsicsRootMismatch = errors.New("extrinsics root mismatch")
 )
 
 type SignedBlock struct {
@@ -37,3 +44,45 @@ func (s *SignedBlock) DecodeExtrinsics(decoder *registry.ExtrinsicDecoder) ([]*r
 
 	return decodedExtrinsics, nil
 }
+
+// Hash returns the hash of the block, which is the hash of its header.
+func (b *Block) Hash() (types.Hash, error) {
+	return b.Header.Hash()
+}
+
+// ExtrinsicsRoot computes the root of the trie holding the extrinsics of the block, which is expected in the
+// ExtrinsicsRoot of the header. The state version is the one of the runtime of the block, found in the
+// StateVersion of its runtime version.
+func (b *Block) ExtrinsicsRoot(version trie.StateVersion) (types.Hash, error) {
+	extrinsics := make([][]byte, len(b.Extrinsics))
+
+	for i, hexEncodedExtrinsic := range b.Extrinsics {
+		extrinsic, err := codec.HexDecodeString(hexEncodedExtrinsic)
+		if err != nil {
+			return types.Hash{}, fmt.Errorf("extrinsic %d: %w", i, err)
+		}
+
+		extrinsics[i] = extrinsic
+	}
+
+	return trie.OrderedRoot(extrinsics, version)
+}
+
+// VerifyExtrinsicsRoot checks that the extrinsics of the block match the ExtrinsicsRoot of its header.
+func (b *Block) VerifyExtrinsicsRoot(version trie.StateVersion) error {
+	root, err := b.ExtrinsicsRoot(version)
+	if err != nil {
+		return err
+	}
+
+	if root != b.Header.ExtrinsicsRoot {
+		return fmt.Errorf(
+			"%w: computed %s, header has %s",
+			ErrExtrinsicsRootMismatch,
+			root.Hex(),
+			b.Header.ExtrinsicsRoot.Hex(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/trie"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlock_Hash(t *testing.T) {
+	// The genesis block of Polkadot.
+	b := Block{
+		Header: types.Header{
+			StateRoot:      types.NewHash(codec.MustHexDecodeString("0x29d0d972cd27cbc511e9589fcb7a4506d5eb6a9e8df205f00472e5ab354a4e17")), //nolint:lll
+			ExtrinsicsRoot: trie.EmptyRoot,
+		},
+	}
+
+	hash, err := b.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3", hash.Hex())
+
+	assert.NoError(t, b.VerifyExtrinsicsRoot(trie.StateVersionV0))
+}
+
+func TestBlock_VerifyExtrinsicsRoot(t *testing.T) {
+	extrinsics := [][]byte{
+		codec.MustHexDecodeString("0x280403000b207eb80a8a01"),
+		append([]byte{0xc4}, make([]byte, 49)...),
+	}
+
+	b := Block{
+		Extrinsics: []string{codec.HexEncodeToString(extrinsics[0]), codec.HexEncodeToString(extrinsics[1])},
+	}
+
+	for _, version := range []trie.StateVersion{trie.StateVersionV0, trie.StateVersionV1} {
+		root, err := trie.OrderedRoot(extrinsics, version)
+		require.NoError(t, err)
+
+		b.Header.ExtrinsicsRoot = root
+
+		assert.NoError(t, b.VerifyExtrinsicsRoot(version))
+	}
+
+	// The long extrinsic is hashed with state version 1 only.
+	assert.ErrorIs(t, b.VerifyExtrinsicsRoot(trie.StateVersionV0), ErrExtrinsicsRootMismatch)
+
+	b.Extrinsics = b.Extrinsics[:1]
+
+	assert.ErrorIs(t, b.VerifyExtrinsicsRoot(trie.StateVersionV1), ErrExtrinsicsRootMismatch)
+
+	b.Extrinsics = []string{"0xzz"}
+
+	_, err := b.ExtrinsicsRoot(trie.StateVersionV1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PerDispatchClass holds a value for each of the dispatch classes
+type PerDispatchClass[T any] struct {
+	Normal      T
+	Operational T
+	Mandatory   T
+}
+
+// Get returns the value for the provided dispatch class
+func (p PerDispatchClass[T]) Get(class DispatchClass) T {
+	switch {
+	case class.IsOperational:
+		return p.Operational
+	case class.IsMandatory:
+		return p.Mandatory
+	default:
+		return p.Normal
+	}
+}
+
+// WeightsPerClass holds the weight limits of a single dispatch class
+type WeightsPerClass struct {
+	// The base weight of a single extrinsic of this class
+	BaseExtrinsic Weight
+	// The maximum weight of a single extrinsic of this class, if limited
+	MaxExtrinsic Option[Weight]
+	// The maximum total weight of all extrinsics of this class in a block, if limited
+	MaxTotal Option[Weight]
+	// The weight reserved for extrinsics of this class, if any
+	Reserved Option[Weight]
+}
+
+// BlockWeights holds the weight limits of a block, as found in the System.BlockWeights constant
+type BlockWeights struct {
+	// The base weight of a block
+	BaseBlock Weight
+	// The maximum weight of a block
+	MaxBlock Weight
+	// The weight limits per dispatch class
+	PerClass PerDispatchClass[WeightsPerClass]
+}
+
+// BlockLength holds the maximum length of a block per dispatch class, as found in the System.BlockLength constant
+type BlockLength struct {
+	Max PerDispatchClass[U32]
+}
+
+// RuntimeDispatchInfo holds the dispatch information of a call, as returned by the TransactionPaymentApi and
+// TransactionPaymentCallApi runtime APIs
+type RuntimeDispatchInfo struct {
+	Weight     Weight
+	Class      DispatchClass
+	PartialFee U128
+}
@@ -0,0 +1,89 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testWeightsPerClass = WeightsPerClass{
+		BaseExtrinsic: NewWeight(NewUCompactFromUInt(1), NewUCompactFromUInt(2)),
+		MaxExtrinsic:  NewOption(NewWeight(NewUCompactFromUInt(3), NewUCompactFromUInt(4))),
+		MaxTotal:      NewOption(NewWeight(NewUCompactFromUInt(5), NewUCompactFromUInt(6))),
+		Reserved:      NewEmptyOption[Weight](),
+	}
+
+	testBlockWeights = BlockWeights{
+		BaseBlock: NewWeight(NewUCompactFromUInt(7), NewUCompactFromUInt(8)),
+		MaxBlock:  NewWeight(NewUCompactFromUInt(9), NewUCompactFromUInt(10)),
+		PerClass: PerDispatchClass[WeightsPerClass]{
+			Normal:      testWeightsPerClass,
+			Operational: testWeightsPerClass,
+			Mandatory:   testWeightsPerClass,
+		},
+	}
+
+	testBlockLength = BlockLength{
+		Max: PerDispatchClass[U32]{Normal: 1, Operational: 2, Mandatory: 3},
+	}
+
+	testRuntimeDispatchInfo = RuntimeDispatchInfo{
+		Weight:     NewWeight(NewUCompactFromUInt(11), NewUCompactFromUInt(12)),
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(13)),
+	}
+)
+
+func TestBlockWeights_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testBlockWeights)
+}
+
+func TestBlockWeights_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{
+			testBlockWeights,
+			MustHexDecodeString("0x1c2024280408010c10011418000408010c10011418000408010c1001141800"),
+		},
+	})
+}
+
+func TestBlockLength_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testBlockLength)
+	AssertEncode(t, []EncodingAssert{
+		{testBlockLength, MustHexDecodeString("0x010000000200000003000000")},
+	})
+}
+
+func TestRuntimeDispatchInfo_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testRuntimeDispatchInfo)
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x2c30010d000000000000000000000000000000"), testRuntimeDispatchInfo},
+	})
+}
+
+func TestPerDispatchClass_Get(t *testing.T) {
+	assert.Equal(t, U32(1), testBlockLength.Max.Get(DispatchClass{IsNormal: true}))
+	assert.Equal(t, U32(2), testBlockLength.Max.Get(DispatchClass{IsOperational: true}))
+	assert.Equal(t, U32(3), testBlockLength.Max.Get(DispatchClass{IsMandatory: true}))
+}
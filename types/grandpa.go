@@ -0,0 +1,206 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// GrandpaEngineID is the ConsensusEngineID of GRANDPA, [b'F', b'R', b'N', b'K']
+const GrandpaEngineID ConsensusEngineID = 0x4b4e5246
+
+// GrandpaAuthority is a GRANDPA authority with its voting weight
+type GrandpaAuthority struct {
+	ID     AuthorityID
+	Weight U64
+}
+
+// GrandpaPrecommit is a precommit for a block and its ancestors
+type GrandpaPrecommit struct {
+	TargetHash   Hash
+	TargetNumber U32
+}
+
+// GrandpaSignedPrecommit is a precommit signed by an authority
+type GrandpaSignedPrecommit struct {
+	Precommit GrandpaPrecommit
+	// Signature is the ed25519 signature of the payload returned by GrandpaPrecommitPayload
+	Signature SignatureHash
+	ID        AuthorityID
+}
+
+// GrandpaCommit is the commit message of a round, made of the precommits for a block or its descendants
+type GrandpaCommit struct {
+	TargetHash   Hash
+	TargetNumber U32
+	Precommits   []GrandpaSignedPrecommit
+}
+
+// GrandpaJustification is a GRANDPA justification for block finality
+type GrandpaJustification struct {
+	Round  U64
+	Commit GrandpaCommit
+	// VotesAncestries holds the headers between the target of the commit and the targets of the precommits
+	VotesAncestries []Header
+}
+
+// UnmarshalText deserializes hex string into a GrandpaJustification.
+// Used for decoding JSON-RPC subscription messages (grandpa_subscribeJustifications)
+func (j *GrandpaJustification) UnmarshalText(text []byte) error {
+	return codec.DecodeFromHex(string(text), j)
+}
+
+// GrandpaPrecommitPayload returns the payload signed by the authorities for a precommit, which is the SCALE encoded
+// precommit message with the round and the ID of the authority set.
+func GrandpaPrecommitPayload(precommit GrandpaPrecommit, round U64, setID U64) ([]byte, error) {
+	// The precommit is the variant 1 of the message enum.
+	return codec.Encode(struct {
+		Variant   U8
+		Precommit GrandpaPrecommit
+		Round     U64
+		SetID     U64
+	}{1, precommit, round, setID})
+}
+
+// GrandpaFinalityProof is the proof of finality of a block, returned by grandpa_proveFinality
+type GrandpaFinalityProof struct {
+	// Block is the hash of the block finalized by the justification
+	Block Hash
+	// Justification is the SCALE encoded GrandpaJustification
+	Justification Bytes
+	// UnknownHeaders holds the headers from the requested block to the finalized block
+	UnknownHeaders []Header
+}
+
+// DecodeJustification decodes the justification of the finality proof
+func (p GrandpaFinalityProof) DecodeJustification() (GrandpaJustification, error) {
+	var j GrandpaJustification
+
+	err := codec.Decode(p.Justification, &j)
+
+	return j, err
+}
+
+// GrandpaScheduledChange is a change of the authority set, enacted when the block Delay blocks after the block
+// signaling it is finalized
+type GrandpaScheduledChange struct {
+	NextAuthorities []GrandpaAuthority
+	Delay           U32
+}
+
+// GrandpaForcedChange is a change of the authority set, enacted when the block Delay blocks after the block
+// signaling it is imported
+type GrandpaForcedChange struct {
+	MedianLastFinalized U32
+	Change              GrandpaScheduledChange
+}
+
+// GrandpaConsensusLog is the GRANDPA log found in the Consensus digest items with the GrandpaEngineID
+type GrandpaConsensusLog struct {
+	IsScheduledChange bool // 1
+	AsScheduledChange GrandpaScheduledChange
+	IsForcedChange    bool // 2
+	AsForcedChange    GrandpaForcedChange
+	IsOnDisabled      bool // 3
+	AsOnDisabled      U64
+	IsPause           bool // 4
+	AsPause           U32
+	IsResume          bool // 5
+	AsResume          U32
+}
+
+func (l *GrandpaConsensusLog) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 1:
+		l.IsScheduledChange = true
+		err = decoder.Decode(&l.AsScheduledChange)
+	case 2:
+		l.IsForcedChange = true
+		err = decoder.Decode(&l.AsForcedChange)
+	case 3:
+		l.IsOnDisabled = true
+		err = decoder.Decode(&l.AsOnDisabled)
+	case 4:
+		l.IsPause = true
+		err = decoder.Decode(&l.AsPause)
+	case 5:
+		l.IsResume = true
+		err = decoder.Decode(&l.AsResume)
+	default:
+		return fmt.Errorf("no such variant for GrandpaConsensusLog: %d", b)
+	}
+
+	return err
+}
+
+func (l GrandpaConsensusLog) Encode(encoder scale.Encoder) error {
+	var err1, err2 error
+	switch {
+	case l.IsScheduledChange:
+		err1 = encoder.PushByte(1)
+		err2 = encoder.Encode(l.AsScheduledChange)
+	case l.IsForcedChange:
+		err1 = encoder.PushByte(2)
+		err2 = encoder.Encode(l.AsForcedChange)
+	case l.IsOnDisabled:
+		err1 = encoder.PushByte(3)
+		err2 = encoder.Encode(l.AsOnDisabled)
+	case l.IsPause:
+		err1 = encoder.PushByte(4)
+		err2 = encoder.Encode(l.AsPause)
+	case l.IsResume:
+		err1 = encoder.PushByte(5)
+		err2 = encoder.Encode(l.AsResume)
+	default:
+		return fmt.Errorf("no variant set for GrandpaConsensusLog")
+	}
+
+	if err1 != nil {
+		return err1
+	}
+
+	return err2
+}
+
+// GrandpaConsensusLogs returns the GRANDPA logs found in the Consensus items of the digest
+func GrandpaConsensusLogs(digest Digest) ([]GrandpaConsensusLog, error) {
+	var logs []GrandpaConsensusLog
+
+	for _, item := range digest {
+		if !item.IsConsensus || item.AsConsensus.ConsensusEngineID != GrandpaEngineID {
+			continue
+		}
+
+		var log GrandpaConsensusLog
+
+		if err := codec.Decode(item.AsConsensus.Bytes, &log); err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
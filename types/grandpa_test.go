@@ -0,0 +1,163 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	grandpaConsensusLogFuzzOpts = []FuzzOpt{
+		WithFuzzFuncs(func(l *GrandpaConsensusLog, c fuzz.Continue) {
+			switch c.Intn(5) {
+			case 0:
+				l.IsScheduledChange = true
+				c.Fuzz(&l.AsScheduledChange)
+			case 1:
+				l.IsForcedChange = true
+				c.Fuzz(&l.AsForcedChange)
+			case 2:
+				l.IsOnDisabled = true
+				c.Fuzz(&l.AsOnDisabled)
+			case 3:
+				l.IsPause = true
+				c.Fuzz(&l.AsPause)
+			case 4:
+				l.IsResume = true
+				c.Fuzz(&l.AsResume)
+			}
+		}),
+	}
+)
+
+var testGrandpaJustification = GrandpaJustification{
+	Round: 7,
+	Commit: GrandpaCommit{
+		TargetHash:   NewHash([]byte{0x01}),
+		TargetNumber: 10,
+		Precommits: []GrandpaSignedPrecommit{
+			{
+				Precommit: GrandpaPrecommit{TargetHash: NewHash([]byte{0x02}), TargetNumber: 11},
+				Signature: SignatureHash{0x03},
+				ID:        AuthorityID{0x04},
+			},
+		},
+	},
+	VotesAncestries: []Header{{ParentHash: NewHash([]byte{0x01}), Number: 11}},
+}
+
+func TestGrandpaJustification_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testGrandpaJustification)
+	AssertDecodeNilData[GrandpaJustification](t)
+}
+
+func TestGrandpaJustification_UnmarshalText(t *testing.T) {
+	enc, err := EncodeToHex(testGrandpaJustification)
+	assert.NoError(t, err)
+
+	var j GrandpaJustification
+
+	err = j.UnmarshalText([]byte(enc))
+	assert.NoError(t, err)
+	assert.Equal(t, testGrandpaJustification, j)
+}
+
+func TestGrandpaPrecommitPayload(t *testing.T) {
+	payload, err := GrandpaPrecommitPayload(GrandpaPrecommit{TargetHash: NewHash([]byte{0xab}), TargetNumber: 2}, 3, 4)
+	assert.NoError(t, err)
+
+	expected := append([]byte{0x01, 0xab}, make([]byte, 31)...)
+	expected = append(expected, 2, 0, 0, 0)
+	expected = append(expected, 3, 0, 0, 0, 0, 0, 0, 0)
+	expected = append(expected, 4, 0, 0, 0, 0, 0, 0, 0)
+
+	assert.Equal(t, expected, payload)
+}
+
+func TestGrandpaFinalityProof_DecodeJustification(t *testing.T) {
+	enc, err := Encode(testGrandpaJustification)
+	assert.NoError(t, err)
+
+	proof := GrandpaFinalityProof{Block: NewHash([]byte{0x01}), Justification: enc}
+	AssertRoundtrip(t, proof)
+
+	j, err := proof.DecodeJustification()
+	assert.NoError(t, err)
+	assert.Equal(t, testGrandpaJustification, j)
+}
+
+func TestGrandpaConsensusLog_EncodeDecode(t *testing.T) {
+	AssertRoundTripFuzz[GrandpaConsensusLog](t, 100, grandpaConsensusLogFuzzOpts...)
+	AssertDecodeNilData[GrandpaConsensusLog](t)
+
+	_, err := Encode(GrandpaConsensusLog{})
+	assert.Error(t, err)
+
+	err = Decode([]byte{0x06}, new(GrandpaConsensusLog))
+	assert.Error(t, err)
+
+	AssertEncode(t, []EncodingAssert{
+		{
+			Input: GrandpaConsensusLog{
+				IsScheduledChange: true,
+				AsScheduledChange: GrandpaScheduledChange{
+					NextAuthorities: []GrandpaAuthority{{ID: AuthorityID{0x01}, Weight: 1}},
+					Delay:           5,
+				},
+			},
+			Expected: append(append([]byte{0x01, 0x04, 0x01}, make([]byte, 31)...), 1, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0),
+		},
+		{Input: GrandpaConsensusLog{IsPause: true, AsPause: 3}, Expected: []byte{0x04, 3, 0, 0, 0}},
+	})
+}
+
+func TestGrandpaConsensusLogs(t *testing.T) {
+	scheduled := GrandpaConsensusLog{IsScheduledChange: true, AsScheduledChange: GrandpaScheduledChange{Delay: 2}}
+	resume := GrandpaConsensusLog{IsResume: true, AsResume: 9}
+
+	digest := Digest{
+		testDigestItem1,
+		grandpaConsensusDigestItem(t, scheduled),
+		{IsConsensus: true, AsConsensus: Consensus{ConsensusEngineID: 0x45424142, Bytes: []byte{0x01}}},
+		grandpaConsensusDigestItem(t, resume),
+	}
+
+	logs, err := GrandpaConsensusLogs(digest)
+	assert.NoError(t, err)
+	assert.Equal(t, []GrandpaConsensusLog{scheduled, resume}, logs)
+
+	digest = append(digest, DigestItem{
+		IsConsensus: true,
+		AsConsensus: Consensus{ConsensusEngineID: GrandpaEngineID, Bytes: []byte{0x09}},
+	})
+
+	_, err = GrandpaConsensusLogs(digest)
+	assert.Error(t, err)
+}
+
+func grandpaConsensusDigestItem(t *testing.T, log GrandpaConsensusLog) DigestItem {
+	enc, err := Encode(log)
+	assert.NoError(t, err)
+
+	return DigestItem{IsConsensus: true, AsConsensus: Consensus{ConsensusEngineID: GrandpaEngineID, Bytes: enc}}
+}
@@ -18,11 +18,15 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
 )
 
 type Header struct {
@@ -33,6 +37,50 @@ type Header struct {
 	Digest         Digest      `json:"digest"`
 }
 
+var (
+	ErrHeaderNumberMismatch = errors.New("header number mismatch")
+	ErrParentHashMismatch   = errors.New("parent hash mismatch")
+)
+
+// Hash returns the blake2b-256 hash of the SCALE encoded header, which is the hash of its block.
+func (h Header) Hash() (Hash, error) {
+	enc, err := codec.Encode(h)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	return blake2b.Sum256(enc), nil
+}
+
+// VerifyHeaderChain checks that the provided headers form a chain, ordered by number: every header must have the
+// number following the one of the previous header, and the hash of the previous header as parent hash.
+func VerifyHeaderChain(headers []Header) error {
+	for i := 1; i < len(headers); i++ {
+		parent, header := headers[i-1], headers[i]
+
+		if header.Number != parent.Number+1 {
+			return fmt.Errorf("%w: header #%d follows header #%d", ErrHeaderNumberMismatch, header.Number, parent.Number)
+		}
+
+		parentHash, err := parent.Hash()
+		if err != nil {
+			return err
+		}
+
+		if header.ParentHash != parentHash {
+			return fmt.Errorf(
+				"%w: header #%d has parent hash %s instead of %s",
+				ErrParentHashMismatch,
+				header.Number,
+				header.ParentHash.Hex(),
+				parentHash.Hex(),
+			)
+		}
+	}
+
+	return nil
+}
+
 type BlockNumber U32
 
 // UnmarshalJSON fills BlockNumber with the JSON encoded byte array given by bz
@@ -19,6 +19,9 @@ package types_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
@@ -82,3 +85,48 @@ func TestHeader_Eq(t *testing.T) {
 		{exampleHeader, NewBool(false), false},
 	})
 }
+
+func TestHeader_Hash(t *testing.T) {
+	// The genesis header of Polkadot.
+	header := Header{
+		StateRoot:      NewHash(MustHexDecodeString("0x29d0d972cd27cbc511e9589fcb7a4506d5eb6a9e8df205f00472e5ab354a4e17")),
+		ExtrinsicsRoot: NewHash(MustHexDecodeString("0x03170a2e7597b7b7e3d84c05391d139a62b157e78786d8c082f29dcf4c111314")),
+	}
+
+	hash, err := header.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, NewHash(MustHexDecodeString("0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3")), hash)
+
+	// The digest is part of the hash.
+	hash, err = exampleHeader.Hash()
+	assert.NoError(t, err)
+
+	header = exampleHeader
+	header.Digest = header.Digest[:1]
+
+	otherHash, err := header.Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
+func TestVerifyHeaderChain(t *testing.T) {
+	headers := []Header{exampleHeader}
+
+	for i := 0; i < 3; i++ {
+		parentHash, err := headers[i].Hash()
+		require.NoError(t, err)
+
+		headers = append(headers, Header{ParentHash: parentHash, Number: headers[i].Number + 1})
+	}
+
+	assert.NoError(t, VerifyHeaderChain(headers))
+	assert.NoError(t, VerifyHeaderChain(headers[:1]))
+	assert.NoError(t, VerifyHeaderChain(nil))
+
+	tampered := append([]Header{}, headers...)
+	tampered[1].StateRoot = Hash{1}
+
+	assert.ErrorIs(t, VerifyHeaderChain(tampered), ErrParentHashMismatch)
+
+	assert.ErrorIs(t, VerifyHeaderChain([]Header{headers[0], headers[2]}), ErrHeaderNumberMismatch)
+}
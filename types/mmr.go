@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 )
@@ -32,7 +33,7 @@ func (d *GenerateMMRProofResponse) UnmarshalJSON(bz []byte) error {
 	if err != nil {
 		return err
 	}
-	err = codec.Decode(encodedLeaf, &d.Leaf)
+	d.Leaf, err = DecodeMMRLeaf(encodedLeaf)
 	if err != nil {
 		return err
 	}
@@ -62,8 +63,49 @@ type MMRLeaf struct {
 	ParachainHeads        H256
 }
 
+// MMRLeafVersion is the version of a MMR leaf, with the major version in the 3 most significant bits and the minor
+// version in the 5 least significant bits. The leaves of a minor version append fields to the leaves of the previous
+// minor versions.
 type MMRLeafVersion U8
 
+// NewMMRLeafVersion creates a new MMRLeafVersion
+func NewMMRLeafVersion(major, minor uint8) MMRLeafVersion {
+	return MMRLeafVersion(major<<5 | minor&0x1f)
+}
+
+// Major returns the major version
+func (v MMRLeafVersion) Major() uint8 {
+	return uint8(v) >> 5
+}
+
+// Minor returns the minor version
+func (v MMRLeafVersion) Minor() uint8 {
+	return uint8(v) & 0x1f
+}
+
+// mmrLeafLen is the length of the fields of the MMR leaves of major version 0.
+const mmrLeafLen = 113
+
+// DecodeMMRLeaf decodes the fields of the MMR leaf that are common to all the minor versions of major version 0,
+// ignoring the fields appended by the later minor versions.
+func DecodeMMRLeaf(opaque []byte) (MMRLeaf, error) {
+	var leaf MMRLeaf
+
+	if len(opaque) < mmrLeafLen {
+		return leaf, fmt.Errorf("MMR leaf of %d bytes, expected at least %d bytes", len(opaque), mmrLeafLen)
+	}
+
+	if err := codec.Decode(opaque, &leaf); err != nil {
+		return leaf, err
+	}
+
+	if major := leaf.Version.Major(); major != 0 {
+		return leaf, fmt.Errorf("unsupported MMR leaf major version %d", major)
+	}
+
+	return leaf, nil
+}
+
 type ParentNumberAndHash struct {
 	ParentNumber U32
 	Hash         Hash
@@ -77,3 +119,81 @@ type BeefyNextAuthoritySet struct {
 	// Merkle Root Hash build from BEEFY uncompressed AuthorityIds.
 	Root H256
 }
+
+// MMRBatchProof is the proof of several leaves of a MMR
+type MMRBatchProof struct {
+	// The indices of the leaves the proof is for.
+	LeafIndices []U64
+	// Number of leaves in MMR, when the proof was generated.
+	LeafCount U64
+	// Proof elements (hashes of siblings of inner nodes on the path to the leaves, and of the peaks).
+	Items []H256
+}
+
+// MMRLeavesProof is the proof of the leaves of the blocks returned by mmr_generateProof
+type MMRLeavesProof struct {
+	// BlockHash is the hash of the block the proof was generated at.
+	BlockHash H256
+	// Leaves are the SCALE encoded leaves, in the order of the leaf indices of the proof.
+	Leaves []MMREncodableOpaqueLeaf
+	Proof  MMRBatchProof
+}
+
+// DecodeLeaves decodes the leaves of the proof with DecodeMMRLeaf.
+func (p MMRLeavesProof) DecodeLeaves() ([]MMRLeaf, error) {
+	leaves := make([]MMRLeaf, 0, len(p.Leaves))
+
+	for _, opaque := range p.Leaves {
+		leaf, err := DecodeMMRLeaf(opaque)
+		if err != nil {
+			return nil, err
+		}
+
+		leaves = append(leaves, leaf)
+	}
+
+	return leaves, nil
+}
+
+type mmrLeavesProofJSON struct {
+	BlockHash string `json:"blockHash"`
+	Leaves    string `json:"leaves"`
+	Proof     string `json:"proof"`
+}
+
+// UnmarshalJSON fills p with the JSON encoded proof, whose leaves and proof are SCALE encoded
+func (p *MMRLeavesProof) UnmarshalJSON(bz []byte) error {
+	var tmp mmrLeavesProofJSON
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	if err := codec.DecodeFromHex(tmp.BlockHash, &p.BlockHash); err != nil {
+		return err
+	}
+
+	if err := codec.DecodeFromHex(tmp.Leaves, &p.Leaves); err != nil {
+		return err
+	}
+
+	return codec.DecodeFromHex(tmp.Proof, &p.Proof)
+}
+
+// MarshalJSON returns the JSON encoded proof, as expected by mmr_verifyProof
+func (p MMRLeavesProof) MarshalJSON() ([]byte, error) {
+	leaves, err := codec.EncodeToHex(p.Leaves)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := codec.EncodeToHex(p.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mmrLeavesProofJSON{
+		BlockHash: p.BlockHash.Hex(),
+		Leaves:    leaves,
+		Proof:     proof,
+	})
+}
@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGenerateMMRProofResponse_Unmarshal(t *testing.T) {
@@ -39,3 +41,69 @@ func TestGenerateMMRProofResponse_Unmarshal(t *testing.T) {
 
 	AssertEqual(t, unmarshalled, expected)
 }
+
+func TestMMRLeafVersion(t *testing.T) {
+	v := NewMMRLeafVersion(1, 2)
+	assert.Equal(t, MMRLeafVersion(0x22), v)
+	assert.Equal(t, uint8(1), v.Major())
+	assert.Equal(t, uint8(2), v.Minor())
+}
+
+func TestDecodeMMRLeaf(t *testing.T) {
+	leaf := MMRLeaf{
+		Version:               NewMMRLeafVersion(0, 1),
+		ParentNumberAndHash:   ParentNumberAndHash{ParentNumber: 10, Hash: NewHash([]byte{0x01})},
+		BeefyNextAuthoritySet: BeefyNextAuthoritySet{ID: 2, Len: 3, Root: NewH256([]byte{0x02})},
+		ParachainHeads:        NewH256([]byte{0x03}),
+	}
+
+	enc, err := Encode(leaf)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeMMRLeaf(enc)
+	assert.NoError(t, err)
+	assert.Equal(t, leaf, decoded)
+
+	// The fields appended by later minor versions are ignored.
+	decoded, err = DecodeMMRLeaf(append(enc, 0x01, 0x02))
+	assert.NoError(t, err)
+	assert.Equal(t, leaf, decoded)
+
+	_, err = DecodeMMRLeaf(enc[:len(enc)-1])
+	assert.Error(t, err)
+
+	enc[0] = byte(NewMMRLeafVersion(1, 0))
+	_, err = DecodeMMRLeaf(enc)
+	assert.Error(t, err)
+}
+
+func TestMMRLeavesProof_JSON(t *testing.T) {
+	leaf, err := Encode(MMRLeaf{ParentNumberAndHash: ParentNumberAndHash{ParentNumber: 4}})
+	assert.NoError(t, err)
+
+	proof := MMRLeavesProof{
+		BlockHash: NewH256([]byte{0x01}),
+		Leaves:    []MMREncodableOpaqueLeaf{leaf, leaf},
+		Proof: MMRBatchProof{
+			LeafIndices: []U64{4, 5},
+			LeafCount:   11,
+			Items:       []H256{NewH256([]byte{0x02}), NewH256([]byte{0x03})},
+		},
+	}
+
+	marshalled, err := json.Marshal(proof)
+	assert.NoError(t, err)
+
+	var jsonData map[string]string
+	assert.NoError(t, json.Unmarshal(marshalled, &jsonData))
+	assert.Equal(t, proof.BlockHash.Hex(), jsonData["blockHash"])
+
+	var unmarshalled MMRLeavesProof
+	assert.NoError(t, json.Unmarshal(marshalled, &unmarshalled))
+	assert.Equal(t, proof, unmarshalled)
+
+	leaves, err := unmarshalled.DecodeLeaves()
+	assert.NoError(t, err)
+	assert.Len(t, leaves, 2)
+	assert.Equal(t, U32(4), leaves[1].ParentNumberAndHash.ParentNumber)
+}
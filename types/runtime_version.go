@@ -31,6 +31,8 @@ type RuntimeVersion struct {
 	SpecName           string              `json:"specName"`
 	SpecVersion        U32                 `json:"specVersion"`
 	TransactionVersion U32                 `json:"transactionVersion"`
+	// StateVersion is the version of the trie layout used by the runtime, as a trie.StateVersion.
+	StateVersion U8 `json:"stateVersion"`
 }
 
 func NewRuntimeVersion() *RuntimeVersion {
@@ -0,0 +1,39 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// KeyTypeID is the 4 byte identifier of a session key type, e.g. "babe", "gran" or "imon"
+type KeyTypeID [4]byte
+
+// NewKeyTypeID creates a new KeyTypeID from its string representation. Only the first 4 bytes of the string are used.
+func NewKeyTypeID(s string) KeyTypeID {
+	var k KeyTypeID
+	copy(k[:], s)
+	return k
+}
+
+// String returns the string representation of the KeyTypeID
+func (k KeyTypeID) String() string {
+	return string(k[:])
+}
+
+// SessionKey is a single public session key together with its key type, as returned by the
+// SessionKeys_decode_session_keys runtime API
+type SessionKey struct {
+	PublicKey Bytes
+	KeyType   KeyTypeID
+}
@@ -0,0 +1,57 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	. "github.com/centrifuge/go-substrate-rpc-client/v4/types/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSessionKey = SessionKey{
+		PublicKey: NewBytes([]byte{0x01, 0x02, 0x03}),
+		KeyType:   NewKeyTypeID("babe"),
+	}
+)
+
+func TestKeyTypeID(t *testing.T) {
+	assert.Equal(t, KeyTypeID{'g', 'r', 'a', 'n'}, NewKeyTypeID("gran"))
+	assert.Equal(t, "imon", NewKeyTypeID("imon").String())
+	assert.Equal(t, KeyTypeID{'a', 'u', 'd', 'i'}, NewKeyTypeID("audio"))
+}
+
+func TestSessionKey_EncodeDecode(t *testing.T) {
+	AssertRoundtrip(t, testSessionKey)
+	AssertRoundTripFuzz[SessionKey](t, 100)
+	AssertEncodeEmptyObj[SessionKey](t, 5)
+}
+
+func TestSessionKey_Encode(t *testing.T) {
+	AssertEncode(t, []EncodingAssert{
+		{testSessionKey, MustHexDecodeString("0x0c01020362616265")},
+	})
+}
+
+func TestSessionKey_Decode(t *testing.T) {
+	AssertDecode(t, []DecodingAssert{
+		{MustHexDecodeString("0x0c01020362616265"), testSessionKey},
+	})
+}
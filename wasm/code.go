@@ -0,0 +1,137 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wasm inspects the WASM blobs of the Substrate runtimes, as found in the storage or in the files built by
+// the runtime crates, to check the code hash and the version of a runtime upgrade without running it.
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CodeKey is the storage key under which the runtime code is stored.
+const CodeKey = ":code"
+
+// CodeBombLimit is the maximum size of the decompressed code, as enforced by the nodes.
+const CodeBombLimit = 50 * 1024 * 1024
+
+var (
+	// zstdPrefix is the prefix of the zstd compressed code.
+	zstdPrefix = []byte{82, 188, 83, 118, 70, 219, 142, 5}
+	// wasmMagic is the magic number that the WASM modules start with.
+	wasmMagic = []byte{0x00, 'a', 's', 'm'}
+)
+
+// Code is the code of a runtime.
+type Code struct {
+	// Blob is the code as stored or set by system.set_code, which may be compressed.
+	Blob []byte
+	// WASM is the decompressed WASM module.
+	WASM []byte
+}
+
+// NewCode creates a new Code from the blob, which is decompressed if it has the zstd prefix.
+func NewCode(blob []byte) (*Code, error) {
+	module := blob
+
+	if bytes.HasPrefix(blob, zstdPrefix) {
+		var err error
+
+		module, err = decompress(blob[len(zstdPrefix):])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !bytes.HasPrefix(module, wasmMagic) {
+		return nil, ErrInvalidModule.WithMsg("missing wasm magic number")
+	}
+
+	return &Code{Blob: blob, WASM: module}, nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(CodeBombLimit))
+	if err != nil {
+		return nil, ErrDecompression.Wrap(err)
+	}
+
+	defer decoder.Close()
+
+	module, err := decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, ErrDecompression.Wrap(err)
+	}
+
+	return module, nil
+}
+
+// ReadFile reads the code from a .wasm or .compact.compressed.wasm file.
+func ReadFile(path string) (*Code, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrCodeRead.Wrap(err)
+	}
+
+	return NewCode(blob)
+}
+
+// Fetch returns the code stored at the block.
+func Fetch(ctx context.Context, stateRPC state.State, blockHash types.Hash) (*Code, error) {
+	raw, err := stateRPC.GetStorageRaw(ctx, types.NewStorageKey([]byte(CodeKey)), blockHash)
+	if err != nil {
+		return nil, ErrCodeRetrieval.Wrap(err)
+	}
+
+	return newStoredCode(raw)
+}
+
+// FetchLatest returns the code stored at the latest block.
+func FetchLatest(ctx context.Context, stateRPC state.State) (*Code, error) {
+	raw, err := stateRPC.GetStorageRawLatest(ctx, types.NewStorageKey([]byte(CodeKey)))
+	if err != nil {
+		return nil, ErrCodeRetrieval.Wrap(err)
+	}
+
+	return newStoredCode(raw)
+}
+
+func newStoredCode(raw *types.StorageDataRaw) (*Code, error) {
+	if raw == nil || len(*raw) == 0 {
+		return nil, ErrCodeNotFound
+	}
+
+	return NewCode(*raw)
+}
+
+// Hash returns the blake2b-256 hash of the blob, which is the code hash expected by system.authorize_upgrade.
+func (c *Code) Hash() (types.Hash, error) {
+	h, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	h.Write(c.Blob)
+
+	return types.NewHash(h.Sum(nil)), nil
+}
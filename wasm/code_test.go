@@ -0,0 +1,167 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state/mocks"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestModule returns a WASM module with the provided custom sections, and a type section.
+func newTestModule(sections ...[2][]byte) []byte {
+	module := append([]byte{}, wasmMagic...)
+	module = append(module, 1, 0, 0, 0)
+
+	// An empty type section.
+	module = append(module, 1, 1, 0)
+
+	for _, section := range sections {
+		content := append(appendLEB128(nil, uint64(len(section[0]))), section[0]...)
+		content = append(content, section[1]...)
+
+		module = append(module, customSectionID)
+		module = appendLEB128(module, uint64(len(content)))
+		module = append(module, content...)
+	}
+
+	return module
+}
+
+func appendLEB128(data []byte, value uint64) []byte {
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+
+		if value == 0 {
+			return append(data, b)
+		}
+
+		data = append(data, b|0x80)
+	}
+}
+
+func compressTestModule(t *testing.T, module []byte) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+
+	defer encoder.Close()
+
+	return append(append([]byte{}, zstdPrefix...), encoder.EncodeAll(module, nil)...)
+}
+
+func TestNewCode(t *testing.T) {
+	module := newTestModule()
+
+	code, err := NewCode(module)
+	assert.NoError(t, err)
+	assert.Equal(t, module, code.Blob)
+	assert.Equal(t, module, code.WASM)
+
+	compressed := compressTestModule(t, module)
+
+	code, err = NewCode(compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, compressed, code.Blob)
+	assert.Equal(t, module, code.WASM)
+
+	_, err = NewCode(append(append([]byte{}, zstdPrefix...), 0x01, 0x02))
+	assert.ErrorIs(t, err, ErrDecompression)
+
+	_, err = NewCode([]byte{0x01, 0x02})
+	assert.ErrorIs(t, err, ErrInvalidModule)
+}
+
+func TestNewCode_BombLimit(t *testing.T) {
+	module := append(newTestModule(), make([]byte, CodeBombLimit)...)
+
+	_, err := NewCode(compressTestModule(t, module))
+	assert.ErrorIs(t, err, ErrDecompression)
+}
+
+func TestCode_Hash(t *testing.T) {
+	code, err := NewCode(newTestModule())
+	require.NoError(t, err)
+
+	hash, err := code.Hash()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		types.NewHash(codec.MustHexDecodeString("0x19cd468f37d63d048d19f3974461033c8575ec743ad51cdd5b703bdc01b788b1")),
+		hash,
+	)
+}
+
+func TestReadFile(t *testing.T) {
+	module := newTestModule()
+	path := filepath.Join(t.TempDir(), "runtime.compact.compressed.wasm")
+
+	require.NoError(t, os.WriteFile(path, compressTestModule(t, module), 0600))
+
+	code, err := ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, module, code.WASM)
+
+	_, err = ReadFile(filepath.Join(t.TempDir(), "missing.wasm"))
+	assert.ErrorIs(t, err, ErrCodeRead)
+}
+
+func TestFetch(t *testing.T) {
+	stateRPCMock := mocks.NewState(t)
+
+	module := newTestModule()
+	blob := types.StorageDataRaw(compressTestModule(t, module))
+	key := types.NewStorageKey([]byte(CodeKey))
+	blockHash := types.NewHash([]byte{0x01})
+
+	stateRPCMock.On("GetStorageRaw", mock.Anything, key, blockHash).Return(&blob, nil).Once()
+
+	code, err := Fetch(context.Background(), stateRPCMock, blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, module, code.WASM)
+
+	stateRPCMock.On("GetStorageRaw", mock.Anything, key, blockHash).Return(&types.StorageDataRaw{}, nil).Once()
+
+	_, err = Fetch(context.Background(), stateRPCMock, blockHash)
+	assert.ErrorIs(t, err, ErrCodeNotFound)
+
+	stateRPCMock.On("GetStorageRaw", mock.Anything, key, blockHash).Return(nil, errors.New("error")).Once()
+
+	_, err = Fetch(context.Background(), stateRPCMock, blockHash)
+	assert.ErrorIs(t, err, ErrCodeRetrieval)
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, key).Return(&blob, nil).Once()
+
+	code, err = FetchLatest(context.Background(), stateRPCMock)
+	assert.NoError(t, err)
+	assert.Equal(t, module, code.WASM)
+
+	stateRPCMock.On("GetStorageRawLatest", mock.Anything, key).Return(nil, errors.New("error")).Once()
+
+	_, err = FetchLatest(context.Background(), stateRPCMock)
+	assert.ErrorIs(t, err, ErrCodeRetrieval)
+}
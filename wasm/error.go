@@ -0,0 +1,31 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import libErr "github.com/centrifuge/go-substrate-rpc-client/v4/error"
+
+const (
+	ErrCodeRetrieval           = libErr.Error("code retrieval")
+	ErrCodeNotFound            = libErr.Error("code not found")
+	ErrCodeRead                = libErr.Error("code read")
+	ErrDecompression           = libErr.Error("code decompression")
+	ErrInvalidModule           = libErr.Error("invalid wasm module")
+	ErrMissingSection          = libErr.Error("missing custom section")
+	ErrSectionDecoding         = libErr.Error("custom section decoding")
+	ErrInvalidSpecName         = libErr.Error("spec name mismatch")
+	ErrSpecVersionNotIncreased = libErr.Error("spec version needs to increase")
+)
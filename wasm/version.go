@@ -0,0 +1,249 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+const (
+	// RuntimeVersionSection is the custom section holding the SCALE encoded version of the runtime.
+	RuntimeVersionSection = "runtime_version"
+	// RuntimeAPIsSection is the custom section holding the IDs and versions of the runtime APIs.
+	RuntimeAPIsSection = "runtime_apis"
+
+	// coreAPIID is the ID of the Core runtime API, whose version tells the fields of the runtime version.
+	coreAPIID = "0xdf6acb689907609b"
+
+	// runtimeAPISize is the size of an API in the runtime APIs section, an 8 bytes ID followed by a u32 version.
+	runtimeAPISize = 12
+
+	customSectionID = 0
+	wasmHeaderSize  = 8
+)
+
+// RuntimeVersion returns the version embedded in the custom sections of the code, which is the version returned by
+// state_getRuntimeVersion once the code is enacted.
+func (c *Code) RuntimeVersion() (*types.RuntimeVersion, error) {
+	versionSection, ok, err := CustomSection(c.WASM, RuntimeVersionSection)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrMissingSection.WithMsg("%s", RuntimeVersionSection)
+	}
+
+	apisSection, hasAPIs, err := CustomSection(c.WASM, RuntimeAPIsSection)
+	if err != nil {
+		return nil, err
+	}
+
+	var apis []types.RuntimeVersionAPI
+
+	if hasAPIs {
+		apis, err = decodeRuntimeAPIs(apisSection)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	version, err := decodeRuntimeVersion(versionSection, apis)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasAPIs {
+		version.APIs = apis
+	}
+
+	return version, nil
+}
+
+// decodeRuntimeAPIs decodes the runtime APIs section, which is not length prefixed.
+func decodeRuntimeAPIs(section []byte) ([]types.RuntimeVersionAPI, error) {
+	if len(section)%runtimeAPISize != 0 {
+		return nil, ErrSectionDecoding.WithMsg("%s size %d", RuntimeAPIsSection, len(section))
+	}
+
+	apis := make([]types.RuntimeVersionAPI, 0, len(section)/runtimeAPISize)
+
+	for i := 0; i < len(section); i += runtimeAPISize {
+		apis = append(apis, types.RuntimeVersionAPI{
+			APIID:   codec.HexEncodeToString(section[i : i+8]),
+			Version: types.U32(binary.LittleEndian.Uint32(section[i+8 : i+runtimeAPISize])),
+		})
+	}
+
+	return apis, nil
+}
+
+// decodeRuntimeVersion decodes the runtime version section. The transaction version and the state version were
+// added with the versions 3 and 4 of the Core API, which tells whether they are encoded.
+func decodeRuntimeVersion(section []byte, apis []types.RuntimeVersionAPI) (*types.RuntimeVersion, error) {
+	r := bytes.NewReader(section)
+	decoder := scale.NewDecoder(r)
+
+	version := types.NewRuntimeVersion()
+
+	var encodedAPIs []struct {
+		ID      [8]byte
+		Version types.U32
+	}
+
+	for _, target := range []interface{}{
+		&version.SpecName,
+		&version.ImplName,
+		&version.AuthoringVersion,
+		&version.SpecVersion,
+		&version.ImplVersion,
+		&encodedAPIs,
+	} {
+		if err := decoder.Decode(target); err != nil {
+			return nil, ErrSectionDecoding.Wrap(err)
+		}
+	}
+
+	for _, api := range encodedAPIs {
+		version.APIs = append(version.APIs, types.RuntimeVersionAPI{
+			APIID:   codec.HexEncodeToString(api.ID[:]),
+			Version: api.Version,
+		})
+	}
+
+	if apis == nil {
+		apis = version.APIs
+	}
+
+	coreVersion, hasCore := findAPIVersion(apis, coreAPIID)
+
+	version.TransactionVersion = 1
+
+	if (hasCore && coreVersion >= 3) || (!hasCore && r.Len() > 0) {
+		if err := decoder.Decode(&version.TransactionVersion); err != nil {
+			return nil, ErrSectionDecoding.Wrap(err)
+		}
+	}
+
+	if (hasCore && coreVersion >= 4) || (!hasCore && r.Len() > 0) {
+		if err := decoder.Decode(&version.StateVersion); err != nil {
+			return nil, ErrSectionDecoding.Wrap(err)
+		}
+	}
+
+	return version, nil
+}
+
+func findAPIVersion(apis []types.RuntimeVersionAPI, id string) (types.U32, bool) {
+	for _, api := range apis {
+		if api.APIID == id {
+			return api.Version, true
+		}
+	}
+
+	return 0, false
+}
+
+// CustomSection returns the content of the first custom section of the WASM module with the provided name, and
+// false if there is none.
+func CustomSection(module []byte, name string) ([]byte, bool, error) {
+	if len(module) < wasmHeaderSize || !bytes.HasPrefix(module, wasmMagic) {
+		return nil, false, ErrInvalidModule.WithMsg("missing wasm header")
+	}
+
+	data := module[wasmHeaderSize:]
+
+	for len(data) > 0 {
+		id := data[0]
+
+		size, n, err := readLEB128(data[1:])
+		if err != nil {
+			return nil, false, err
+		}
+
+		data = data[1+n:]
+
+		if uint64(len(data)) < size {
+			return nil, false, ErrInvalidModule.WithMsg("section size %d exceeds the module", size)
+		}
+
+		content := data[:size]
+		data = data[size:]
+
+		if id != customSectionID {
+			continue
+		}
+
+		nameLen, n, err := readLEB128(content)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if uint64(len(content)-n) < nameLen {
+			return nil, false, ErrInvalidModule.WithMsg("custom section name length %d exceeds the section", nameLen)
+		}
+
+		if string(content[n:n+int(nameLen)]) == name {
+			return content[n+int(nameLen):], true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// readLEB128 reads an unsigned LEB128 integer of at most 32 bits, as used for the sizes in the WASM modules, and
+// returns it with the number of bytes read.
+func readLEB128(data []byte) (uint64, int, error) {
+	var value uint64
+
+	for i := 0; i < 5; i++ {
+		if i >= len(data) {
+			return 0, 0, ErrInvalidModule.WithMsg("truncated LEB128 integer")
+		}
+
+		value |= uint64(data[i]&0x7f) << (7 * i)
+
+		if data[i]&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+
+	return 0, 0, ErrInvalidModule.WithMsg("LEB128 integer too long")
+}
+
+// CheckUpgrade checks that the proposed runtime can replace the current one, as checked by system.set_code: the
+// spec names must match and the spec version must increase.
+func CheckUpgrade(current, proposed *types.RuntimeVersion) error {
+	if current.SpecName != proposed.SpecName {
+		return ErrInvalidSpecName.WithMsg("current %s, proposed %s", current.SpecName, proposed.SpecName)
+	}
+
+	if proposed.SpecVersion <= current.SpecVersion {
+		return ErrSpecVersionNotIncreased.WithMsg(
+			"current %d, proposed %d",
+			current.SpecVersion,
+			proposed.SpecVersion,
+		)
+	}
+
+	return nil
+}
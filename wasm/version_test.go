@@ -0,0 +1,190 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wasm
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testVersionSection is the runtime version section as encoded by the runtime_version macro, with the APIs left
+// to the runtime APIs section.
+type testVersionSection struct {
+	SpecName           string
+	ImplName           string
+	AuthoringVersion   types.U32
+	SpecVersion        types.U32
+	ImplVersion        types.U32
+	APIs               types.U8
+	TransactionVersion types.U32
+	StateVersion       types.U8
+}
+
+var testAPIsSection = codec.MustHexDecodeString(
+	"0xdf6acb689907609b" + "04000000" + "37e397fc7c91f5e4" + "02000000",
+)
+
+func newTestVersionSection(t *testing.T) []byte {
+	section, err := codec.Encode(testVersionSection{
+		SpecName:           "node",
+		ImplName:           "node-impl",
+		AuthoringVersion:   10,
+		SpecVersion:        268,
+		ImplVersion:        1,
+		TransactionVersion: 2,
+		StateVersion:       1,
+	})
+	require.NoError(t, err)
+
+	return section
+}
+
+func TestCode_RuntimeVersion(t *testing.T) {
+	module := newTestModule(
+		[2][]byte{[]byte("other"), {0x01}},
+		[2][]byte{[]byte(RuntimeVersionSection), newTestVersionSection(t)},
+		[2][]byte{[]byte(RuntimeAPIsSection), testAPIsSection},
+	)
+
+	code, err := NewCode(compressTestModule(t, module))
+	require.NoError(t, err)
+
+	version, err := code.RuntimeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, &types.RuntimeVersion{
+		APIs: []types.RuntimeVersionAPI{
+			{APIID: "0xdf6acb689907609b", Version: 4},
+			{APIID: "0x37e397fc7c91f5e4", Version: 2},
+		},
+		AuthoringVersion:   10,
+		ImplName:           "node-impl",
+		ImplVersion:        1,
+		SpecName:           "node",
+		SpecVersion:        268,
+		TransactionVersion: 2,
+		StateVersion:       1,
+	}, version)
+}
+
+func TestCode_RuntimeVersion_CoreVersion(t *testing.T) {
+	// The version 3 of the Core API has no state version, which defaults to 0.
+	apis := codec.MustHexDecodeString("0xdf6acb689907609b" + "03000000")
+
+	module := newTestModule(
+		[2][]byte{[]byte(RuntimeVersionSection), newTestVersionSection(t)},
+		[2][]byte{[]byte(RuntimeAPIsSection), apis},
+	)
+
+	code, err := NewCode(module)
+	require.NoError(t, err)
+
+	version, err := code.RuntimeVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(2), version.TransactionVersion)
+	assert.Equal(t, types.U8(0), version.StateVersion)
+
+	// Without the runtime APIs section, the encoded fields are decoded.
+	module = newTestModule([2][]byte{[]byte(RuntimeVersionSection), newTestVersionSection(t)})
+
+	code, err = NewCode(module)
+	require.NoError(t, err)
+
+	version, err = code.RuntimeVersion()
+	assert.NoError(t, err)
+	assert.Empty(t, version.APIs)
+	assert.Equal(t, types.U32(2), version.TransactionVersion)
+	assert.Equal(t, types.U8(1), version.StateVersion)
+}
+
+func TestCode_RuntimeVersion_Errors(t *testing.T) {
+	code, err := NewCode(newTestModule())
+	require.NoError(t, err)
+
+	_, err = code.RuntimeVersion()
+	assert.ErrorIs(t, err, ErrMissingSection)
+
+	code, err = NewCode(newTestModule(
+		[2][]byte{[]byte(RuntimeVersionSection), newTestVersionSection(t)},
+		[2][]byte{[]byte(RuntimeAPIsSection), testAPIsSection[:11]},
+	))
+	require.NoError(t, err)
+
+	_, err = code.RuntimeVersion()
+	assert.ErrorIs(t, err, ErrSectionDecoding)
+
+	code, err = NewCode(newTestModule(
+		[2][]byte{[]byte(RuntimeVersionSection), newTestVersionSection(t)[:10]},
+		[2][]byte{[]byte(RuntimeAPIsSection), testAPIsSection},
+	))
+	require.NoError(t, err)
+
+	_, err = code.RuntimeVersion()
+	assert.ErrorIs(t, err, ErrSectionDecoding)
+}
+
+func TestCustomSection(t *testing.T) {
+	module := newTestModule(
+		[2][]byte{[]byte("first"), {0x01}},
+		[2][]byte{[]byte("second"), make([]byte, 200)},
+		[2][]byte{[]byte("first"), {0x02}},
+	)
+
+	section, ok, err := CustomSection(module, "first")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x01}, section)
+
+	section, ok, err = CustomSection(module, "second")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, section, 200)
+
+	_, ok, err = CustomSection(module, "third")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = CustomSection(module[:len(module)-1], "third")
+	assert.ErrorIs(t, err, ErrInvalidModule)
+
+	_, _, err = CustomSection(append(newTestModule(), 0, 0x80, 0x80), "first")
+	assert.ErrorIs(t, err, ErrInvalidModule)
+
+	_, _, err = CustomSection(append(newTestModule(), 0, 0xff, 0xff, 0xff, 0xff, 0xff), "first")
+	assert.ErrorIs(t, err, ErrInvalidModule)
+
+	_, _, err = CustomSection(append(newTestModule(), 0, 1, 5), "first")
+	assert.ErrorIs(t, err, ErrInvalidModule)
+
+	_, _, err = CustomSection([]byte{0x01}, "first")
+	assert.ErrorIs(t, err, ErrInvalidModule)
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	current := &types.RuntimeVersion{SpecName: "node", SpecVersion: 10}
+
+	assert.NoError(t, CheckUpgrade(current, &types.RuntimeVersion{SpecName: "node", SpecVersion: 11}))
+
+	err := CheckUpgrade(current, &types.RuntimeVersion{SpecName: "other", SpecVersion: 11})
+	assert.ErrorIs(t, err, ErrInvalidSpecName)
+
+	err = CheckUpgrade(current, &types.RuntimeVersion{SpecName: "node", SpecVersion: 10})
+	assert.ErrorIs(t, err, ErrSpecVersionNotIncreased)
+}